@@ -0,0 +1,295 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package minpk
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/signature"
+	"golang.org/x/crypto/hkdf"
+)
+
+var (
+	errInvalidPublicKey = errors.New("invalid public key: point at infinity")
+	errShortIKM         = errors.New("key material must be at least 32 bytes")
+	errEmptyList        = errors.New("empty list")
+	errLengthMismatch   = errors.New("number of public keys and messages differ")
+)
+
+const (
+	sizeFr         = fr.Bytes
+	sizePublicKey  = bls12377.SizeOfG1AffineCompressed
+	sizeSignature  = bls12377.SizeOfG2AffineCompressed
+	sizePrivateKey = sizePublicKey + sizeFr
+)
+
+// suiteID identifies the ciphersuite, the domain separation tags
+// of the signatures and of the proofs of possession derive from it
+const suiteID = "BLS12377G2_XMD:SHA-256_SVDW_RO_POP_"
+
+var (
+	dstSignature = []byte("BLS_SIG_" + suiteID)
+	dstPoP       = []byte("BLS_POP_" + suiteID)
+)
+
+// PublicKey bls public key, pk = sk * g1
+type PublicKey struct {
+	A bls12377.G1Affine
+}
+
+// PrivateKey private key of a bls instance
+type PrivateKey struct {
+	PublicKey PublicKey    // copy of the associated public key
+	scalar    [sizeFr]byte // secret scalar, in big Endian
+}
+
+// Signature represents a bls signature, s = sk * H(m)
+type Signature struct {
+	S bls12377.G2Affine
+}
+
+func init() {
+	signature.Register(signature.BLS_MINPK_BLS12_377, GenerateKeyInterfaces)
+}
+
+// GenerateKey generates a public and private key pair from 32 bytes read from r.
+func GenerateKey(r io.Reader) (PrivateKey, error) {
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(r, ikm); err != nil {
+		return PrivateKey{}, err
+	}
+	return KeyGen(ikm, nil)
+}
+
+// KeyGen derives a key pair from the secret input keying material ikm (at least 32 bytes)
+// and the optional keyInfo.
+// https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05#section-2.3
+func KeyGen(ikm, keyInfo []byte) (PrivateKey, error) {
+
+	var priv PrivateKey
+
+	if len(ikm) < 32 {
+		return priv, errShortIKM
+	}
+
+	// L = ceil((3 * ceil(log2(r))) / 16)
+	const L = 48
+
+	// IKM || I2OSP(0, 1)
+	_ikm := make([]byte, len(ikm)+1)
+	copy(_ikm, ikm)
+
+	// key_info || I2OSP(L, 2)
+	info := make([]byte, len(keyInfo)+2)
+	copy(info, keyInfo)
+	info[len(keyInfo)+1] = L
+
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+	okm := make([]byte, L)
+	var sk big.Int
+	for sk.Sign() == 0 {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		if _, err := io.ReadFull(hkdf.New(sha256.New, _ikm, salt, info), okm); err != nil {
+			return priv, err
+		}
+		sk.SetBytes(okm).Mod(&sk, fr.Modulus())
+	}
+	sk.FillBytes(priv.scalar[:])
+
+	g := generator()
+	priv.PublicKey.A.ScalarMultiplication(&g, &sk)
+
+	return priv, nil
+}
+
+// GenerateKeyInterfaces generate interfaces for the public/private key.
+// This purpose of this function is to be registered in the list of signature schemes.
+func GenerateKeyInterfaces(r io.Reader) (signature.Signer, error) {
+	priv, err := GenerateKey(r)
+	return &priv, err
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKey) Equal(other signature.PublicKey) bool {
+	bpk := pub.Bytes()
+	bother := other.Bytes()
+	return subtle.ConstantTimeCompare(bpk, bother) == 1
+}
+
+// Public returns the public key associated to the private key.
+// From Signer interface defined in gnark/crypto/signature.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Sign signs a message.
+// If hFunc is not nil the message is first digested with hFunc, the digest
+// is then hashed to G2 with the domain separation tag of the ciphersuite.
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	res, err := privKey.sign(digest(message, hFunc), dstSignature)
+	if err != nil {
+		return nil, err
+	}
+	return res.Bytes(), nil
+}
+
+// ProvePossession returns a proof of possession of the private key,
+// that is a signature of the public key with the proof of possession domain separation tag.
+// https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05#section-3.3.2
+func (privKey *PrivateKey) ProvePossession() (Signature, error) {
+	return privKey.sign(privKey.PublicKey.Bytes(), dstPoP)
+}
+
+// sign returns sk * H(message)
+func (privKey *PrivateKey) sign(message, dst []byte) (Signature, error) {
+	var res Signature
+	h, err := bls12377.HashToCurveG2Svdw(message, dst)
+	if err != nil {
+		return res, err
+	}
+	var sk big.Int
+	sk.SetBytes(privKey.scalar[:])
+	res.S.ScalarMultiplication(&h, &sk)
+	return res, nil
+}
+
+// Verify verifies a bls signature.
+// If hFunc is not nil the message is first digested with hFunc, as in Sign.
+func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	return verify([]PublicKey{*pub}, [][]byte{digest(message, hFunc)}, &sig, dstSignature)
+}
+
+// VerifyPossession verifies the proof of possession of the private key associated to pub.
+// https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05#section-3.3.3
+func (pub *PublicKey) VerifyPossession(proof *Signature) (bool, error) {
+	return verify([]PublicKey{*pub}, [][]byte{pub.Bytes()}, proof, dstPoP)
+}
+
+// Aggregate aggregates signatures in a single one.
+// https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05#section-2.8
+func Aggregate(signatures []Signature) (Signature, error) {
+	var res Signature
+	if len(signatures) == 0 {
+		return res, errEmptyList
+	}
+	var acc bls12377.G2Jac
+	acc.FromAffine(&signatures[0].S)
+	for i := 1; i < len(signatures); i++ {
+		acc.AddMixed(&signatures[i].S)
+	}
+	res.S.FromJacobian(&acc)
+	return res, nil
+}
+
+// AggregatePublicKeys aggregates public keys in a single one.
+// The aggregated key is only meaningful if each public key
+// comes with a valid proof of possession.
+func AggregatePublicKeys(publicKeys []PublicKey) (PublicKey, error) {
+	var res PublicKey
+	if len(publicKeys) == 0 {
+		return res, errEmptyList
+	}
+	var acc bls12377.G1Jac
+	acc.FromAffine(&publicKeys[0].A)
+	for i := 1; i < len(publicKeys); i++ {
+		acc.AddMixed(&publicKeys[i].A)
+	}
+	res.A.FromJacobian(&acc)
+	return res, nil
+}
+
+// FastAggregateVerify verifies an aggregated signature of a same message by several signers.
+// The public keys must have been checked with VerifyPossession beforehand.
+// https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05#section-3.3.4
+func FastAggregateVerify(publicKeys []PublicKey, message []byte, sig *Signature) (bool, error) {
+	pk, err := AggregatePublicKeys(publicKeys)
+	if err != nil {
+		return false, err
+	}
+	return verify([]PublicKey{pk}, [][]byte{message}, sig, dstSignature)
+}
+
+// AggregateVerify verifies an aggregated signature, where publicKeys[i] signed messages[i].
+// https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05#section-2.9
+func AggregateVerify(publicKeys []PublicKey, messages [][]byte, sig *Signature) (bool, error) {
+	if len(publicKeys) != len(messages) {
+		return false, errLengthMismatch
+	}
+	if len(publicKeys) == 0 {
+		return false, errEmptyList
+	}
+	return verify(publicKeys, messages, sig, dstSignature)
+}
+
+// verify checks that ∏ e(pk_i, H(m_i)) == e(g, s)
+func verify(publicKeys []PublicKey, messages [][]byte, sig *Signature, dst []byte) (bool, error) {
+
+	if !sig.S.IsInSubGroup() {
+		return false, nil
+	}
+
+	pks := make([]bls12377.G1Affine, len(publicKeys)+1)
+	hs := make([]bls12377.G2Affine, len(messages)+1)
+	for i := 0; i < len(publicKeys); i++ {
+		if publicKeys[i].A.IsInfinity() {
+			return false, errInvalidPublicKey
+		}
+		if !publicKeys[i].A.IsInSubGroup() {
+			return false, nil
+		}
+		pks[i].Set(&publicKeys[i].A)
+		h, err := bls12377.HashToCurveG2Svdw(messages[i], dst)
+		if err != nil {
+			return false, err
+		}
+		hs[i] = h
+	}
+	g := generator()
+	pks[len(publicKeys)].Neg(&g)
+	hs[len(messages)].Set(&sig.S)
+	return bls12377.PairingCheck(pks, hs)
+}
+
+// generator returns the generator of the group of the public keys
+func generator() bls12377.G1Affine {
+	_, _, g, _ := bls12377.Generators()
+	return g
+}
+
+// digest returns hFunc(message), or message if hFunc is nil
+func digest(message []byte, hFunc hash.Hash) []byte {
+	if hFunc == nil {
+		return message
+	}
+	hFunc.Reset()
+	hFunc.Write(message)
+	return hFunc.Sum(nil)
+}
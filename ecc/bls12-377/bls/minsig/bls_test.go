@@ -0,0 +1,325 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package minsig
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	crand "crypto/rand"
+
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+func Example() {
+	// create a bls key pair
+	privateKey, _ := signature.BLS_MINSIG_BLS12_377.New(crand.Reader)
+	publicKey := privateKey.Public()
+
+	msg := []byte("message")
+
+	// sign the message, it is hashed to the curve by the scheme
+	signature, _ := privateKey.Sign(msg, nil)
+
+	// verifies signature
+	isValid, _ := publicKey.Verify(signature, msg, nil)
+	if !isValid {
+		fmt.Println("1. invalid signature")
+	} else {
+		fmt.Println("1. valid signature")
+	}
+
+	// Output: 1. valid signature
+}
+
+func TestSerialization(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	privKey1, err := signature.BLS_MINSIG_BLS12_377.New(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey1 := privKey1.Public()
+
+	privKey2, err := signature.BLS_MINSIG_BLS12_377.New(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey2 := privKey2.Public()
+
+	pubKeyBin1 := pubKey1.Bytes()
+	if _, err := pubKey2.SetBytes(pubKeyBin1); err != nil {
+		t.Fatal(err)
+	}
+	if !pubKey1.Equal(pubKey2) {
+		t.Fatal("Error serialize(deserialize(.))")
+	}
+
+	privKeyBin1 := privKey1.Bytes()
+	if _, err := privKey2.SetBytes(privKeyBin1); err != nil {
+		t.Fatal(err)
+	}
+	privKeyBin2 := privKey2.Bytes()
+	if len(privKeyBin1) != len(privKeyBin2) {
+		t.Fatal("Inconistent size")
+	}
+	for i := 0; i < len(privKeyBin1); i++ {
+		if privKeyBin1[i] != privKeyBin2[i] {
+			t.Fatal("Error serialize(deserialize(.))")
+		}
+	}
+
+	// the point at infinity is not a valid public key
+	var pk PublicKey
+	if _, err := pk.SetBytes(pk.Bytes()); err == nil {
+		t.Fatal("point at infinity should be rejected")
+	}
+}
+
+func TestKeyGen(t *testing.T) {
+
+	ikm := make([]byte, 32)
+	for i := 0; i < len(ikm); i++ {
+		ikm[i] = byte(i)
+	}
+
+	privKey1, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKey2, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !privKey1.PublicKey.Equal(&privKey2.PublicKey) {
+		t.Fatal("KeyGen should be deterministic")
+	}
+
+	privKey3, err := KeyGen(ikm, []byte("info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if privKey1.PublicKey.Equal(&privKey3.PublicKey) {
+		t.Fatal("KeyGen should depend on the key info")
+	}
+
+	if _, err := KeyGen(ikm[:31], nil); err == nil {
+		t.Fatal("KeyGen should reject short key material")
+	}
+}
+
+func TestBLS(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	privKey, err := GenerateKey(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := privKey.Public()
+
+	signature, err := privKey.Sign([]byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// verifies correct msg
+	res, err := pubKey.Verify(signature, []byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("Verifiy correct signature should return true")
+	}
+
+	// verifies wrong msg
+	res, err = pubKey.Verify(signature, []byte("wrong_message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("Verfiy wrong signature should be false")
+	}
+
+	// pre-hashed message
+	signature, err = privKey.Sign([]byte("message"), sha256.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = pubKey.Verify(signature, []byte("message"), sha256.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("Verifiy correct signature should return true")
+	}
+	res, err = pubKey.Verify(signature, []byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("Verfiy signature with a different hash should be false")
+	}
+
+	// proof of possession
+	proof, err := privKey.ProvePossession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = privKey.PublicKey.VerifyPossession(&proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("VerifyPossession of a correct proof should return true")
+	}
+	otherKey, err := GenerateKey(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = otherKey.PublicKey.VerifyPossession(&proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("VerifyPossession of a wrong proof should be false")
+	}
+}
+
+func TestAggregate(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	const nbSigners = 4
+	privKeys := make([]PrivateKey, nbSigners)
+	pubKeys := make([]PublicKey, nbSigners)
+	for i := 0; i < nbSigners; i++ {
+		var err error
+		privKeys[i], err = GenerateKey(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKeys[i] = privKeys[i].PublicKey
+	}
+
+	// same message
+	msg := []byte("message")
+	sigs := make([]Signature, nbSigners)
+	for i := 0; i < nbSigners; i++ {
+		var err error
+		sigs[i], err = privKeys[i].sign(msg, dstSignature)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	aggSig, err := Aggregate(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := FastAggregateVerify(pubKeys, msg, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("FastAggregateVerify of a correct signature should return true")
+	}
+	res, err = FastAggregateVerify(pubKeys[1:], msg, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("FastAggregateVerify with a missing signer should be false")
+	}
+
+	// distinct messages
+	msgs := make([][]byte, nbSigners)
+	for i := 0; i < nbSigners; i++ {
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		sigs[i], err = privKeys[i].sign(msgs[i], dstSignature)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	aggSig, err = Aggregate(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = AggregateVerify(pubKeys, msgs, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("AggregateVerify of a correct signature should return true")
+	}
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+	res, err = AggregateVerify(pubKeys, msgs, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("AggregateVerify with swapped messages should be false")
+	}
+
+	if _, err := AggregateVerify(pubKeys, msgs[1:], &aggSig); err == nil {
+		t.Fatal("AggregateVerify should reject lists of different lengths")
+	}
+	if _, err := Aggregate(nil); err == nil {
+		t.Fatal("Aggregate should reject an empty list")
+	}
+}
+
+// benchmarks
+
+func BenchmarkSign(b *testing.B) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	privKey, err := GenerateKey(r)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.Sign([]byte("message"), nil)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	privKey, err := GenerateKey(r)
+	if err != nil {
+		b.Fatal(err)
+	}
+	pubKey := privKey.Public()
+	signature, _ := privKey.Sign([]byte("message"), nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pubKey.Verify(signature, []byte("message"), nil)
+	}
+}
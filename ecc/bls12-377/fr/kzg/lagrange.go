@@ -0,0 +1,237 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	"github.com/consensys/gnark-crypto/pool"
+)
+
+// LagrangeSRS stores the SRS in the Lagrange basis of a fft.Domain:
+// G1[i] = [L_i(alpha)]gen, where L_i is the i-th Lagrange polynomial of the domain,
+// L_i(ω^j) = 1 if i == j, 0 otherwise (natural order).
+type LagrangeSRS struct {
+	G1 []bls12377.G1Affine  // [L_0(alpha)]gen, [L_1(alpha)]gen, ...
+	G2 [2]bls12377.G2Affine // [gen, [alpha]gen ]
+}
+
+// NewLagrangeSRS returns the Lagrange basis of srs over domain.
+// It runs an inverse FFT on the first domain.Cardinality points of srs.G1:
+// [L_i(alpha)]gen = 1/n * Σ_j ω^(-ij) [alpha^j]gen.
+// The pool of the computations is set with WithPool.
+func NewLagrangeSRS(srs *SRS, domain *fft.Domain, opts ...Option) (*LagrangeSRS, error) {
+	n := int(domain.Cardinality)
+	if n > len(srs.G1) {
+		return nil, ErrLagrangeSRSSize
+	}
+	o := newOptions(opts)
+
+	points := make([]bls12377.G1Jac, n)
+	o.pool.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			points[i].FromAffine(&srs.G1[i])
+		}
+	})
+
+	// inverse fft, the result is in bit reversed order
+	difFFTG1(points, domain.TwiddlesInv, 0, o.pool)
+	bitReverseG1Jac(points)
+
+	// scale by 1/n
+	var bCardinalityInv big.Int
+	domain.CardinalityInv.ToBigIntRegular(&bCardinalityInv)
+	o.pool.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			points[i].ScalarMultiplication(&points[i], &bCardinalityInv)
+		}
+	})
+
+	var res LagrangeSRS
+	res.G1 = make([]bls12377.G1Affine, n)
+	bls12377.BatchJacobianToAffineG1(points, res.G1)
+	res.G2 = srs.G2
+
+	return &res, nil
+}
+
+// CommitLagrange commits to a polynomial given by its evaluations on the domain of srs
+// (in natural order, in Montgomery form), using a multi exponentiation with the Lagrange basis.
+// The result is the same as Commit on the canonical form of the polynomial.
+// The multi exponentiation runs on pool.Default().
+func CommitLagrange(evaluations []fr.Element, srs *LagrangeSRS, nbTasks ...int) (Digest, error) {
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	return commitLagrange(evaluations, srs, config)
+}
+
+func commitLagrange(evaluations []fr.Element, srs *LagrangeSRS, config ecc.MultiExpConfig) (Digest, error) {
+
+	if len(evaluations) != len(srs.G1) {
+		return Digest{}, ErrInvalidNbEvaluations
+	}
+
+	var res bls12377.G1Affine
+
+	if _, err := res.MultiExp(srs.G1, evaluations, config); err != nil {
+		return Digest{}, err
+	}
+
+	return res, nil
+}
+
+// OpenLagrange computes an opening proof at point of the polynomial given by its evaluations
+// on domain (in natural order). The evaluation at point and the quotient (f - f(point))/(X - point)
+// are computed in Lagrange form with the barycentric formula, without going through
+// the canonical form. The proof verifies with Verify.
+// The progress and the pool of the computations are set with WithProgress and WithPool.
+func OpenLagrange(evaluations []fr.Element, point *fr.Element, domain *fft.Domain, srs *LagrangeSRS, opts ...Option) (OpeningProof, error) {
+
+	n := int(domain.Cardinality)
+	if len(evaluations) != n || len(srs.G1) != n {
+		return OpeningProof{}, ErrInvalidNbEvaluations
+	}
+
+	// ω^i
+	roots := make([]fr.Element, n)
+	roots[0].SetOne()
+	for i := 1; i < n; i++ {
+		roots[i].Mul(&roots[i-1], &domain.Generator)
+	}
+
+	// 1/(ω^i - point), point might be in the domain
+	inDomain := -1
+	denominators := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		denominators[i].Sub(&roots[i], point)
+		if denominators[i].IsZero() {
+			inDomain = i
+		}
+	}
+	denominators = fr.BatchInvert(denominators)
+
+	res := OpeningProof{
+		Point: *point,
+	}
+
+	// f(point) = (point^n - 1)/n * Σ_i f_i ω^i / (point - ω^i)
+	if inDomain != -1 {
+		res.ClaimedValue.Set(&evaluations[inDomain])
+	} else {
+		var t fr.Element
+		for i := 0; i < n; i++ {
+			t.Mul(&evaluations[i], &roots[i]).Mul(&t, &denominators[i])
+			res.ClaimedValue.Sub(&res.ClaimedValue, &t)
+		}
+		var one fr.Element
+		one.SetOne()
+		t.Exp(*point, big.NewInt(int64(n))).Sub(&t, &one).Mul(&t, &domain.CardinalityInv)
+		res.ClaimedValue.Mul(&res.ClaimedValue, &t)
+	}
+
+	// h_i = (f_i - f(point)) / (ω^i - point), for ω^i != point
+	o := newOptions(opts)
+	h := make([]fr.Element, n)
+	o.pool.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			if i == inDomain {
+				continue
+			}
+			h[i].Sub(&evaluations[i], &res.ClaimedValue).Mul(&h[i], &denominators[i])
+		}
+	})
+
+	// if point == ω^m, h_m = f'(ω^m) = Σ_{i != m} (f_i - f(point)) ω^i / (point (point - ω^i))
+	//                                = -1/point * Σ_{i != m} h_i ω^i
+	if inDomain != -1 {
+		var t fr.Element
+		for i := 0; i < n; i++ {
+			if i == inDomain {
+				continue
+			}
+			t.Mul(&h[i], &roots[i])
+			h[inDomain].Sub(&h[inDomain], &t)
+		}
+		t.Inverse(point)
+		h[inDomain].Mul(&h[inDomain], &t)
+	}
+
+	// commit to H
+	hCommit, err := commitLagrange(h, srs, ecc.MultiExpConfig{ScalarsMont: true, Progress: o.progress, Pool: o.pool})
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	res.H.Set(&hCommit)
+
+	return res, nil
+}
+
+// difFFTG1 computes the fft of a, with the twiddles of the fft.Domain, in place,
+// using the decimation in frequency: the result is in bit reversed order
+func difFFTG1(a []bls12377.G1Jac, twiddles [][]fr.Element, stage int, pl *pool.Pool) {
+	n := len(a)
+	if n == 1 {
+		return
+	}
+	m := n >> 1
+
+	butterflies := func(start, end int) {
+		var t bls12377.G1Jac
+		var w big.Int
+		for i := start; i < end; i++ {
+			t.Set(&a[i])
+			a[i].AddAssign(&a[i+m])
+			a[i+m].Neg(&a[i+m]).AddAssign(&t)
+			if i != 0 {
+				twiddles[stage][i].ToBigIntRegular(&w)
+				a[i+m].ScalarMultiplication(&a[i+m], &w)
+			}
+		}
+	}
+	if m > fftG1Threshold {
+		pl.Execute(m, butterflies)
+	} else {
+		butterflies(0, m)
+	}
+
+	difFFTG1(a[:m], twiddles, stage+1, pl)
+	difFFTG1(a[m:], twiddles, stage+1, pl)
+}
+
+// parallelize threshold for a stage of difFFTG1
+const fftG1Threshold = 16
+
+// bitReverseG1Jac applies the bit-reversal permutation to a.
+// len(a) must be a power of 2
+func bitReverseG1Jac(a []bls12377.G1Jac) {
+	n := uint64(len(a))
+	nn := uint64(64 - bits.TrailingZeros64(n))
+
+	for i := uint64(0); i < n; i++ {
+		irev := bits.Reverse64(i) >> nn
+		if irev > i {
+			a[i], a[irev] = a[irev], a[i]
+		}
+	}
+}
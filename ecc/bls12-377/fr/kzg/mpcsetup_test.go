@@ -0,0 +1,195 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+)
+
+func TestPowersOfTau(t *testing.T) {
+
+	const (
+		size            = 32
+		nbContributions = 3
+	)
+
+	// contributions exchanged through their binary encoding
+	transcript := make([]*PowersOfTau, nbContributions+1)
+	var err error
+	transcript[0], err = InitPowersOfTau(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= nbContributions; i++ {
+		var buf bytes.Buffer
+		if _, err := transcript[i-1].WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		var next PowersOfTau
+		if _, err := next.ReadFrom(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if err := next.Contribute(); err != nil {
+			t.Fatal(err)
+		}
+		transcript[i] = &next
+	}
+
+	for i := 1; i <= nbContributions; i++ {
+		if err := transcript[i-1].Verify(transcript[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// contributions must be verified in order
+	if err := transcript[0].Verify(transcript[2]); err == nil {
+		t.Fatal("verifying a contribution out of order should fail")
+	}
+
+	// the final SRS is usable
+	srs, err := transcript[nbContributions].Finalize([]byte("beacon"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := randomPolynomial(size)
+	digest, err := Commit(f, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var point fr.Element
+	point.SetRandom()
+	proof, err := Open(f, &point, fft.NewDomain(size, 0, false), srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(&digest, &proof, srs); err != nil {
+		t.Fatal(err)
+	}
+
+	// finalize is deterministic
+	other, err := transcript[nbContributions].Finalize([]byte("beacon"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !other.G2[1].Equal(&srs.G2[1]) || !other.G1[size-1].Equal(&srs.G1[size-1]) {
+		t.Fatal("finalize should be deterministic")
+	}
+}
+
+func TestPowersOfTauInvalidContribution(t *testing.T) {
+
+	const size = 16
+
+	prev, err := InitPowersOfTau(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := prev.Contribute(); err != nil {
+		t.Fatal(err)
+	}
+
+	contribute := func() *PowersOfTau {
+		var buf bytes.Buffer
+		if _, err := prev.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		var next PowersOfTau
+		if _, err := next.ReadFrom(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if err := next.Contribute(); err != nil {
+			t.Fatal(err)
+		}
+		return &next
+	}
+
+	// points are not successive powers
+	next := contribute()
+	next.Parameters.G1[5], next.Parameters.G1[6] = next.Parameters.G1[6], next.Parameters.G1[5]
+	if err := prev.Verify(next); err != ErrInvalidPowers {
+		t.Fatal("swapped points should be rejected")
+	}
+
+	// the transcript hash doesn't chain the contribution
+	next = contribute()
+	next.Hash[0] ^= 1
+	if err := prev.Verify(next); err != ErrInvalidContributionHash {
+		t.Fatal("wrong transcript hash should be rejected")
+	}
+
+	// the contribution doesn't match the proof of knowledge
+	next = contribute()
+	other := contribute()
+	next.PublicKey = other.PublicKey
+	next.Hash = other.Hash
+	if err := prev.Verify(next); err != ErrInvalidContributionUpdate {
+		t.Fatal("contribution inconsistent with its proof of knowledge should be rejected")
+	}
+
+	// the contribution erases the previous secret
+	next, err = InitPowersOfTau(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := next.Contribute(); err != nil {
+		t.Fatal(err)
+	}
+	next.Hash = transcriptHash(&prev.Hash, &next.PublicKey)
+	if err := prev.Verify(next); err == nil {
+		t.Fatal("contribution discarding the previous secret should be rejected")
+	}
+
+	// wrong size
+	next = contribute()
+	next.Parameters.G1 = next.Parameters.G1[:size-1]
+	if err := prev.Verify(next); err != ErrInvalidContributionSize {
+		t.Fatal("contribution of wrong size should be rejected")
+	}
+}
+
+func BenchmarkPowersOfTauContribute(b *testing.B) {
+	p, err := InitPowersOfTau(1 << 10)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = p.Contribute()
+	}
+}
+
+func BenchmarkPowersOfTauVerify(b *testing.B) {
+	prev, err := InitPowersOfTau(1 << 10)
+	if err != nil {
+		b.Fatal(err)
+	}
+	next, err := InitPowersOfTau(1 << 10)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := next.Contribute(); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = prev.Verify(next)
+	}
+}
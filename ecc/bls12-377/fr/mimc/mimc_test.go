@@ -0,0 +1,163 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mimc
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+func TestMiMC(t *testing.T) {
+	msg := []byte("the quick brown fox jumps over the lazy dog, in several blocks of field elements")
+
+	// digest of the default parameters, which must not change
+	sum, err := Sum("seed", msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(sum) != "004767da2776e82f68611f022b2f980aff19b9c35d760265218600b0c512c4cf" {
+		t.Fatal("the digest of the default parameters should not change")
+	}
+
+	// New without options is NewMiMC
+	h1 := NewMiMC("seed")
+	h2, err := New("seed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h1.Write(msg)
+	h2.Write(msg)
+	if hex.EncodeToString(h1.Sum(nil)) != hex.EncodeToString(h2.Sum(nil)) {
+		t.Fatal("New without options should be NewMiMC")
+	}
+
+	// the number of rounds is derived from the exponent
+	h3, err := New("seed", WithExponent(11))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h3.(*digest).Params) != 148/2 || h3.(*digest).exponent != 11 {
+		t.Fatal("the number of rounds should be derived from the exponent")
+	}
+	h4, err := New("seed", WithExponent(11), WithNbRounds(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h3.Write(msg)
+	h4.Write(msg)
+	if len(h4.(*digest).Params) != 10 || hex.EncodeToString(h3.Sum(nil)) == hex.EncodeToString(h4.Sum(nil)) {
+		t.Fatal("the number of rounds should be set by WithNbRounds")
+	}
+
+	// invalid options
+	for _, e := range []int{-1, 1, 2, 4} {
+		if _, err := New("seed", WithExponent(e)); err != ErrInvalidExponent {
+			t.Fatalf("x^%d should be rejected", e)
+		}
+	}
+	if _, err := New("seed", WithNbRounds(-1)); err != ErrInvalidNbRounds {
+		t.Fatal("negative number of rounds should be rejected")
+	}
+}
+
+func TestSBox(t *testing.T) {
+	var x, expected fr.Element
+	for _, e := range []int{3, 5, 7, 11, 17} {
+		x.SetRandom()
+		expected.Exp(x, big.NewInt(int64(e)))
+		sBox(&x, e)
+		if !x.Equal(&expected) {
+			t.Fatalf("sBox should compute x^%d", e)
+		}
+	}
+	x.SetRandom()
+	expected.Inverse(&x)
+	sBox(&x, -1)
+	if !x.Equal(&expected) {
+		t.Fatal("sBox should compute 1/x")
+	}
+}
+
+func TestSponge(t *testing.T) {
+	var key fr.Element
+	key.SetRandom()
+	inputs := make([]fr.Element, 5)
+	for i := range inputs {
+		inputs[i].SetRandom()
+	}
+
+	// the outputs are the successive permutations of the state
+	s, err := NewSponge(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.constants) != 148 || s.exponent != 11 {
+		t.Fatal("wrong default parameters")
+	}
+	s.Absorb(inputs...)
+	res := s.Squeeze(3)
+	var xL, xR fr.Element
+	for i := range inputs {
+		xL.Add(&xL, &inputs[i])
+		s.Permutation(&xL, &xR)
+	}
+	for i := range res {
+		if i > 0 {
+			s.Permutation(&xL, &xR)
+		}
+		if !res[i].Equal(&xL) {
+			t.Fatal("Squeeze should output the left half of the successive states")
+		}
+	}
+
+	// Reset, and MultiHash
+	s.Reset()
+	s.Absorb(inputs...)
+	res2, err := MultiHash(inputs, key, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Squeeze(1)[0].Equal(&res[0]) || !res2[2].Equal(&res[2]) {
+		t.Fatal("MultiHash should absorb the inputs in a reset sponge")
+	}
+	if _, err := MultiHash(inputs, key, 0); err == nil {
+		t.Fatal("MultiHash without output should be rejected")
+	}
+	if _, err := NewSponge(key, WithNbRounds(1)); err != ErrInvalidNbRounds {
+		t.Fatal("a single round should be rejected")
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkMiMC(b *testing.B) {
+	var x fr.Element
+	x.SetRandom()
+	buf := x.Bytes()
+	h := NewMiMC("seed")
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		h.Reset()
+		h.Write(buf[:])
+		h.Sum(nil)
+	}
+}
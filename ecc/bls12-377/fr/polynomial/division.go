@@ -0,0 +1,177 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+// newtonDivisionThreshold is the number of coefficients of the divisor and of the quotient
+// above which DivMod uses Newton iteration instead of the schoolbook division
+const newtonDivisionThreshold = 128
+
+// Div sets p to the quotient of the Euclidean division of a by b, see DivMod
+// This function always allocates a new slice
+func (p *Polynomial) Div(a, b Polynomial) *Polynomial {
+	*p, _ = DivMod(a, b)
+	return p
+}
+
+// DivMod returns the quotient q and the remainder r of the Euclidean division of a by b,
+// such that a = b*q + r and deg(r) < deg(b).
+// q and r have no leading zero coefficients, the zero polynomial being a single zero coefficient.
+// The division is computed with the schoolbook method for small degrees, and with Newton
+// iteration on the reversed polynomials otherwise, in O(M(n)) with M(n) the cost of Mul.
+// It panics if b is zero.
+func DivMod(a, b Polynomial) (q, r Polynomial) {
+	a, b = trim(a), trim(b)
+	if isZero(b) {
+		panic("polynomial: division by zero")
+	}
+
+	if len(a) < len(b) {
+		return make(Polynomial, 1), a.Clone()
+	}
+
+	if len(b) > newtonDivisionThreshold && len(a)-len(b)+1 > newtonDivisionThreshold {
+		return divModNewton(a, b)
+	}
+	return divModSchoolbook(a, b)
+}
+
+// GCD sets p to the monic greatest common divisor of a and b, computed with Euclid's algorithm.
+// If a and b are both zero, p is set to zero.
+// This function always allocates a new slice
+func (p *Polynomial) GCD(a, b Polynomial) *Polynomial {
+	a, b = trim(a), trim(b)
+	for !isZero(b) {
+		_, r := DivMod(a, b)
+		a, b = b, r
+	}
+
+	res := a.Clone()
+	if !isZero(res) {
+		var lInv fr.Element
+		lInv.Inverse(&res[len(res)-1])
+		res.ScaleInPlace(&lInv)
+	}
+	*p = res
+	return p
+}
+
+// divModSchoolbook is the Euclidean division of a by b, with len(a) >= len(b) and b[len(b)-1] != 0
+func divModSchoolbook(a, b Polynomial) (Polynomial, Polynomial) {
+	r := a.Clone()
+	q := make(Polynomial, len(a)-len(b)+1)
+
+	var lInv, tmp fr.Element
+	lInv.Inverse(&b[len(b)-1])
+	for i := len(q) - 1; i >= 0; i-- {
+		q[i].Mul(&r[i+len(b)-1], &lInv)
+		for j := 0; j < len(b); j++ {
+			tmp.Mul(&q[i], &b[j])
+			r[i+j].Sub(&r[i+j], &tmp)
+		}
+	}
+
+	return q, trim(r[:len(b)-1])
+}
+
+// divModNewton is the Euclidean division of a by b, with len(a) >= len(b) and b[len(b)-1] != 0.
+// With m = len(a)-len(b)+1 the number of coefficients of q, the reversed polynomials satisfy
+// rev(a) = rev(q)*rev(b) mod X^m, and rev(b) is invertible modulo X^m since its constant
+// coefficient is the leading coefficient of b.
+func divModNewton(a, b Polynomial) (Polynomial, Polynomial) {
+	m := len(a) - len(b) + 1
+
+	revA := reverse(a[len(a)-m:])
+	revQ := mul(revA, invMod(reverse(b), m))
+	q := reverse(revQ[:m])
+
+	// r = a - b*q, whose coefficients of degree >= deg(b) are zero
+	bq := mul(b, q)
+	r := make(Polynomial, len(b)-1)
+	for i := 0; i < len(r); i++ {
+		r[i].Sub(&a[i], &bq[i])
+	}
+
+	return q, trim(r)
+}
+
+// invMod returns g such that f*g = 1 mod X^m, with f[0] != 0.
+// It uses Newton iteration g <- g*(2 - f*g), which doubles the precision of g at each step.
+func invMod(f Polynomial, m int) Polynomial {
+	g := make(Polynomial, 1)
+	g[0].Inverse(&f[0])
+
+	var two fr.Element
+	two.SetUint64(2)
+
+	for k := 1; k < m; {
+		k *= 2
+		if k > m {
+			k = m
+		}
+
+		fk := f
+		if len(fk) > k {
+			fk = fk[:k]
+		}
+		e := mul(fk, g)
+		if len(e) > k {
+			e = e[:k]
+		}
+		for i := 0; i < len(e); i++ {
+			e[i].Neg(&e[i])
+		}
+		e[0].Add(&e[0], &two)
+
+		g = mul(g, e)
+		if len(g) > k {
+			g = g[:k]
+		}
+	}
+
+	return g
+}
+
+// trim returns p without its leading zero coefficients, keeping at least one coefficient.
+// The result shares its coefficients with p.
+func trim(p Polynomial) Polynomial {
+	n := len(p)
+	for n > 1 && p[n-1].IsZero() {
+		n--
+	}
+	if n == 0 {
+		return make(Polynomial, 1)
+	}
+	return p[:n]
+}
+
+// isZero returns true if p is a trimmed zero polynomial
+func isZero(p Polynomial) bool {
+	return len(p) == 1 && p[0].IsZero()
+}
+
+// reverse returns a new polynomial with the coefficients of p in reverse order
+func reverse(p Polynomial) Polynomial {
+	res := make(Polynomial, len(p))
+	for i := 0; i < len(p); i++ {
+		res[i] = p[len(p)-1-i]
+	}
+	return res
+}
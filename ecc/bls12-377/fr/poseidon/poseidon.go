@@ -0,0 +1,201 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package poseidon
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+// MaxWidth is the largest width of the permutation with default parameters
+const MaxWidth = 17
+
+var (
+	ErrInvalidWidth     = errors.New("invalid width")
+	ErrInvalidNbRounds  = errors.New("invalid number of rounds")
+	ErrInvalidSizeState = errors.New("the size of the state should be the width of the permutation")
+	ErrInvalidNbInputs  = fmt.Errorf("the number of inputs should be between 1 and %d", MaxWidth-1)
+)
+
+// Parameters describe the Poseidon permutation of a given width
+type Parameters struct {
+	Width           int // number of field elements of the state, t
+	NbFullRounds    int // number of full rounds, R_F (even)
+	NbPartialRounds int // number of partial rounds, R_P
+
+	// RoundKeys[i] is added to the state in the i-th round, and MDS is the linear layer of each round
+	RoundKeys [][]fr.Element
+	MDS       [][]fr.Element
+}
+
+// NewParameters returns the parameters of the Poseidon permutation of width t, with nbFullRounds
+// full rounds and nbPartialRounds partial rounds, generated with the Grain LFSR
+// the MDS matrix is the first Cauchy matrix 1/(x_i+y_j) drawn from the LFSR after the round keys
+func NewParameters(width, nbFullRounds, nbPartialRounds int) (*Parameters, error) {
+	if width < 2 {
+		return nil, ErrInvalidWidth
+	}
+	if nbFullRounds < 2 || nbFullRounds%2 != 0 || nbPartialRounds < 0 {
+		return nil, ErrInvalidNbRounds
+	}
+	res := &Parameters{
+		Width:           width,
+		NbFullRounds:    nbFullRounds,
+		NbPartialRounds: nbPartialRounds,
+	}
+
+	g := newGrain(width, nbFullRounds, nbPartialRounds)
+	res.RoundKeys = make([][]fr.Element, nbFullRounds+nbPartialRounds)
+	for i := range res.RoundKeys {
+		res.RoundKeys[i] = make([]fr.Element, width)
+		for j := range res.RoundKeys[i] {
+			res.RoundKeys[i][j] = g.nextElement()
+		}
+	}
+
+	res.MDS = make([][]fr.Element, width)
+	for i := range res.MDS {
+		res.MDS[i] = make([]fr.Element, width)
+	}
+	for !res.setCauchyMatrix(g) {
+	}
+	return res, nil
+}
+
+// setCauchyMatrix draws 2·Width elements x_i, y_j from g and sets MDS to 1/(x_i+y_j), or returns false
+// if the elements are not distinct or if some x_i+y_j is zero
+func (params *Parameters) setCauchyMatrix(g *grain) bool {
+	t := params.Width
+	xy := make([]fr.Element, 2*t)
+	for i := range xy {
+		xy[i] = g.nextElementReduced()
+		for j := 0; j < i; j++ {
+			if xy[i].Equal(&xy[j]) {
+				return false
+			}
+		}
+	}
+	for i := 0; i < t; i++ {
+		for j := 0; j < t; j++ {
+			params.MDS[i][j].Add(&xy[i], &xy[t+j])
+			if params.MDS[i][j].IsZero() {
+				return false
+			}
+			params.MDS[i][j].Inverse(&params.MDS[i][j])
+		}
+	}
+	return true
+}
+
+// default number of rounds for 128 bits of security with the s-box x^11, for the widths 2 to MaxWidth,
+// as computed by calc_round_numbers.py in the reference implementation (with the security margin)
+var (
+	defaultNbFullRounds    = [MaxWidth - 1]int{8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8}
+	defaultNbPartialRounds = [MaxWidth - 1]int{37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 38, 38, 38, 38, 38, 38}
+)
+
+var (
+	defaultParameters     [MaxWidth + 1]*Parameters
+	defaultParametersLock sync.Mutex
+)
+
+// NewDefaultParameters returns the parameters of the Poseidon permutation of width t for 128 bits of
+// security, with the number of partial rounds rounded up to a multiple of t as in the paper
+// the parameters are computed once, and shared by the callers
+func NewDefaultParameters(width int) (*Parameters, error) {
+	if width < 2 || width > MaxWidth {
+		return nil, ErrInvalidWidth
+	}
+	defaultParametersLock.Lock()
+	defer defaultParametersLock.Unlock()
+	if defaultParameters[width] == nil {
+		nbPartialRounds := defaultNbPartialRounds[width-2]
+		nbPartialRounds = (nbPartialRounds + width - 1) / width * width
+		params, err := NewParameters(width, defaultNbFullRounds[width-2], nbPartialRounds)
+		if err != nil {
+			return nil, err
+		}
+		defaultParameters[width] = params
+	}
+	return defaultParameters[width], nil
+}
+
+// Permutation applies the Poseidon permutation to state, whose size must be the width
+func (params *Parameters) Permutation(state []fr.Element) error {
+	if len(state) != params.Width {
+		return ErrInvalidSizeState
+	}
+	rf := params.NbFullRounds / 2
+	tmp := make([]fr.Element, params.Width)
+	for r := 0; r < params.NbFullRounds+params.NbPartialRounds; r++ {
+		for i := range state {
+			state[i].Add(&state[i], &params.RoundKeys[r][i])
+		}
+		if r < rf || r >= rf+params.NbPartialRounds {
+			for i := range state {
+				sBox(&state[i])
+			}
+		} else {
+			sBox(&state[0])
+		}
+		params.mulMDS(state, tmp)
+	}
+	return nil
+}
+
+// mulMDS sets state to MDS·state, using tmp of size the width
+func (params *Parameters) mulMDS(state, tmp []fr.Element) {
+	var t fr.Element
+	for i := range tmp {
+		tmp[i].SetZero()
+		for j := range state {
+			t.Mul(&params.MDS[i][j], &state[j])
+			tmp[i].Add(&tmp[i], &t)
+		}
+	}
+	copy(state, tmp)
+}
+
+// Hash returns the Poseidon hash of inputs: the first element of the permutation of width len(inputs)+1
+// of the state (0, inputs...), as in circomlib
+func Hash(inputs []fr.Element) (fr.Element, error) {
+	if len(inputs) < 1 || len(inputs) >= MaxWidth {
+		return fr.Element{}, ErrInvalidNbInputs
+	}
+	params, err := NewDefaultParameters(len(inputs) + 1)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	state := make([]fr.Element, len(inputs)+1)
+	copy(state[1:], inputs)
+	if err := params.Permutation(state); err != nil {
+		return fr.Element{}, err
+	}
+	return state[0], nil
+}
+
+// sBox sets x to x^11, the s-box of the permutation
+func sBox(x *fr.Element) {
+	var tmp fr.Element
+	var x2 fr.Element
+	x2.Square(x)
+	tmp.Square(&x2).Square(&tmp).Mul(&tmp, &x2)
+	x.Mul(x, &tmp)
+}
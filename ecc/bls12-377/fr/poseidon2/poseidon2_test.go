@@ -0,0 +1,137 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package poseidon2
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+func TestPermutation(t *testing.T) {
+	for width := 2; width <= 3; width++ {
+		params, err := NewDefaultParameters(width)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if other, _ := NewDefaultParameters(width); other != params {
+			t.Fatal("default parameters should be computed once")
+		}
+		rf := params.NbFullRounds / 2
+		for r, keys := range params.RoundKeys {
+			partial := r >= rf && r < rf+params.NbPartialRounds
+			if (partial && len(keys) != 1) || (!partial && len(keys) != width) {
+				t.Fatal("wrong number of round keys")
+			}
+		}
+
+		// the permutation doesn't map two states to the same one
+		state := make([]fr.Element, width)
+		state2 := make([]fr.Element, width)
+		for i := range state {
+			state[i].SetRandom()
+		}
+		copy(state2, state)
+		state2[width-1].SetOne().Add(&state2[width-1], &state[width-1])
+		if err := params.Permutation(state); err != nil {
+			t.Fatal(err)
+		}
+		if err := params.Permutation(state2); err != nil {
+			t.Fatal(err)
+		}
+		for i := range state {
+			if state[i].Equal(&state2[i]) {
+				t.Fatal("different states should have different images")
+			}
+		}
+
+		if err := params.Permutation(state[1:]); err != ErrInvalidSizeState {
+			t.Fatal("state of the wrong size should be rejected")
+		}
+	}
+
+	// invalid parameters
+	for _, width := range []int{0, 1, 4} {
+		if _, err := NewDefaultParameters(width); err != ErrInvalidWidth {
+			t.Fatal("invalid width should be rejected")
+		}
+	}
+	if _, err := NewParameters(3, 7, 56); err != ErrInvalidNbRounds {
+		t.Fatal("odd number of full rounds should be rejected")
+	}
+}
+
+func TestSponge(t *testing.T) {
+	params, err := NewDefaultParameters(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 5 elements, absorbed in 3 blocks of 2 elements
+	var elements [5]fr.Element
+	var data []byte
+	for i := range elements {
+		elements[i].SetRandom()
+		b := elements[i].Bytes()
+		data = append(data, b[:]...)
+	}
+	state := make([]fr.Element, 3)
+	for i := 0; i < len(elements); i += 2 {
+		state[1].Add(&state[1], &elements[i])
+		if i+1 < len(elements) {
+			state[2].Add(&state[2], &elements[i+1])
+		}
+		if err := params.Permutation(state); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := state[0].Bytes()
+
+	h := NewSponge(params)
+	h.Write(data[:50])
+	h.Write(data[50:])
+	if string(h.Sum(nil)) != string(expected[:]) {
+		t.Fatal("the sponge should absorb the elements by blocks of width-1 elements")
+	}
+	if string(h.Sum(nil)) != string(expected[:]) {
+		t.Fatal("Sum should not change the state of the hash")
+	}
+	if string(NewPoseidon2().Sum(nil)) == string(expected[:]) {
+		t.Fatal("the empty message should have a different digest")
+	}
+	h.Reset()
+	h.Write(data)
+	if string(h.Sum(nil)) != string(expected[:]) || h.Size() != fr.Bytes || h.BlockSize() != BlockSize {
+		t.Fatal("Reset should reset the hash")
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkPermutation(b *testing.B) {
+	params, _ := NewDefaultParameters(3)
+	var state [3]fr.Element
+	for i := range state {
+		state[i].SetRandom()
+	}
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		_ = params.Permutation(state[:])
+	}
+}
@@ -19,12 +19,12 @@ package bls12377
 import (
 	"math"
 	"math/big"
-	"runtime"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/internal/parallel"
+	"github.com/consensys/gnark-crypto/pool"
 )
 
 // G1Affine point in affine coordinates
@@ -858,7 +858,8 @@ func BatchJacobianToAffineG1(points []G1Jac, result []G1Affine) {
 // BatchScalarMultiplicationG1 multiplies the same base (generator) by all scalars
 // and return resulting points in affine coordinates
 // uses a simple windowed-NAF like exponentiation algorithm
-func BatchScalarMultiplicationG1(base *G1Affine, scalars []fr.Element) []G1Affine {
+// the work is run on pl[0] if set, or on the default pool
+func BatchScalarMultiplicationG1(base *G1Affine, scalars []fr.Element, pl ...*pool.Pool) []G1Affine {
 
 	// approximate cost in group ops is
 	// cost = 2^{c-1} + n(scalar.nbBits+nbChunks)
@@ -897,7 +898,11 @@ func BatchScalarMultiplicationG1(base *G1Affine, scalars []fr.Element) []G1Affin
 		baseTable[i].AddMixed(base)
 	}
 
-	pScalars := partitionScalars(scalars, c, false, runtime.NumCPU())
+	var _pool *pool.Pool
+	if len(pl) > 0 {
+		_pool = pl[0]
+	}
+	pScalars := partitionScalars(scalars, c, false, _pool.NbWorkers(), _pool)
 
 	// compute offset and word selector / shift to select the right bits of our windows
 	selectors := make([]selector, nbChunks)
@@ -921,7 +926,7 @@ func BatchScalarMultiplicationG1(base *G1Affine, scalars []fr.Element) []G1Affin
 	toReturn := make([]G1Jac, len(scalars))
 
 	// for each digit, take value in the base table, double it c time, voila.
-	parallel.Execute(len(pScalars), func(start, end int) {
+	_pool.Execute(len(pScalars), func(start, end int) {
 		var p G1Jac
 		for i := start; i < end; i++ {
 			p.Set(&g1Infinity)
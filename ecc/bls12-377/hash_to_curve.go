@@ -274,3 +274,187 @@ func HashToCurveG2Svdw(msg, dst []byte) (G2Affine, error) {
 	res.FromJacobian(&_res)
 	return res, nil
 }
+
+// ----------------------------------------------------------------------------------------
+// Simplified SWU with isogeny
+//
+// https://www.rfc-editor.org/rfc/rfc9380#section-6.6.3
+//
+// The SSWU map requires AB != 0, hence for G1 it is applied on the curve
+// E1': y² = x³ - 15x + 22, which is 2-isogenous to E1: y² = x³ + 1, and the result
+// is mapped back using the isogeny.
+//
+// G2 has no rational 2-torsion and its small degree isogenies (3, 7) all land on
+// j-invariant 0 curves; the smallest suitable isogeny has degree 23. G2 therefore
+// only exposes the Shallue and van de Woestijne method above.
+
+// sswuG1 holds the curve E1': y² = x³ + A'x + B', the SSWU constant Z
+// and the coefficients (low degree first) of the rational maps of the isogeny E1' -> E1
+var sswuG1 struct {
+	A, B, Z                fp.Element
+	xNum, xDen, yNum, yDen []fp.Element
+}
+
+func init() {
+	sswuG1.A.SetString("258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458162")
+	sswuG1.B.SetUint64(22)
+	sswuG1.Z.SetString("258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458166")
+
+	// x = (x'² - 2x' - 3) / (4(x' - 2))
+	sswuG1.xNum = fpElements(
+		"193998319509726820507989550271170150152295134566185995404913197000040351261255617081226666104680020093330241093632",
+		"129332213006484547005326366847446766768196756377457330269942131333360234174170411387484444069786680062220160729088",
+		"193998319509726820507989550271170150152295134566185995404913197000040351261255617081226666104680020093330241093633",
+	)
+	sswuG1.xDen = fpElements(
+		"258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458175",
+		"1",
+	)
+	// y = y' (x'² - 4x' + 7) / (8(x' - 2)²)
+	sswuG1.yNum = fpElements(
+		"32333053251621136751331591711861691692049189094364332567485532833340058543542602846871111017446670015555040182273",
+		"129332213006484547005326366847446766768196756377457330269942131333360234174170411387484444069786680062220160729088",
+		"226331372761347957259321141983031841844344323660550327972398729833380409804798219928097777122126690108885281275905",
+	)
+	sswuG1.yDen = fpElements(
+		"4",
+		"258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458173",
+		"1",
+	)
+}
+
+// fpElements returns the fp.Element slice corresponding to the base10 strings s
+func fpElements(s ...string) []fp.Element {
+	res := make([]fp.Element, len(s))
+	for i := 0; i < len(s); i++ {
+		res[i].SetString(s[i])
+	}
+	return res
+}
+
+// sgn0Fp returns the parity of u (in regular form)
+// https://www.rfc-editor.org/rfc/rfc9380#section-4.1
+func sgn0Fp(u *fp.Element) uint64 {
+	r := u.ToRegular()
+	return r[0] & 1
+}
+
+// evalPolyFp evaluates the polynomial of coefficients c (low degree first) at x
+func evalPolyFp(c []fp.Element, x *fp.Element) fp.Element {
+	res := c[len(c)-1]
+	for i := len(c) - 2; i >= 0; i-- {
+		res.Mul(&res, x).Add(&res, &c[i])
+	}
+	return res
+}
+
+// sswuMapG1 maps u to a point on E1' using the simplified SWU method
+// https://www.rfc-editor.org/rfc/rfc9380#section-6.6.2
+func sswuMapG1(u fp.Element) G1Affine {
+
+	var res G1Affine
+	var tv1, tv2, x1, gx1, x2, gx2 fp.Element
+
+	// tv1 = Z * u^2, tv2 = Z^2 * u^4 + Z * u^2
+	tv1.Square(&u).Mul(&tv1, &sswuG1.Z)
+	tv2.Square(&tv1).Add(&tv2, &tv1)
+
+	// x1 = (-B / A) * (1 + 1/tv2), or B / (Z * A) if tv2 == 0
+	if tv2.IsZero() {
+		x1.Mul(&sswuG1.Z, &sswuG1.A).Inverse(&x1).Mul(&x1, &sswuG1.B)
+	} else {
+		var one fp.Element
+		one.SetOne()
+		tv2.Inverse(&tv2).Add(&tv2, &one)
+		x1.Inverse(&sswuG1.A).Mul(&x1, &sswuG1.B).Neg(&x1).Mul(&x1, &tv2)
+	}
+
+	// gx1 = x1^3 + A * x1 + B
+	gx1.Square(&x1).Add(&gx1, &sswuG1.A).Mul(&gx1, &x1).Add(&gx1, &sswuG1.B)
+
+	if gx1.Legendre() != -1 {
+		res.X.Set(&x1)
+		res.Y.Sqrt(&gx1)
+	} else {
+		// x2 = Z * u^2 * x1, gx2 = x2^3 + A * x2 + B
+		x2.Mul(&tv1, &x1)
+		gx2.Square(&x2).Add(&gx2, &sswuG1.A).Mul(&gx2, &x2).Add(&gx2, &sswuG1.B)
+		res.X.Set(&x2)
+		res.Y.Sqrt(&gx2)
+	}
+
+	if sgn0Fp(&u) != sgn0Fp(&res.Y) {
+		res.Y.Neg(&res.Y)
+	}
+
+	return res
+}
+
+// isogenyG1 maps p from E1' to E1 using the 2-isogeny
+func isogenyG1(p *G1Affine) G1Affine {
+
+	var res G1Affine
+
+	xNum := evalPolyFp(sswuG1.xNum, &p.X)
+	xDen := evalPolyFp(sswuG1.xDen, &p.X)
+	yNum := evalPolyFp(sswuG1.yNum, &p.X)
+	yDen := evalPolyFp(sswuG1.yDen, &p.X)
+
+	// kernel of the isogeny: the image is the point at infinity
+	if xDen.IsZero() || yDen.IsZero() {
+		return res
+	}
+
+	// x = xNum / xDen, y = y' * yNum / yDen, with a single inversion
+	var inv fp.Element
+	inv.Mul(&xDen, &yDen).Inverse(&inv)
+	res.X.Mul(&xNum, &yDen).Mul(&res.X, &inv)
+	res.Y.Mul(&yNum, &xDen).Mul(&res.Y, &inv).Mul(&res.Y, &p.Y)
+
+	return res
+}
+
+// MapToCurveG1SSWU maps an fp.Element to a point on the curve using the simplified SWU map
+// with the 2-isogeny, and clears the cofactor
+// https://www.rfc-editor.org/rfc/rfc9380#section-6.6.3
+func MapToCurveG1SSWU(u fp.Element) G1Affine {
+	res := sswuMapG1(u)
+	res = isogenyG1(&res)
+	res.ClearCofactor(&res)
+	return res
+}
+
+// EncodeToCurveG1SSWU hashes msg to a point on the curve using the simplified SWU map
+// (nonuniform encoding)
+// https://www.rfc-editor.org/rfc/rfc9380#section-3
+func EncodeToCurveG1SSWU(msg, dst []byte) (G1Affine, error) {
+	var res G1Affine
+	u, err := hashToFp(msg, dst, 1)
+	if err != nil {
+		return res, err
+	}
+	res = MapToCurveG1SSWU(u[0])
+	return res, nil
+}
+
+// HashToCurveG1SSWU hashes msg to a point on the curve using the simplified SWU map
+// (random oracle encoding)
+// https://www.rfc-editor.org/rfc/rfc9380#section-3
+func HashToCurveG1SSWU(msg, dst []byte) (G1Affine, error) {
+	var res G1Affine
+	u, err := hashToFp(msg, dst, 2)
+	if err != nil {
+		return res, err
+	}
+	Q0 := sswuMapG1(u[0])
+	Q1 := sswuMapG1(u[1])
+	Q0 = isogenyG1(&Q0)
+	Q1 = isogenyG1(&Q1)
+	var _Q0, _Q1, _res G1Jac
+	_Q0.FromAffine(&Q0)
+	_Q1.FromAffine(&Q1)
+	_res.Set(&_Q1).AddAssign(&_Q0)
+	_res.ClearCofactor(&_res)
+	res.FromJacobian(&_res)
+	return res, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bls12377
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestMapToCurveSSWU(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 10
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[G1] SSWU mapping should output point on the isogenous curve", prop.ForAll(
+		func(a fp.Element) bool {
+			g := sswuMapG1(a)
+			var rhs, lhs fp.Element
+			lhs.Square(&g.Y)
+			rhs.Square(&g.X).Add(&rhs, &sswuG1.A).Mul(&rhs, &g.X).Add(&rhs, &sswuG1.B)
+			return lhs.Equal(&rhs)
+		},
+		GenFp(),
+	))
+
+	properties.Property("[G1] isogeny should map E1' to the curve", prop.ForAll(
+		func(a fp.Element) bool {
+			g := sswuMapG1(a)
+			g = isogenyG1(&g)
+			return g.IsOnCurve()
+		},
+		GenFp(),
+	))
+
+	properties.Property("[G1] SSWU mapping should output point in the subgroup", prop.ForAll(
+		func(a fp.Element) bool {
+			g := MapToCurveG1SSWU(a)
+			return g.IsInSubGroup()
+		},
+		GenFp(),
+	))
+
+	properties.Property("[G1] SSWU mapping should preserve the sign of the input", prop.ForAll(
+		func(a fp.Element) bool {
+			g := sswuMapG1(a)
+			return sgn0Fp(&a) == sgn0Fp(&g.Y)
+		},
+		GenFp(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestHashToCurveG1SSWU(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12377G1_XMD:SHA-256_SSWU_RO_")
+	for _, msg := range []string{"", "abc", "abcdef0123456789"} {
+		p, err := HashToCurveG1SSWU([]byte(msg), dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.IsInSubGroup() {
+			t.Fatalf("HashToCurveG1SSWU(%q) is not in the subgroup", msg)
+		}
+		q, err := HashToCurveG1SSWU([]byte(msg), dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.Equal(&q) {
+			t.Fatalf("HashToCurveG1SSWU(%q) is not deterministic", msg)
+		}
+	}
+}
+
+func BenchmarkHashToCurveG1SSWU(b *testing.B) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12377G1_XMD:SHA-256_SSWU_RO_")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		HashToCurveG1SSWU([]byte("abc"), dst)
+	}
+}
@@ -17,12 +17,14 @@
 package eddsa
 
 import (
+	"crypto/rand"
 	"crypto/subtle"
 	"errors"
 	"hash"
 	"io"
 	"math/big"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/twistededwards"
 	"github.com/consensys/gnark-crypto/signature"
@@ -65,8 +67,6 @@ func init() {
 // GenerateKey generates a public and private key pair.
 func GenerateKey(r io.Reader) (PrivateKey, error) {
 
-	c := twistededwards.GetEdwardsCurve()
-
 	var pub PublicKey
 	var priv PrivateKey
 
@@ -100,7 +100,7 @@ func GenerateKey(r io.Reader) (PrivateKey, error) {
 
 	var bscalar big.Int
 	bscalar.SetBytes(priv.scalar[:])
-	pub.A.ScalarMul(&c.Base, &bscalar)
+	pub.A.ScalarMulBase(&bscalar)
 
 	priv.PublicKey = pub
 
@@ -154,38 +154,22 @@ func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error)
 	blindingFactorBigInt.SetBytes(blindingFactorBytes[:sizeFr])
 
 	// compute R = randScalar*Base
-	res.R.ScalarMul(&curveParams.Base, &blindingFactorBigInt)
+	res.R.ScalarMulBase(&blindingFactorBigInt)
 	if !res.R.IsOnCurve() {
 		return nil, errNotOnCurve
 	}
 
-	// compute H(R, A, M), all parameters in data are in Montgomery form
-	resRX := res.R.X.Bytes()
-	resRY := res.R.Y.Bytes()
-	resAX := privKey.PublicKey.A.X.Bytes()
-	resAY := privKey.PublicKey.A.Y.Bytes()
-	sizeDataToHash := 4*sizeFr + len(message)
-	dataToHash := make([]byte, sizeDataToHash)
-	copy(dataToHash[:], resRX[:])
-	copy(dataToHash[sizeFr:], resRY[:])
-	copy(dataToHash[2*sizeFr:], resAX[:])
-	copy(dataToHash[3*sizeFr:], resAY[:])
-	copy(dataToHash[4*sizeFr:], message)
-	hFunc.Reset()
-	_, err := hFunc.Write(dataToHash[:])
+	// compute H(R, A, M)
+	hramInt, err := hashRAM(&res.R, &privKey.PublicKey.A, message, hFunc)
 	if err != nil {
 		return nil, err
 	}
 
-	var hramInt big.Int
-	hramBin := hFunc.Sum(nil)
-	hramInt.SetBytes(hramBin)
-
 	// Compute s = randScalarInt + H(R,A,M)*S
 	// going with big int to do ops mod curve order
 	var bscalar, bs big.Int
 	bscalar.SetBytes(privKey.scalar[:])
-	bs.Mul(&hramInt, &bscalar).
+	bs.Mul(hramInt, &bscalar).
 		Add(&bs, &blindingFactorBigInt).
 		Mod(&bs, &curveParams.Order)
 	sb := bs.Bytes()
@@ -213,53 +197,142 @@ func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, err
 	if _, err := sig.SetBytes(sigBin); err != nil {
 		return false, err
 	}
+	if !sig.R.IsOnCurve() {
+		return false, errNotOnCurve
+	}
 
-	// compute H(R, A, M), all parameters in data are in Montgomery form
-	sigRX := sig.R.X.Bytes()
-	sigRY := sig.R.Y.Bytes()
-	sigAX := pub.A.X.Bytes()
-	sigAY := pub.A.Y.Bytes()
-	sizeDataToHash := 4*sizeFr + len(message)
-	dataToHash := make([]byte, sizeDataToHash)
-	copy(dataToHash[:], sigRX[:])
-	copy(dataToHash[sizeFr:], sigRY[:])
-	copy(dataToHash[2*sizeFr:], sigAX[:])
-	copy(dataToHash[3*sizeFr:], sigAY[:])
-	copy(dataToHash[4*sizeFr:], message)
-	hFunc.Reset()
-	if _, err := hFunc.Write(dataToHash[:]); err != nil {
+	// compute H(R, A, M)
+	hramInt, err := hashRAM(&sig.R, &pub.A, message, hFunc)
+	if err != nil {
 		return false, err
 	}
 
-	var hramInt big.Int
-	hramBin := hFunc.Sum(nil)
-	hramInt.SetBytes(hramBin)
-
 	// lhs = cofactor*S*Base
-	var lhs twistededwards.PointAffine
+	var lhs twistededwards.PointExtended
 	var bCofactor, bs big.Int
 	curveParams.Cofactor.ToBigInt(&bCofactor)
 	bs.SetBytes(sig.S[:])
-	lhs.ScalarMul(&curveParams.Base, &bs).
+	lhs.ScalarMulBase(&bs).
 		ScalarMul(&lhs, &bCofactor)
 
-	if !lhs.IsOnCurve() {
-		return false, errNotOnCurve
-	}
-
 	// rhs = cofactor*(R + H(R,A,M)*A)
-	var rhs twistededwards.PointAffine
-	rhs.ScalarMul(&pub.A, &hramInt).
-		Add(&rhs, &sig.R).
+	var rhs, A twistededwards.PointExtended
+	A.FromAffine(&pub.A)
+	rhs.ScalarMul(&A, hramInt).
+		MixedAdd(&rhs, &sig.R).
 		ScalarMul(&rhs, &bCofactor)
-	if !rhs.IsOnCurve() {
-		return false, errNotOnCurve
-	}
 
 	// verifies that cofactor*S*Base=cofactor*(R + H(R,A,M)*A)
-	if !lhs.X.Equal(&rhs.X) || !lhs.Y.Equal(&rhs.Y) {
+	if !lhs.Equal(&rhs) {
 		return false, nil
 	}
 
 	return true, nil
 }
+
+// BatchVerify verifies the eddsa signatures sigsBin of messages by pubKeys, with the same cofactored
+// semantics as Verify: a signature (R, S) is valid iff cofactor*S*Base = cofactor*(R + H(R,A,M)*A).
+//
+// The signatures are checked at once with a random linear combination of the verification equations,
+// cofactor*(sum(z_i*S_i)*Base - sum(z_i*R_i) - sum(z_i*H(R_i,A_i,M_i)*A_i)) = 0, computed with a multi
+// exponentiation, for random 128-bit z_i. A batch of valid signatures is always accepted, and a batch
+// with an invalid signature is accepted with probability at most 2^-128.
+//
+// It returns true if all the signatures are valid. Otherwise, the signatures are verified one by one,
+// and it returns false, the index of the first invalid signature, and the error returned by Verify for it.
+func BatchVerify(pubKeys []PublicKey, messages [][]byte, sigsBin [][]byte, hFunc hash.Hash) (bool, int, error) {
+	if len(pubKeys) != len(messages) || len(pubKeys) != len(sigsBin) {
+		return false, -1, errors.New("len(pubKeys), len(messages) and len(sigsBin) differ")
+	}
+
+	if !batchVerify(pubKeys, messages, sigsBin, hFunc) {
+		for i := range pubKeys {
+			if valid, err := pubKeys[i].Verify(sigsBin[i], messages[i], hFunc); !valid {
+				return false, i, err
+			}
+		}
+	}
+	return true, -1, nil
+}
+
+// batchVerify returns true if the random linear combination of the verification equations of
+// the signatures holds, and false if it doesn't or a signature can't be decoded
+func batchVerify(pubKeys []PublicKey, messages [][]byte, sigsBin [][]byte, hFunc hash.Hash) bool {
+	curveParams := twistededwards.GetEdwardsCurve()
+	n := len(pubKeys)
+
+	// the points are Base, the R_i, and the A_i
+	points := make([]twistededwards.PointAffine, 2*n+1)
+	scalars := make([]fr.Element, 2*n+1)
+	points[0] = curveParams.Base
+
+	var sumS, s, z, hram big.Int
+	var buf [16]byte
+	for i := 0; i < n; i++ {
+		var sig Signature
+		if _, err := sig.SetBytes(sigsBin[i]); err != nil {
+			return false
+		}
+		if !sig.R.IsOnCurve() || !pubKeys[i].A.IsOnCurve() {
+			return false
+		}
+		h, err := hashRAM(&sig.R, &pubKeys[i].A, messages[i], hFunc)
+		if err != nil {
+			return false
+		}
+		if _, err := rand.Read(buf[:]); err != nil {
+			return false
+		}
+		z.SetBytes(buf[:])
+
+		// sum(z_i*S_i)
+		s.SetBytes(sig.S[:])
+		s.Mul(&s, &z)
+		sumS.Add(&sumS, &s)
+
+		// -z_i*R_i and -z_i*H(R_i,A_i,M_i)*A_i, with the scalars mod Order, as the
+		// result is multiplied by the cofactor
+		points[1+i] = sig.R
+		s.Neg(&z).Mod(&s, &curveParams.Order)
+		scalars[1+i].SetBigInt(&s).FromMont()
+
+		points[1+n+i] = pubKeys[i].A
+		hram.Mul(h, &z).Neg(&hram).Mod(&hram, &curveParams.Order)
+		scalars[1+n+i].SetBigInt(&hram).FromMont()
+	}
+	sumS.Mod(&sumS, &curveParams.Order)
+	scalars[0].SetBigInt(&sumS).FromMont()
+
+	var res twistededwards.PointExtended
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return false
+	}
+	var bCofactor big.Int
+	curveParams.Cofactor.ToBigInt(&bCofactor)
+	res.ScalarMul(&res, &bCofactor)
+
+	return res.IsZero()
+}
+
+// hashRAM returns H(R, A, M), all parameters in data are in Montgomery form
+func hashRAM(R, A *twistededwards.PointAffine, message []byte, hFunc hash.Hash) (*big.Int, error) {
+
+	RX := R.X.Bytes()
+	RY := R.Y.Bytes()
+	AX := A.X.Bytes()
+	AY := A.Y.Bytes()
+	sizeDataToHash := 4*sizeFr + len(message)
+	dataToHash := make([]byte, sizeDataToHash)
+	copy(dataToHash[:], RX[:])
+	copy(dataToHash[sizeFr:], RY[:])
+	copy(dataToHash[2*sizeFr:], AX[:])
+	copy(dataToHash[3*sizeFr:], AY[:])
+	copy(dataToHash[4*sizeFr:], message)
+	hFunc.Reset()
+	if _, err := hFunc.Write(dataToHash[:]); err != nil {
+		return nil, err
+	}
+
+	hramBin := hFunc.Sum(nil)
+	return new(big.Int).SetBytes(hramBin), nil
+}
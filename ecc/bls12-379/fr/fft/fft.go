@@ -17,11 +17,12 @@
 package fft
 
 import (
+	"context"
 	"math/bits"
-	"runtime"
+	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc"
-	"github.com/consensys/gnark-crypto/internal/parallel"
+	"github.com/consensys/gnark-crypto/pool"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
 )
@@ -37,6 +38,10 @@ const (
 // parallelize threshold for a single butterfly op, if the fft stage is not parallelized already
 const butterflyThreshold = 16
 
+// minimum size of the recursive calls checking for the cancellation of FFTCtx and FFTInverseCtx,
+// and size of the blocks of their progress
+const cancellationThreshold = 1 << 8
+
 // FFT computes (recursively) the discrete Fourier transform of a and stores the result in a
 // if decimation == DIT (decimation in time), the input must be in bit-reversed order
 // if decimation == DIF (decimation in frequency), the output will be in bit-reversed order
@@ -48,13 +53,25 @@ const butterflyThreshold = 16
 // domain := NewDomain(m, 2) -->  contains precomputed data for Z/mZ, and Z/4mZ
 // FFT(pol, DIT, 1) --> evaluates pol on the coset 1 in (Z/4mZ)/(Z/mZ)
 func (domain *Domain) FFT(a []fr.Element, decimation Decimation, coset uint64) {
+	domain.fft(a, decimation, coset, domain.newFFTContext(nil, nil, len(a)))
+}
+
+// FFTCtx is FFT, and stops early when ctx is done. In this case, it returns ctx.Err()
+// and the content of a is unspecified.
+// If progress is not nil, it reports the progress of the FFT.
+func (domain *Domain) FFTCtx(ctx context.Context, a []fr.Element, decimation Decimation, coset uint64, progress ecc.Progress) error {
+	domain.fft(a, decimation, coset, domain.newFFTContext(ctx, progress, len(a)))
+	return ctx.Err()
+}
+
+func (domain *Domain) fft(a []fr.Element, decimation Decimation, coset uint64, ctx *fftContext) {
 
-	numCPU := uint64(runtime.NumCPU())
+	numCPU := uint64(ctx.pool.NbWorkers())
 
 	// if coset != 0, scale by coset table
 	if coset != 0 {
 		scale := func(cosetTable []fr.Element) {
-			parallel.Execute(len(a), func(start, end int) {
+			ctx.execute(len(a), func(start, end int) {
 				for i := start; i < end; i++ {
 					a[i].Mul(&a[i], &cosetTable[i])
 				}
@@ -65,7 +82,7 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, coset uint64) {
 				// no precomputed coset, we adjust the index of the coset table
 				n := uint64(len(a))
 				nn := uint64(64 - bits.TrailingZeros64(n))
-				parallel.Execute(len(a), func(start, end int) {
+				ctx.execute(len(a), func(start, end int) {
 					for i := start; i < end; i++ {
 						irev := bits.Reverse64(uint64(i)) >> nn
 						a[i].Mul(&a[i], &domain.CosetTable[coset-1][int(irev)])
@@ -79,8 +96,8 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, coset uint64) {
 		}
 	}
 
-	// find the stage where we should stop spawning go routines in our recursive calls
-	// (ie when we have as many go routines running as we have available CPUs)
+	// find the stage where we should stop splitting our recursive calls in parallel tasks
+	// (ie when we have as many tasks running as we have available CPUs)
 	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(numCPU))
 	if numCPU <= 1 {
 		maxSplits = -1
@@ -88,9 +105,9 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, coset uint64) {
 
 	switch decimation {
 	case DIF:
-		difFFT(a, domain.Twiddles, 0, maxSplits, nil)
+		difFFT(a, domain.Twiddles, 0, maxSplits, ctx)
 	case DIT:
-		ditFFT(a, domain.Twiddles, 0, maxSplits, nil)
+		ditFFT(a, domain.Twiddles, 0, maxSplits, ctx)
 	default:
 		panic("not implemented")
 	}
@@ -102,27 +119,39 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, coset uint64) {
 // coset sets the shift of the fft (0 = no shift, standard fft)
 // len(a) must be a power of 2, and w must be a len(a)th root of unity in field F.
 func (domain *Domain) FFTInverse(a []fr.Element, decimation Decimation, coset uint64) {
+	domain.fftInverse(a, decimation, coset, domain.newFFTContext(nil, nil, len(a)))
+}
+
+// FFTInverseCtx is FFTInverse, and stops early when ctx is done. In this case, it returns
+// ctx.Err() and the content of a is unspecified.
+// If progress is not nil, it reports the progress of the FFT.
+func (domain *Domain) FFTInverseCtx(ctx context.Context, a []fr.Element, decimation Decimation, coset uint64, progress ecc.Progress) error {
+	domain.fftInverse(a, decimation, coset, domain.newFFTContext(ctx, progress, len(a)))
+	return ctx.Err()
+}
 
-	numCPU := uint64(runtime.NumCPU())
+func (domain *Domain) fftInverse(a []fr.Element, decimation Decimation, coset uint64, ctx *fftContext) {
 
-	// find the stage where we should stop spawning go routines in our recursive calls
-	// (ie when we have as many go routines running as we have available CPUs)
+	numCPU := uint64(ctx.pool.NbWorkers())
+
+	// find the stage where we should stop splitting our recursive calls in parallel tasks
+	// (ie when we have as many tasks running as we have available CPUs)
 	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(numCPU))
 	if numCPU <= 1 {
 		maxSplits = -1
 	}
 	switch decimation {
 	case DIF:
-		difFFT(a, domain.TwiddlesInv, 0, maxSplits, nil)
+		difFFT(a, domain.TwiddlesInv, 0, maxSplits, ctx)
 	case DIT:
-		ditFFT(a, domain.TwiddlesInv, 0, maxSplits, nil)
+		ditFFT(a, domain.TwiddlesInv, 0, maxSplits, ctx)
 	default:
 		panic("not implemented")
 	}
 
 	// scale by CardinalityInv (+ cosetTableInv is coset!=0)
 	if coset == 0 {
-		parallel.Execute(len(a), func(start, end int) {
+		ctx.execute(len(a), func(start, end int) {
 			for i := start; i < end; i++ {
 				a[i].Mul(&a[i], &domain.CardinalityInv)
 			}
@@ -131,7 +160,7 @@ func (domain *Domain) FFTInverse(a []fr.Element, decimation Decimation, coset ui
 	}
 
 	scale := func(cosetTable []fr.Element) {
-		parallel.Execute(len(a), func(start, end int) {
+		ctx.execute(len(a), func(start, end int) {
 			for i := start; i < end; i++ {
 				a[i].Mul(&a[i], &cosetTable[i]).
 					Mul(&a[i], &domain.CardinalityInv)
@@ -152,7 +181,7 @@ func (domain *Domain) FFTInverse(a []fr.Element, decimation Decimation, coset ui
 	// no precomputed coset, we adjust the index of the coset table
 	n := uint64(len(a))
 	nn := uint64(64 - bits.TrailingZeros64(n))
-	parallel.Execute(len(a), func(start, end int) {
+	ctx.execute(len(a), func(start, end int) {
 		for i := start; i < end; i++ {
 			irev := bits.Reverse64(uint64(i)) >> nn
 			a[i].Mul(&a[i], &domain.CosetTableInv[coset-1][int(irev)]).
@@ -162,12 +191,15 @@ func (domain *Domain) FFTInverse(a []fr.Element, decimation Decimation, coset ui
 
 }
 
-func difFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDone chan struct{}) {
-	if chDone != nil {
-		defer close(chDone)
-	}
+func difFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, ctx *fftContext) {
 
 	n := len(a)
+	if n >= cancellationThreshold && ctx.cancelled() {
+		return
+	}
+	if n == ctx.blockSize {
+		defer ctx.blockDone()
+	}
 	if n == 1 {
 		return
 	} else if n == 8 {
@@ -180,8 +212,8 @@ func difFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDon
 	// but we have only numCPU / stage cpus available
 	if (m > butterflyThreshold) && (stage < maxSplits) {
 		// 1 << stage == estimated used CPUs
-		numCPU := runtime.NumCPU() / (1 << (stage))
-		parallel.Execute(m, func(start, end int) {
+		numCPU := ctx.pool.NbWorkers() / (1 << (stage))
+		ctx.pool.Execute(m, func(start, end int) {
 			for i := start; i < end; i++ {
 				fr.Butterfly(&a[i], &a[i+m])
 				a[i+m].Mul(&a[i+m], &twiddles[stage][i])
@@ -202,22 +234,26 @@ func difFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDon
 
 	nextStage := stage + 1
 	if stage < maxSplits {
-		chDone := make(chan struct{}, 1)
-		go difFFT(a[m:n], twiddles, nextStage, maxSplits, chDone)
-		difFFT(a[0:m], twiddles, nextStage, maxSplits, nil)
-		<-chDone
+		ctx.pool.Execute(2, func(start, end int) {
+			for i := start; i < end; i++ {
+				difFFT(a[i*m:(i+1)*m], twiddles, nextStage, maxSplits, ctx)
+			}
+		}, 2)
 	} else {
-		difFFT(a[0:m], twiddles, nextStage, maxSplits, nil)
-		difFFT(a[m:n], twiddles, nextStage, maxSplits, nil)
+		difFFT(a[0:m], twiddles, nextStage, maxSplits, ctx)
+		difFFT(a[m:n], twiddles, nextStage, maxSplits, ctx)
 	}
 
 }
 
-func ditFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDone chan struct{}) {
-	if chDone != nil {
-		defer close(chDone)
-	}
+func ditFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, ctx *fftContext) {
 	n := len(a)
+	if n >= cancellationThreshold && ctx.cancelled() {
+		return
+	}
+	if n == ctx.blockSize {
+		defer ctx.blockDone()
+	}
 	if n == 1 {
 		return
 	} else if n == 8 {
@@ -229,14 +265,15 @@ func ditFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDon
 	nextStage := stage + 1
 
 	if stage < maxSplits {
-		// that's the only time we fire go routines
-		chDone := make(chan struct{}, 1)
-		go ditFFT(a[m:], twiddles, nextStage, maxSplits, chDone)
-		ditFFT(a[0:m], twiddles, nextStage, maxSplits, nil)
-		<-chDone
+		// that's the only time we split the recursive calls in parallel tasks
+		ctx.pool.Execute(2, func(start, end int) {
+			for i := start; i < end; i++ {
+				ditFFT(a[i*m:(i+1)*m], twiddles, nextStage, maxSplits, ctx)
+			}
+		}, 2)
 	} else {
-		ditFFT(a[0:m], twiddles, nextStage, maxSplits, nil)
-		ditFFT(a[m:n], twiddles, nextStage, maxSplits, nil)
+		ditFFT(a[0:m], twiddles, nextStage, maxSplits, ctx)
+		ditFFT(a[m:n], twiddles, nextStage, maxSplits, ctx)
 
 	}
 
@@ -244,8 +281,8 @@ func ditFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDon
 	// but we have only numCPU / stage cpus available
 	if (m > butterflyThreshold) && (stage < maxSplits) {
 		// 1 << stage == estimated used CPUs
-		numCPU := runtime.NumCPU() / (1 << (stage))
-		parallel.Execute(m, func(start, end int) {
+		numCPU := ctx.pool.NbWorkers() / (1 << (stage))
+		ctx.pool.Execute(m, func(start, end int) {
 			for k := start; k < end; k++ {
 				a[k+m].Mul(&a[k+m], &twiddles[stage][k])
 				fr.Butterfly(&a[k], &a[k+m])
@@ -261,6 +298,58 @@ func ditFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDon
 	}
 }
 
+// fftContext carries the cancellation, the progress reporting and the pool of an FFT
+type fftContext struct {
+	ctx       context.Context // nil if the FFT can't be cancelled
+	progress  func()          // called when a recursive call of size blockSize returns
+	blockSize int             // size of the recursive calls reporting the progress
+	pool      *pool.Pool      // workers running the FFT, or nil for the default pool
+}
+
+// newFFTContext returns the fftContext of an FFT of size n on domain; ctx and progress may be nil.
+// The progress is reported for each block of cancellationThreshold elements, or once if n is
+// smaller.
+func (domain *Domain) newFFTContext(ctx context.Context, progress ecc.Progress, n int) *fftContext {
+	res := &fftContext{ctx: ctx, blockSize: n, pool: domain.pool}
+	if res.blockSize > cancellationThreshold {
+		res.blockSize = cancellationThreshold
+	}
+	if progress != nil {
+		total := n / res.blockSize
+		var lock sync.Mutex
+		done := 0
+		res.progress = func() {
+			lock.Lock()
+			done++
+			progress(done, total)
+			lock.Unlock()
+		}
+	}
+	return res
+}
+
+// cancelled returns true if the FFT is cancelled
+func (ctx *fftContext) cancelled() bool {
+	return ctx.ctx != nil && ctx.ctx.Err() != nil
+}
+
+// blockDone reports that a block of the FFT is processed
+func (ctx *fftContext) blockDone() {
+	if ctx.progress != nil {
+		ctx.progress()
+	}
+}
+
+// execute runs work on the pool of the FFT, and stops early if the FFT is cancelled
+func (ctx *fftContext) execute(nbIterations int, work func(int, int)) {
+	if ctx.ctx == nil {
+		ctx.pool.Execute(nbIterations, work)
+		return
+	}
+	// the error is returned by FFTCtx and FFTInverseCtx
+	_ = ctx.pool.ExecuteCtx(ctx.ctx, nbIterations, work)
+}
+
 // BitReverse applies the bit-reversal permutation to a.
 // len(a) must be a power of 2 (as in every single function in this file)
 func BitReverse(a []fr.Element) {
@@ -17,12 +17,15 @@
 package fft
 
 import (
+	"context"
 	"math/big"
 	"strconv"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
 
+	"github.com/consensys/gnark-crypto/pool"
+
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
 	"github.com/leanovate/gopter/prop"
@@ -308,6 +311,95 @@ func TestFFT(t *testing.T) {
 
 }
 
+func TestFFTCtx(t *testing.T) {
+	const maxSize = 1 << 10
+
+	domain := NewDomain(maxSize, 2, true)
+
+	pol := make([]fr.Element, maxSize)
+	for i := 0; i < maxSize; i++ {
+		pol[i].SetRandom()
+	}
+	expected := make([]fr.Element, maxSize)
+	copy(expected, pol)
+	domain.FFT(expected, DIF, 1)
+
+	// a live context gives the same result as FFT, and the progress reaches the total
+	var lastDone, lastTotal int
+	monotonic := true
+	progress := func(done, total int) {
+		monotonic = monotonic && done == lastDone+1 && done <= total
+		lastDone, lastTotal = done, total
+	}
+	result := make([]fr.Element, maxSize)
+	copy(result, pol)
+	if err := domain.FFTCtx(context.Background(), result, DIF, 1, progress); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxSize; i++ {
+		if !result[i].Equal(&expected[i]) {
+			t.Fatal("FFTCtx differs from FFT")
+		}
+	}
+	if !monotonic || lastDone == 0 || lastDone != lastTotal {
+		t.Fatal("FFTCtx progress should increase up to total")
+	}
+	lastDone, lastTotal = 0, 0
+	if err := domain.FFTInverseCtx(context.Background(), result, DIT, 1, progress); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < maxSize; i++ {
+		if !result[i].Equal(&pol[i]) {
+			t.Fatal("FFTInverseCtx(FFTCtx) != id")
+		}
+	}
+	if !monotonic || lastDone == 0 || lastDone != lastTotal {
+		t.Fatal("FFTInverseCtx progress should increase up to total")
+	}
+
+	// a cancelled context stops the transforms
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := domain.FFTCtx(ctx, result, DIT, 0, nil); err != context.Canceled {
+		t.Fatal("FFTCtx should return the context error")
+	}
+	if err := domain.FFTInverseCtx(ctx, result, DIF, 0, nil); err != context.Canceled {
+		t.Fatal("FFTInverseCtx should return the context error")
+	}
+}
+
+func TestFFTWithPool(t *testing.T) {
+	const maxSize = 1 << 10
+
+	p := pool.New(2)
+	defer p.Stop()
+	domain := NewDomain(maxSize, 2, false)
+	domainWithPool := NewDomain(maxSize, 2, false, WithPool(p))
+
+	pol := make([]fr.Element, maxSize)
+	for i := 0; i < maxSize; i++ {
+		pol[i].SetRandom()
+	}
+	expected := make([]fr.Element, maxSize)
+	copy(expected, pol)
+	domain.FFT(expected, DIT, 1)
+
+	result := make([]fr.Element, maxSize)
+	copy(result, pol)
+	domainWithPool.FFT(result, DIT, 1)
+	for i := 0; i < maxSize; i++ {
+		if !result[i].Equal(&expected[i]) {
+			t.Fatal("FFT on a custom pool differs from FFT on the default pool")
+		}
+	}
+	domainWithPool.FFTInverse(result, DIF, 1)
+	for i := 0; i < maxSize; i++ {
+		if !result[i].Equal(&pol[i]) {
+			t.Fatal("FFTInverse(FFT) != id on a custom pool")
+		}
+	}
+}
+
 // --------------------------------------------------------------------
 // benches
 func BenchmarkBitReverse(b *testing.B) {
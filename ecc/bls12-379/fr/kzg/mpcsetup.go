@@ -0,0 +1,342 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"crypto/sha256"
+	"io"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-379"
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
+	"github.com/consensys/gnark-crypto/pool"
+)
+
+// powersOfTauDST domain separation tag used to hash to G2 in the proofs of knowledge
+const powersOfTauDST = "KZG_POWERS_OF_TAU_POK_"
+
+// PowersOfTau state of a powers of tau ceremony, after a sequence of contributions.
+//
+// Each participant multiplies the secret τ of the previous state by a fresh secret x,
+// without learning τ, and publishes a proof of knowledge of x. The resulting SRS is
+// secure as long as one participant discarded its contribution.
+//
+// implements io.ReaderFrom and io.WriterTo
+type PowersOfTau struct {
+	// Parameters [τ^i]gen in G1, [gen, [τ]gen] in G2
+	Parameters SRS
+
+	// PublicKey proof of knowledge of the last contribution
+	PublicKey PublicKey
+
+	// Hash of the transcript up to the last contribution
+	Hash [sha256.Size]byte
+}
+
+// PublicKey proof of knowledge of a contribution x to the ceremony.
+type PublicKey struct {
+	SG  bls12379.G1Affine // [s]gen, s random
+	SXG bls12379.G1Affine // [s·x]gen
+	XR  bls12379.G2Affine // [x]R, R hash of the challenge and of [s]gen, [s·x]gen to G2
+}
+
+// InitPowersOfTau returns the initial state of a ceremony for an SRS of given size (τ = 1).
+func InitPowersOfTau(size uint64) (*PowersOfTau, error) {
+	if size < 2 {
+		return nil, ErrMinSRSSize
+	}
+	var p PowersOfTau
+	_, _, gen1Aff, gen2Aff := bls12379.Generators()
+	p.Parameters.G1 = make([]bls12379.G1Affine, size)
+	for i := 0; i < len(p.Parameters.G1); i++ {
+		p.Parameters.G1[i] = gen1Aff
+	}
+	p.Parameters.G2[0] = gen2Aff
+	p.Parameters.G2[1] = gen2Aff
+	return &p, nil
+}
+
+// Contribute updates the state with fresh randomness.
+// The randomness is discarded when Contribute returns.
+// The pool of the computations is set with WithPool.
+func (p *PowersOfTau) Contribute(opts ...Option) error {
+	var x, s fr.Element
+	if _, err := x.SetRandom(); err != nil {
+		return err
+	}
+	if _, err := s.SetRandom(); err != nil {
+		return err
+	}
+	return p.contribute(&x, &s, newOptions(opts).pool)
+}
+
+// Verify checks that next is a valid contribution on top of p:
+// the hash chains the transcript, the proof of knowledge holds, the secret of next is
+// the secret of p times the contribution, and the points of next are successive powers.
+// The pool of the computations is set with WithPool.
+func (p *PowersOfTau) Verify(next *PowersOfTau, opts ...Option) error {
+	n := len(p.Parameters.G1)
+	if n < 2 || len(next.Parameters.G1) != n {
+		return ErrInvalidContributionSize
+	}
+
+	// the transcript hash must chain the public key of the contribution
+	if next.Hash != transcriptHash(&p.Hash, &next.PublicKey) {
+		return ErrInvalidContributionHash
+	}
+
+	// points must be in the subgroups, and the secret must not be 0
+	_, _, gen1Aff, gen2Aff := bls12379.Generators()
+	if !next.Parameters.G1[0].Equal(&gen1Aff) || !next.Parameters.G2[0].Equal(&gen2Aff) {
+		return ErrInvalidPowers
+	}
+	if next.PublicKey.SG.IsInfinity() || next.PublicKey.SXG.IsInfinity() || next.PublicKey.XR.IsInfinity() ||
+		next.Parameters.G1[1].IsInfinity() || next.Parameters.G2[1].IsInfinity() {
+		return ErrInvalidPoK
+	}
+	if !next.PublicKey.SG.IsInSubGroup() || !next.PublicKey.SXG.IsInSubGroup() || !next.PublicKey.XR.IsInSubGroup() ||
+		!next.Parameters.G2[1].IsInSubGroup() {
+		return ErrInvalidPoK
+	}
+	o := newOptions(opts)
+	var nbErrs uint64
+	o.pool.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			if !next.Parameters.G1[i].IsInSubGroup() {
+				atomic.AddUint64(&nbErrs, 1)
+			}
+		}
+	})
+	if nbErrs != 0 {
+		return ErrInvalidPowers
+	}
+
+	// proof of knowledge of x: e([s]gen, [x]R) == e([s·x]gen, R)
+	r, err := powersOfTauR(&p.Hash, &next.PublicKey.SG, &next.PublicKey.SXG)
+	if err != nil {
+		return err
+	}
+	if !sameRatio(next.PublicKey.SG, next.PublicKey.SXG, r, next.PublicKey.XR) {
+		return ErrInvalidPoK
+	}
+
+	// the contribution is x: τ' = τ·x in G1 and G2
+	if !sameRatio(p.Parameters.G1[1], next.Parameters.G1[1], r, next.PublicKey.XR) {
+		return ErrInvalidContributionUpdate
+	}
+	if !sameRatio(next.PublicKey.SG, next.PublicKey.SXG, p.Parameters.G2[1], next.Parameters.G2[1]) {
+		return ErrInvalidContributionUpdate
+	}
+
+	// successive powers: with ρ random, Σ ρ^i [τ^(i+1)]gen = τ · Σ ρ^i [τ^i]gen
+	if !areSuccessivePowers(next.Parameters.G1, &next.Parameters.G2, o.pool) {
+		return ErrInvalidPowers
+	}
+
+	return nil
+}
+
+// Finalize applies a last public contribution derived from beacon (for instance the
+// output of a randomness beacon after the last contribution) and returns the SRS.
+//
+// The contribution is deterministic, anyone can recompute the SRS from the last
+// verified state and beacon.
+// The pool of the computations is set with WithPool.
+func (p *PowersOfTau) Finalize(beacon []byte, opts ...Option) (*SRS, error) {
+	var x, s fr.Element
+	x.SetBytes(beaconHash(&p.Hash, beacon, 0))
+	s.SetBytes(beaconHash(&p.Hash, beacon, 1))
+
+	final := PowersOfTau{
+		Parameters: SRS{G1: make([]bls12379.G1Affine, len(p.Parameters.G1))},
+		Hash:       p.Hash,
+	}
+	copy(final.Parameters.G1, p.Parameters.G1)
+	final.Parameters.G2 = p.Parameters.G2
+	if err := final.contribute(&x, &s, newOptions(opts).pool); err != nil {
+		return nil, err
+	}
+
+	return &final.Parameters, nil
+}
+
+// contribute multiplies the secret of p by x, s being the randomness of the proof of knowledge
+func (p *PowersOfTau) contribute(x, s *fr.Element, pl *pool.Pool) error {
+	if x.IsZero() || s.IsZero() {
+		return ErrInvalidPoK
+	}
+
+	// proof of knowledge
+	_, _, gen1Aff, _ := bls12379.Generators()
+	var bs, bsx, bx big.Int
+	var sx fr.Element
+	sx.Mul(s, x)
+	p.PublicKey.SG.ScalarMultiplication(&gen1Aff, s.ToBigIntRegular(&bs))
+	p.PublicKey.SXG.ScalarMultiplication(&gen1Aff, sx.ToBigIntRegular(&bsx))
+	r, err := powersOfTauR(&p.Hash, &p.PublicKey.SG, &p.PublicKey.SXG)
+	if err != nil {
+		return err
+	}
+	x.ToBigIntRegular(&bx)
+	p.PublicKey.XR.ScalarMultiplication(&r, &bx)
+
+	// [τ^i]gen <- [(τ·x)^i]gen
+	n := len(p.Parameters.G1)
+	points := make([]bls12379.G1Jac, n)
+	pl.Execute(n, func(start, end int) {
+		var xi fr.Element
+		var bxi big.Int
+		xi.Exp(*x, big.NewInt(int64(start)))
+		for i := start; i < end; i++ {
+			points[i].FromAffine(&p.Parameters.G1[i])
+			points[i].ScalarMultiplication(&points[i], xi.ToBigIntRegular(&bxi))
+			xi.Mul(&xi, x)
+		}
+	})
+	bls12379.BatchJacobianToAffineG1(points, p.Parameters.G1)
+	p.Parameters.G2[1].ScalarMultiplication(&p.Parameters.G2[1], &bx)
+
+	p.Hash = transcriptHash(&p.Hash, &p.PublicKey)
+
+	return nil
+}
+
+// powersOfTauR returns the point R of the proof of knowledge, bound to the previous
+// transcript hash and to [s]gen, [s·x]gen
+func powersOfTauR(challenge *[sha256.Size]byte, sg, sxg *bls12379.G1Affine) (bls12379.G2Affine, error) {
+	bsg := sg.Bytes()
+	bsxg := sxg.Bytes()
+	msg := make([]byte, 0, len(challenge)+len(bsg)+len(bsxg))
+	msg = append(msg, challenge[:]...)
+	msg = append(msg, bsg[:]...)
+	msg = append(msg, bsxg[:]...)
+	return bls12379.HashToCurveG2Svdw(msg, []byte(powersOfTauDST))
+}
+
+// transcriptHash returns sha256(previous || public key)
+func transcriptHash(previous *[sha256.Size]byte, pk *PublicKey) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write(previous[:])
+	bsg := pk.SG.Bytes()
+	bsxg := pk.SXG.Bytes()
+	bxr := pk.XR.Bytes()
+	h.Write(bsg[:])
+	h.Write(bsxg[:])
+	h.Write(bxr[:])
+	var res [sha256.Size]byte
+	copy(res[:], h.Sum(nil))
+	return res
+}
+
+// beaconHash returns sha256(transcript hash || beacon || index)
+func beaconHash(previous *[sha256.Size]byte, beacon []byte, index byte) []byte {
+	h := sha256.New()
+	h.Write(previous[:])
+	h.Write(beacon)
+	h.Write([]byte{index})
+	return h.Sum(nil)
+}
+
+// sameRatio returns true if e(a1, b2) == e(b1, a2), that is if b1/a1 == b2/a2
+func sameRatio(a1, b1 bls12379.G1Affine, a2, b2 bls12379.G2Affine) bool {
+	var nb1 bls12379.G1Affine
+	nb1.Neg(&b1)
+	res, err := bls12379.PairingCheck(
+		[]bls12379.G1Affine{a1, nb1},
+		[]bls12379.G2Affine{b2, a2},
+	)
+	return err == nil && res
+}
+
+// areSuccessivePowers checks with a random linear combination that g1[i+1] = τ·g1[i],
+// where g2 = [gen, [τ]gen]
+func areSuccessivePowers(g1 []bls12379.G1Affine, g2 *[2]bls12379.G2Affine, pl *pool.Pool) bool {
+	n := len(g1) - 1
+
+	var rho fr.Element
+	if _, err := rho.SetRandom(); err != nil {
+		return false
+	}
+	rhos := make([]fr.Element, n)
+	rhos[0].SetOne()
+	for i := 1; i < n; i++ {
+		rhos[i].Mul(&rhos[i-1], &rho)
+	}
+
+	var l, r bls12379.G1Affine
+	config := ecc.MultiExpConfig{ScalarsMont: true, Pool: pl}
+	if _, err := l.MultiExp(g1[:n], rhos, config); err != nil {
+		return false
+	}
+	if _, err := r.MultiExp(g1[1:], rhos, config); err != nil {
+		return false
+	}
+
+	return sameRatio(l, r, g2[0], g2[1])
+}
+
+// WriteTo writes binary encoding of the PowersOfTau
+func (p *PowersOfTau) WriteTo(w io.Writer) (int64, error) {
+	n, err := p.Parameters.WriteTo(w)
+	if err != nil {
+		return n, err
+	}
+
+	enc := bls12379.NewEncoder(w)
+
+	toEncode := []interface{}{
+		&p.PublicKey.SG,
+		&p.PublicKey.SXG,
+		&p.PublicKey.XR,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return n + enc.BytesWritten(), err
+		}
+	}
+
+	written, err := w.Write(p.Hash[:])
+	return n + enc.BytesWritten() + int64(written), err
+}
+
+// ReadFrom decodes PowersOfTau data from reader.
+func (p *PowersOfTau) ReadFrom(r io.Reader) (int64, error) {
+	n, err := p.Parameters.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+
+	dec := bls12379.NewDecoder(r)
+
+	toDecode := []interface{}{
+		&p.PublicKey.SG,
+		&p.PublicKey.SXG,
+		&p.PublicKey.XR,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return n + dec.BytesRead(), err
+		}
+	}
+
+	read, err := io.ReadFull(r, p.Hash[:])
+	return n + dec.BytesRead() + int64(read), err
+}
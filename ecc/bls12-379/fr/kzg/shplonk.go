@@ -0,0 +1,441 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"encoding/binary"
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-379"
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr/polynomial"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// ShplonkOpeningProof opening proof of many polynomials, each at its own set of points,
+// of constant size (https://eprint.iacr.org/2020/081.pdf, section 4).
+type ShplonkOpeningProof struct {
+	// W commitment to Σ_i γ^i Z_{T\S_i}(f_i-r_i)/Z_T
+	W bls12379.G1Affine
+
+	// WPrime commitment to L/(X-z), L the linearized polynomial, vanishing at z
+	WPrime bls12379.G1Affine
+
+	// ClaimedValues[i][j] purported value of the i-th polynomial at its j-th point
+	ClaimedValues [][]fr.Element
+}
+
+// BatchOpenShplonk opens polynomials[i] at points[i], with a proof of two G1 points whatever
+// the number of polynomials and points. The challenges are derived with Fiat Shamir.
+//
+// * polynomials list of polynomials to open, in canonical form
+// * digests list of commitments of the polynomials
+// * points points[i] are the distinct points at which polynomials[i] is opened
+func BatchOpenShplonk(polynomials []polynomial.Polynomial, digests []Digest, points [][]fr.Element, hf hash.Hash, srs *SRS) (ShplonkOpeningProof, error) {
+
+	var res ShplonkOpeningProof
+
+	nbPolynomials := len(polynomials)
+	if nbPolynomials == 0 || nbPolynomials != len(digests) {
+		return res, ErrInvalidNbDigests
+	}
+	if nbPolynomials != len(points) {
+		return res, ErrInvalidNbPoints
+	}
+	maxSize := 0
+	for i := 0; i < nbPolynomials; i++ {
+		if len(polynomials[i]) == 0 || len(polynomials[i]) > len(srs.G1) {
+			return res, ErrInvalidPolynomialSize
+		}
+		if len(polynomials[i]) > maxSize {
+			maxSize = len(polynomials[i])
+		}
+	}
+	t, err := unionOfPoints(points)
+	if err != nil {
+		return res, err
+	}
+
+	// claimed values
+	res.ClaimedValues = make([][]fr.Element, nbPolynomials)
+	for i := 0; i < nbPolynomials; i++ {
+		res.ClaimedValues[i] = make([]fr.Element, len(points[i]))
+		for j := 0; j < len(points[i]); j++ {
+			res.ClaimedValues[i][j] = polynomials[i].Eval(&points[i][j])
+		}
+	}
+
+	fs := fiatshamir.NewTranscript(hf, "gamma", "z")
+	gamma, err := deriveShplonkGamma(&fs, digests, points, res.ClaimedValues)
+	if err != nil {
+		return res, err
+	}
+
+	// f = Σ_i γ^i Z_{T\S_i}(f_i-r_i), of degree < maxSize + |T| - 1
+	var f polynomial.Polynomial
+	var gammaI fr.Element
+	gammaI.SetOne()
+	for i := 0; i < nbPolynomials; i++ {
+		ri := interpolate(points[i], res.ClaimedValues[i])
+		fi := make(polynomial.Polynomial, len(polynomials[i]))
+		if len(ri) > len(fi) {
+			fi = make(polynomial.Polynomial, len(ri))
+		}
+		copy(fi, polynomials[i])
+		for j := 0; j < len(ri); j++ {
+			fi[j].Sub(&fi[j], &ri[j])
+		}
+		zi := vanishingPolynomial(complementOfPoints(t, points[i]))
+		tmp := mulPolynomials(fi, zi)
+		if len(tmp) > len(f) {
+			f = append(f, make(polynomial.Polynomial, len(tmp)-len(f))...)
+		}
+		for j := 0; j < len(tmp); j++ {
+			tmp[j].Mul(&tmp[j], &gammaI)
+			f[j].Add(&f[j], &tmp[j])
+		}
+		gammaI.Mul(&gammaI, &gamma)
+	}
+
+	// W = [f/Z_T]
+	zt := vanishingPolynomial(t)
+	q := divideByMonic(f, zt)
+	res.W, err = Commit(q, srs)
+	if err != nil {
+		return res, err
+	}
+
+	// derive z, binded to W
+	z, err := deriveShplonkZ(&fs, &res.W)
+	if err != nil {
+		return res, err
+	}
+
+	// L = Σ_i γ^i Z_{T\S_i}(z)(f_i-r_i(z)) - Z_T(z)·f/Z_T
+	if len(q) > maxSize {
+		maxSize = len(q)
+	}
+	l := make(polynomial.Polynomial, maxSize)
+	gammaI.SetOne()
+	var zti, riz, c fr.Element
+	for i := 0; i < nbPolynomials; i++ {
+		zti = evalVanishing(complementOfPoints(t, points[i]), &z)
+		riz = interpolateAt(points[i], res.ClaimedValues[i], &z)
+		c.Mul(&gammaI, &zti)
+		for j := 0; j < len(polynomials[i]); j++ {
+			var tmp fr.Element
+			tmp.Mul(&polynomials[i][j], &c)
+			l[j].Add(&l[j], &tmp)
+		}
+		riz.Mul(&riz, &c)
+		l[0].Sub(&l[0], &riz)
+		gammaI.Mul(&gammaI, &gamma)
+	}
+	ztz := evalVanishing(t, &z)
+	for j := 0; j < len(q); j++ {
+		var tmp fr.Element
+		tmp.Mul(&q[j], &ztz)
+		l[j].Sub(&l[j], &tmp)
+	}
+
+	// W' = [L/(X-z)], L(z) = 0
+	var zero fr.Element
+	h := dividePolyByXminusA(l, zero, z)
+	if len(h) == 0 {
+		h = polynomial.Polynomial{zero}
+	}
+	res.WPrime, err = Commit(h, srs)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// BatchVerifyShplonk verifies a ShplonkOpeningProof of digests[i] at points[i], with
+// one multi exponentiation and one pairing check.
+//
+// * digests list of commitments of the opened polynomials
+// * points points[i] are the distinct points at which digests[i] is opened
+func BatchVerifyShplonk(proof *ShplonkOpeningProof, digests []Digest, points [][]fr.Element, hf hash.Hash, srs *SRS) error {
+
+	nbDigests := len(digests)
+	if nbDigests == 0 || nbDigests != len(proof.ClaimedValues) {
+		return ErrInvalidNbDigests
+	}
+	if nbDigests != len(points) {
+		return ErrInvalidNbPoints
+	}
+	for i := 0; i < nbDigests; i++ {
+		if len(points[i]) != len(proof.ClaimedValues[i]) {
+			return ErrInvalidNbPoints
+		}
+	}
+	t, err := unionOfPoints(points)
+	if err != nil {
+		return err
+	}
+
+	fs := fiatshamir.NewTranscript(hf, "gamma", "z")
+	gamma, err := deriveShplonkGamma(&fs, digests, points, proof.ClaimedValues)
+	if err != nil {
+		return err
+	}
+	z, err := deriveShplonkZ(&fs, &proof.W)
+	if err != nil {
+		return err
+	}
+
+	// F + z·W' = Σ_i γ^i Z_{T\S_i}(z)[f_i] - [Σ_i γ^i Z_{T\S_i}(z) r_i(z)] - Z_T(z)·W + z·W'
+	points1 := make([]bls12379.G1Affine, nbDigests+3)
+	scalars := make([]fr.Element, nbDigests+3)
+	copy(points1, digests)
+	var gammaI, zti, riz, sumRiz fr.Element
+	gammaI.SetOne()
+	for i := 0; i < nbDigests; i++ {
+		zti = evalVanishing(complementOfPoints(t, points[i]), &z)
+		scalars[i].Mul(&gammaI, &zti)
+		riz = interpolateAt(points[i], proof.ClaimedValues[i], &z)
+		riz.Mul(&riz, &scalars[i])
+		sumRiz.Add(&sumRiz, &riz)
+		gammaI.Mul(&gammaI, &gamma)
+	}
+	points1[nbDigests] = srs.G1[0]
+	scalars[nbDigests].Neg(&sumRiz)
+	points1[nbDigests+1] = proof.W
+	scalars[nbDigests+1] = evalVanishing(t, &z)
+	scalars[nbDigests+1].Neg(&scalars[nbDigests+1])
+	points1[nbDigests+2] = proof.WPrime
+	scalars[nbDigests+2] = z
+
+	var lhs bls12379.G1Affine
+	if _, err := lhs.MultiExp(points1, scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return err
+	}
+
+	// e(F + z·W', [1]) == e(W', [α])
+	var negWPrime bls12379.G1Affine
+	negWPrime.Neg(&proof.WPrime)
+	check, err := bls12379.PairingCheck(
+		[]bls12379.G1Affine{lhs, negWPrime},
+		[]bls12379.G2Affine{srs.G2[0], srs.G2[1]},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// deriveShplonkGamma derives the challenge γ, binded to the digests, the points and the claimed values.
+// The number of digests and the number of points of each digest are binded too, so that moving a
+// point from a polynomial to another changes γ.
+func deriveShplonkGamma(fs *fiatshamir.Transcript, digests []Digest, points [][]fr.Element, claimedValues [][]fr.Element) (fr.Element, error) {
+	var gamma fr.Element
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], uint64(len(digests)))
+	if err := fs.Bind("gamma", size[:]); err != nil {
+		return gamma, err
+	}
+	for i := 0; i < len(digests); i++ {
+		if err := fs.Bind("gamma", digests[i].Marshal()); err != nil {
+			return gamma, err
+		}
+	}
+	for i := 0; i < len(points); i++ {
+		binary.BigEndian.PutUint64(size[:], uint64(len(points[i])))
+		if err := fs.Bind("gamma", size[:]); err != nil {
+			return gamma, err
+		}
+		for j := 0; j < len(points[i]); j++ {
+			if err := fs.Bind("gamma", points[i][j].Marshal()); err != nil {
+				return gamma, err
+			}
+			if err := fs.Bind("gamma", claimedValues[i][j].Marshal()); err != nil {
+				return gamma, err
+			}
+		}
+	}
+	b, err := fs.ComputeChallenge("gamma")
+	if err != nil {
+		return gamma, err
+	}
+	gamma.SetBytes(b)
+	return gamma, nil
+}
+
+// deriveShplonkZ derives the challenge z, binded to W
+func deriveShplonkZ(fs *fiatshamir.Transcript, w *bls12379.G1Affine) (fr.Element, error) {
+	var z fr.Element
+	if err := fs.Bind("z", w.Marshal()); err != nil {
+		return z, err
+	}
+	b, err := fs.ComputeChallenge("z")
+	if err != nil {
+		return z, err
+	}
+	z.SetBytes(b)
+	return z, nil
+}
+
+// unionOfPoints returns the distinct points of points, and checks that each points[i]
+// is a non empty set of distinct points
+func unionOfPoints(points [][]fr.Element) ([]fr.Element, error) {
+	var res []fr.Element
+	seen := make(map[fr.Element]bool)
+	for i := 0; i < len(points); i++ {
+		if len(points[i]) == 0 {
+			return nil, ErrInvalidPoints
+		}
+		local := make(map[fr.Element]bool, len(points[i]))
+		for j := 0; j < len(points[i]); j++ {
+			if local[points[i][j]] {
+				return nil, ErrInvalidPoints
+			}
+			local[points[i][j]] = true
+			if !seen[points[i][j]] {
+				seen[points[i][j]] = true
+				res = append(res, points[i][j])
+			}
+		}
+	}
+	return res, nil
+}
+
+// complementOfPoints returns the points of t which are not in s
+func complementOfPoints(t, s []fr.Element) []fr.Element {
+	res := make([]fr.Element, 0, len(t)-len(s))
+	for i := 0; i < len(t); i++ {
+		found := false
+		for j := 0; j < len(s) && !found; j++ {
+			found = t[i].Equal(&s[j])
+		}
+		if !found {
+			res = append(res, t[i])
+		}
+	}
+	return res
+}
+
+// vanishingPolynomial returns Π_i (X - points[i]) in canonical form
+func vanishingPolynomial(points []fr.Element) polynomial.Polynomial {
+	res := make(polynomial.Polynomial, len(points)+1)
+	res[0].SetOne()
+	for i := 0; i < len(points); i++ {
+		// res = res·(X - points[i])
+		for j := i + 1; j > 0; j-- {
+			var tmp fr.Element
+			tmp.Mul(&res[j], &points[i])
+			res[j].Sub(&res[j-1], &tmp)
+		}
+		res[0].Mul(&res[0], &points[i]).Neg(&res[0])
+	}
+	return res
+}
+
+// evalVanishing returns Π_i (x - points[i])
+func evalVanishing(points []fr.Element, x *fr.Element) fr.Element {
+	var res, tmp fr.Element
+	res.SetOne()
+	for i := 0; i < len(points); i++ {
+		tmp.Sub(x, &points[i])
+		res.Mul(&res, &tmp)
+	}
+	return res
+}
+
+// interpolate returns the polynomial of degree < len(points) taking values at points, in canonical form
+func interpolate(points, values []fr.Element) polynomial.Polynomial {
+	res := make(polynomial.Polynomial, len(points))
+	for i := 0; i < len(points); i++ {
+		// values[i]·Π_{j≠i} (X - points[j])/(points[i] - points[j])
+		others := make([]fr.Element, 0, len(points)-1)
+		var den, tmp fr.Element
+		den.SetOne()
+		for j := 0; j < len(points); j++ {
+			if j == i {
+				continue
+			}
+			others = append(others, points[j])
+			tmp.Sub(&points[i], &points[j])
+			den.Mul(&den, &tmp)
+		}
+		den.Inverse(&den).Mul(&den, &values[i])
+		li := vanishingPolynomial(others)
+		for j := 0; j < len(li); j++ {
+			tmp.Mul(&li[j], &den)
+			res[j].Add(&res[j], &tmp)
+		}
+	}
+	return res
+}
+
+// interpolateAt returns the value at x of the polynomial of degree < len(points) taking values at points
+func interpolateAt(points, values []fr.Element, x *fr.Element) fr.Element {
+	var res, num, den, tmp fr.Element
+	for i := 0; i < len(points); i++ {
+		num.SetOne()
+		den.SetOne()
+		for j := 0; j < len(points); j++ {
+			if j == i {
+				continue
+			}
+			tmp.Sub(x, &points[j])
+			num.Mul(&num, &tmp)
+			tmp.Sub(&points[i], &points[j])
+			den.Mul(&den, &tmp)
+		}
+		den.Inverse(&den)
+		num.Mul(&num, &den).Mul(&num, &values[i])
+		res.Add(&res, &num)
+	}
+	return res
+}
+
+// mulPolynomials returns a·b, b is assumed to be small
+func mulPolynomials(a, b polynomial.Polynomial) polynomial.Polynomial {
+	res := make(polynomial.Polynomial, len(a)+len(b)-1)
+	var tmp fr.Element
+	for j := 0; j < len(b); j++ {
+		for i := 0; i < len(a); i++ {
+			tmp.Mul(&a[i], &b[j])
+			res[i+j].Add(&res[i+j], &tmp)
+		}
+	}
+	return res
+}
+
+// divideByMonic returns the quotient of the division of f by the monic polynomial d.
+// f memory is re-used.
+func divideByMonic(f, d polynomial.Polynomial) polynomial.Polynomial {
+	k := len(d) - 1
+	if len(f) <= k {
+		return polynomial.Polynomial{fr.Element{}}
+	}
+	var tmp fr.Element
+	for i := len(f) - 1; i >= k; i-- {
+		// f[i] is the coefficient of the quotient of degree i-k
+		for j := 0; j < k; j++ {
+			tmp.Mul(&f[i], &d[j])
+			f[i-k+j].Sub(&f[i-k+j], &tmp)
+		}
+	}
+	return f[k:]
+}
@@ -0,0 +1,176 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr/polynomial"
+	"github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+func TestShplonk(t *testing.T) {
+
+	// polynomials of different sizes, opened at ζ and ωζ as in PLONK, at ζ, and at 3 other points
+	domain := fft.NewDomain(64, 0, false)
+	var zeta, omegaZeta fr.Element
+	zeta.SetRandom()
+	omegaZeta.Mul(&zeta, &domain.Generator)
+	points := make([][]fr.Element, 4)
+	points[0] = []fr.Element{zeta, omegaZeta}
+	points[1] = []fr.Element{zeta}
+	points[2] = make([]fr.Element, 3)
+	for i := 0; i < len(points[2]); i++ {
+		points[2][i].SetRandom()
+	}
+	points[3] = []fr.Element{omegaZeta, zeta}
+
+	polynomials := []polynomial.Polynomial{
+		randomPolynomial(60),
+		randomPolynomial(64),
+		randomPolynomial(20),
+		randomPolynomial(2),
+	}
+	digests := make([]Digest, len(polynomials))
+	for i := 0; i < len(polynomials); i++ {
+		var err error
+		digests[i], err = Commit(polynomials[i], testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hf := sha256.New()
+	proof, err := BatchOpenShplonk(polynomials, digests, points, hf, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < len(points); i++ {
+		for j := 0; j < len(points[i]); j++ {
+			expected := polynomials[i].Eval(&points[i][j])
+			if !proof.ClaimedValues[i][j].Equal(&expected) {
+				t.Fatal("inconsistant claimed value")
+			}
+		}
+	}
+
+	// verify correct proof
+	if err := BatchVerifyShplonk(&proof, digests, points, hf, testSRS); err != nil {
+		t.Fatal(err)
+	}
+
+	// verify wrong claimed value
+	proof.ClaimedValues[2][1].Double(&proof.ClaimedValues[2][1])
+	if err := BatchVerifyShplonk(&proof, digests, points, hf, testSRS); err == nil {
+		t.Fatal("verifying wrong claimed value should have failed")
+	}
+	proof.ClaimedValues[2][1] = polynomials[2].Eval(&points[2][1])
+
+	// verify wrong digest
+	digests[1], digests[0] = digests[0], digests[1]
+	if err := BatchVerifyShplonk(&proof, digests, points, hf, testSRS); err == nil {
+		t.Fatal("verifying wrong digest should have failed")
+	}
+	digests[1], digests[0] = digests[0], digests[1]
+
+	// verify with the point ωζ of the first polynomial moved to the second one: the points and
+	// the claimed values are binded in the same order, but γ must change
+	moved := proof
+	moved.ClaimedValues = [][]fr.Element{
+		{proof.ClaimedValues[0][0]},
+		{proof.ClaimedValues[0][1], proof.ClaimedValues[1][0]},
+		proof.ClaimedValues[2],
+		proof.ClaimedValues[3],
+	}
+	movedPoints := [][]fr.Element{
+		{zeta},
+		{omegaZeta, zeta},
+		points[2],
+		points[3],
+	}
+	fs := fiatshamir.NewTranscript(hf, "gamma")
+	gamma, err := deriveShplonkGamma(&fs, digests, points, proof.ClaimedValues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs = fiatshamir.NewTranscript(hf, "gamma")
+	movedGamma, err := deriveShplonkGamma(&fs, digests, movedPoints, moved.ClaimedValues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gamma.Equal(&movedGamma) {
+		t.Fatal("moving a point to another polynomial should change gamma")
+	}
+	if err := BatchVerifyShplonk(&moved, digests, movedPoints, hf, testSRS); err == nil {
+		t.Fatal("verifying a point moved to another polynomial should have failed")
+	}
+
+	// verify wrong W
+	proof.W.Add(&proof.W, &proof.WPrime)
+	if err := BatchVerifyShplonk(&proof, digests, points, hf, testSRS); err == nil {
+		t.Fatal("verifying wrong proof should have failed")
+	}
+
+	// invalid points
+	points[0][1] = points[0][0]
+	if _, err := BatchOpenShplonk(polynomials, digests, points, hf, testSRS); err != ErrInvalidPoints {
+		t.Fatal("duplicate points should be rejected")
+	}
+	if _, err := BatchOpenShplonk(polynomials, digests, points[1:], hf, testSRS); err != ErrInvalidNbPoints {
+		t.Fatal("wrong number of points should be rejected")
+	}
+}
+
+func BenchmarkShplonkPlonk(b *testing.B) {
+
+	// PLONK like openings: 6 polynomials at ζ, 1 at ζ and ωζ
+	const nbPolynomials = 7
+	domain := fft.NewDomain(uint64(len(testSRS.G1)), 0, false)
+	var zeta, omegaZeta fr.Element
+	zeta.SetRandom()
+	omegaZeta.Mul(&zeta, &domain.Generator)
+	polynomials := make([]polynomial.Polynomial, nbPolynomials)
+	digests := make([]Digest, nbPolynomials)
+	points := make([][]fr.Element, nbPolynomials)
+	for i := 0; i < nbPolynomials; i++ {
+		polynomials[i] = randomPolynomial(len(testSRS.G1) - 2)
+		digests[i], _ = Commit(polynomials[i], testSRS)
+		points[i] = []fr.Element{zeta}
+	}
+	points[0] = append(points[0], omegaZeta)
+
+	hf := sha256.New()
+	proof, err := BatchOpenShplonk(polynomials, digests, points, hf, testSRS)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("open", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = BatchOpenShplonk(polynomials, digests, points, hf, testSRS)
+		}
+	})
+
+	b.Run("verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = BatchVerifyShplonk(&proof, digests, points, hf, testSRS)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mimc
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
+	"golang.org/x/crypto/sha3"
+)
+
+// default parameters of the MiMC-Feistel sponge: the exponent is the smallest e >= 5 coprime with r-1,
+// and the number of rounds is 2·ceil(log_e(r))
+const (
+	spongeSeed     = "mimcsponge"
+	spongeExponent = 7
+	spongeNbRounds = 182
+)
+
+// Sponge is the MiMC-Feistel sponge of circomlib's MiMCSponge: each absorbed element is added to the left
+// half of the state (xL, xR), which is then permuted with the MiMC-Feistel permutation keyed with key.
+// The outputs are the successive left halves of the state, permuted between two outputs.
+type Sponge struct {
+	constants []fr.Element // round constants, the first and the last ones are 0
+	exponent  int
+	key       fr.Element
+	xL, xR    fr.Element
+}
+
+// NewSponge returns the MiMC-Feistel sponge keyed with key, with the default parameters or the exponent
+// and the number of rounds set by opts. The round constants are derived by iterating keccak256 from
+// "mimcsponge", as in circomlib.
+func NewSponge(key fr.Element, opts ...Option) (*Sponge, error) {
+	cfg, err := newConfig(spongeExponent, spongeNbRounds, 2, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.nbRounds < 2 {
+		return nil, ErrInvalidNbRounds
+	}
+	return &Sponge{
+		constants: newFeistelConstants(spongeSeed, cfg.nbRounds),
+		exponent:  cfg.exponent,
+		key:       key,
+	}, nil
+}
+
+// newFeistelConstants derives nbRounds constants from seed as circomlib: c = keccak256(seed), then
+// c_i = keccak256(c_{i-1}) mod r, the first and the last constants being 0
+func newFeistelConstants(seed string, nbRounds int) []fr.Element {
+	res := make([]fr.Element, nbRounds)
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(seed))
+	c := h.Sum(nil)
+	for i := 1; i < nbRounds-1; i++ {
+		h.Reset()
+		h.Write(c)
+		c = h.Sum(c[:0])
+		res[i].SetBytes(c)
+	}
+	return res
+}
+
+// Permutation sets (xL, xR) to the MiMC-Feistel permutation of (xL, xR) keyed with the key of the sponge
+func (s *Sponge) Permutation(xL, xR *fr.Element) {
+	var t fr.Element
+	last := len(s.constants) - 1
+	for i := 0; i <= last; i++ {
+		// t = (xL + k + c_i)^e
+		t.Add(xL, &s.key).Add(&t, &s.constants[i])
+		sBox(&t, s.exponent)
+		if i < last {
+			t.Add(&t, xR)
+			*xR = *xL
+			*xL = t
+		} else {
+			xR.Add(xR, &t)
+		}
+	}
+}
+
+// Absorb adds each element to the left half of the state, and permutes the state
+func (s *Sponge) Absorb(elements ...fr.Element) {
+	for i := range elements {
+		s.xL.Add(&s.xL, &elements[i])
+		s.Permutation(&s.xL, &s.xR)
+	}
+}
+
+// Squeeze returns nbOutputs elements: the left half of the state, then the left half of the state
+// permuted again for each other output
+func (s *Sponge) Squeeze(nbOutputs int) []fr.Element {
+	res := make([]fr.Element, nbOutputs)
+	for i := range res {
+		if i > 0 {
+			s.Permutation(&s.xL, &s.xR)
+		}
+		res[i] = s.xL
+	}
+	return res
+}
+
+// Reset sets the state of the sponge to 0
+func (s *Sponge) Reset() {
+	s.xL.SetZero()
+	s.xR.SetZero()
+}
+
+// MultiHash returns the nbOutputs elements squeezed from the default sponge keyed with key after absorbing
+// inputs
+func MultiHash(inputs []fr.Element, key fr.Element, nbOutputs int) ([]fr.Element, error) {
+	if nbOutputs < 1 {
+		return nil, errors.New("the number of outputs should be at least 1")
+	}
+	s, err := NewSponge(key)
+	if err != nil {
+		return nil, err
+	}
+	s.Absorb(inputs...)
+	return s.Squeeze(nbOutputs), nil
+}
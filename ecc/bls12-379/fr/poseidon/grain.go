@@ -0,0 +1,107 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package poseidon
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
+)
+
+// grain is the self-shrinking Grain LFSR generating the constants of the permutation, as in the
+// reference implementation
+type grain struct {
+	state [80]uint8
+	pos   int // index of the oldest bit of state
+}
+
+// newGrain returns the LFSR initialized with the parameters of the permutation
+func newGrain(width, nbFullRounds, nbPartialRounds int) *grain {
+	g := new(grain)
+	i := 0
+	setBits := func(v uint64, nbBits int) {
+		for j := nbBits - 1; j >= 0; j-- {
+			g.state[i] = uint8(v>>j) & 1
+			i++
+		}
+	}
+	setBits(1, 2) // prime field
+	setBits(0, 4) // s-box x^alpha
+	setBits(fr.Bits, 12)
+	setBits(uint64(width), 12)
+	setBits(uint64(nbFullRounds), 10)
+	setBits(uint64(nbPartialRounds), 10)
+	setBits((1<<30)-1, 30)
+
+	// discard the first 160 bits
+	for j := 0; j < 160; j++ {
+		g.nextBit()
+	}
+	return g
+}
+
+// nextBit updates the LFSR and returns the new bit
+func (g *grain) nextBit() uint8 {
+	s := &g.state
+	b := s[(g.pos+62)%80] ^ s[(g.pos+51)%80] ^ s[(g.pos+38)%80] ^ s[(g.pos+23)%80] ^ s[(g.pos+13)%80] ^ s[g.pos]
+	s[g.pos] = b
+	g.pos = (g.pos + 1) % 80
+	return b
+}
+
+// randomBit returns the second bit of the next pair of bits of the LFSR whose first bit is 1
+func (g *grain) randomBit() uint8 {
+	for {
+		b1, b2 := g.nextBit(), g.nextBit()
+		if b1 == 1 {
+			return b2
+		}
+	}
+}
+
+// randomBits sets res to an integer of fr.Bits random bits, most significant first
+func (g *grain) randomBits(res *big.Int) {
+	res.SetUint64(0)
+	for i := 0; i < fr.Bits; i++ {
+		res.Lsh(res, 1)
+		if g.randomBit() == 1 {
+			res.SetBit(res, 0, 1)
+		}
+	}
+}
+
+// nextElement returns the next random field element, rejecting the integers larger than the modulus
+func (g *grain) nextElement() fr.Element {
+	var b big.Int
+	for {
+		g.randomBits(&b)
+		if b.Cmp(fr.Modulus()) < 0 {
+			var res fr.Element
+			res.SetBigInt(&b)
+			return res
+		}
+	}
+}
+
+// nextElementReduced returns the next random field element, reducing the random integer modulo the modulus
+func (g *grain) nextElementReduced() fr.Element {
+	var b big.Int
+	g.randomBits(&b)
+	var res fr.Element
+	res.SetBigInt(&b)
+	return res
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package poseidon2
+
+import (
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
+)
+
+// BlockSize is the size in bytes of the field elements absorbed by the sponge
+const BlockSize = fr.Bytes
+
+// digest is a sponge of capacity 1 and rate Width-1 over the permutation: the message is absorbed in
+// the last Width-1 elements of the state, initially 0, and the digest is the first element of the state
+type digest struct {
+	params *Parameters
+	data   []byte // data to hash
+}
+
+// NewSponge returns a hash.Hash absorbing the data by chunks of BlockSize bytes, interpreted as big
+// endian integers reduced modulo the modulus. A last partial chunk is padded with leading zeros, and
+// the last block of Width-1 elements with zeros.
+func NewSponge(params *Parameters) hash.Hash {
+	return &digest{params: params}
+}
+
+// NewPoseidon2 returns the sponge over the default Poseidon2 permutation of width 3
+func NewPoseidon2() hash.Hash {
+	params, err := NewDefaultParameters(3)
+	if err != nil {
+		panic(err)
+	}
+	return NewSponge(params)
+}
+
+// Reset resets the Hash to its initial state.
+func (d *digest) Reset() {
+	d.data = nil
+}
+
+// Sum appends the current hash to b and returns the resulting slice.
+// It does not change the underlying hash state.
+func (d *digest) Sum(b []byte) []byte {
+	h := d.checksum()
+	hash := h.Bytes()
+	return append(b, hash[:]...)
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *digest) Size() int {
+	return fr.Bytes
+}
+
+// BlockSize returns the hash's underlying block size.
+// The Write method must be able to accept any amount
+// of data, but it may operate more efficiently if all writes
+// are a multiple of the block size.
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+// Write (via the embedded io.Writer interface) adds more data to the running hash.
+// It never returns an error.
+func (d *digest) Write(p []byte) (n int, err error) {
+	n = len(p)
+	d.data = append(d.data, p...)
+	return
+}
+
+// checksum absorbs the data in the sponge and returns the first element of the state
+func (d *digest) checksum() fr.Element {
+
+	// split the data in chunks of BlockSize bytes
+	nbElements := (len(d.data) + BlockSize - 1) / BlockSize
+	elements := make([]fr.Element, nbElements)
+	for i := range elements {
+		end := (i + 1) * BlockSize
+		if end > len(d.data) {
+			end = len(d.data)
+		}
+		elements[i].SetBytes(d.data[i*BlockSize : end])
+	}
+
+	state := make([]fr.Element, d.params.Width)
+	rate := d.params.Width - 1
+	for i := 0; i == 0 || i < nbElements; i += rate {
+		for j := 0; j < rate && i+j < nbElements; j++ {
+			state[j+1].Add(&state[j+1], &elements[i+j])
+		}
+		// the state has the width of the parameters
+		_ = d.params.Permutation(state)
+	}
+	return state[0]
+}
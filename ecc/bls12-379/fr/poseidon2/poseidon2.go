@@ -0,0 +1,170 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package poseidon2
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
+)
+
+var (
+	ErrInvalidWidth     = errors.New("invalid width, the internal matrix is defined for the widths 2 and 3")
+	ErrInvalidNbRounds  = errors.New("invalid number of rounds")
+	ErrInvalidSizeState = errors.New("the size of the state should be the width of the permutation")
+)
+
+// Parameters describe the Poseidon2 permutation of a given width
+//
+// The external matrix is circ(2, 1) or circ(2, 1, 1), and the internal matrix is [[2, 1], [1, 3]] or
+// [[2, 1, 1], [1, 2, 1], [1, 1, 3]], as in the reference implementation for large prime fields.
+type Parameters struct {
+	Width           int // number of field elements of the state, t (2 or 3)
+	NbFullRounds    int // number of full (external) rounds, R_F (even)
+	NbPartialRounds int // number of partial (internal) rounds, R_P
+
+	// RoundKeys[i] is added to the state in the i-th round: Width elements for the full rounds, and
+	// one element, added to the first element of the state, for the partial rounds
+	RoundKeys [][]fr.Element
+}
+
+// NewParameters returns the parameters of the Poseidon2 permutation of width t, with nbFullRounds
+// full rounds and nbPartialRounds partial rounds, generated with the Grain LFSR
+func NewParameters(width, nbFullRounds, nbPartialRounds int) (*Parameters, error) {
+	if width != 2 && width != 3 {
+		return nil, ErrInvalidWidth
+	}
+	if nbFullRounds < 2 || nbFullRounds%2 != 0 || nbPartialRounds < 0 {
+		return nil, ErrInvalidNbRounds
+	}
+	res := &Parameters{
+		Width:           width,
+		NbFullRounds:    nbFullRounds,
+		NbPartialRounds: nbPartialRounds,
+	}
+
+	g := newGrain(width, nbFullRounds, nbPartialRounds)
+	rf := nbFullRounds / 2
+	res.RoundKeys = make([][]fr.Element, nbFullRounds+nbPartialRounds)
+	for i := range res.RoundKeys {
+		size := width
+		if i >= rf && i < rf+nbPartialRounds {
+			size = 1
+		}
+		res.RoundKeys[i] = make([]fr.Element, size)
+		for j := range res.RoundKeys[i] {
+			res.RoundKeys[i][j] = g.nextElement()
+		}
+	}
+	return res, nil
+}
+
+// default number of rounds for 128 bits of security with the s-box x^7, for the widths 2 and 3,
+// as computed by calc_round_numbers.py in the reference implementation (with the security margin)
+var (
+	defaultNbFullRounds    = [2]int{8, 8}
+	defaultNbPartialRounds = [2]int{46, 46}
+)
+
+var (
+	defaultParameters     [4]*Parameters
+	defaultParametersLock sync.Mutex
+)
+
+// NewDefaultParameters returns the parameters of the Poseidon2 permutation of width t for 128 bits of security
+// the parameters are computed once, and shared by the callers
+func NewDefaultParameters(width int) (*Parameters, error) {
+	if width != 2 && width != 3 {
+		return nil, ErrInvalidWidth
+	}
+	defaultParametersLock.Lock()
+	defer defaultParametersLock.Unlock()
+	if defaultParameters[width] == nil {
+		params, err := NewParameters(width, defaultNbFullRounds[width-2], defaultNbPartialRounds[width-2])
+		if err != nil {
+			return nil, err
+		}
+		defaultParameters[width] = params
+	}
+	return defaultParameters[width], nil
+}
+
+// Permutation applies the Poseidon2 permutation to state, whose size must be the width
+func (params *Parameters) Permutation(state []fr.Element) error {
+	if len(state) != params.Width {
+		return ErrInvalidSizeState
+	}
+	rf := params.NbFullRounds / 2
+
+	matMulExternal(state)
+	for r := 0; r < rf; r++ {
+		params.fullRound(state, r)
+	}
+	for r := rf; r < rf+params.NbPartialRounds; r++ {
+		state[0].Add(&state[0], &params.RoundKeys[r][0])
+		sBox(&state[0])
+		matMulInternal(state)
+	}
+	for r := rf + params.NbPartialRounds; r < params.NbFullRounds+params.NbPartialRounds; r++ {
+		params.fullRound(state, r)
+	}
+	return nil
+}
+
+// fullRound adds the round keys of round r to state, applies the s-box to each element and the external matrix
+func (params *Parameters) fullRound(state []fr.Element, r int) {
+	for i := range state {
+		state[i].Add(&state[i], &params.RoundKeys[r][i])
+		sBox(&state[i])
+	}
+	matMulExternal(state)
+}
+
+// matMulExternal sets state to circ(2, 1, ..., 1)·state, that is, adds the sum of the elements to each element
+func matMulExternal(state []fr.Element) {
+	var sum fr.Element
+	for i := range state {
+		sum.Add(&sum, &state[i])
+	}
+	for i := range state {
+		state[i].Add(&state[i], &sum)
+	}
+}
+
+// matMulInternal sets state to (J + diag(1, ..., 1, 2))·state, where J is the all-ones matrix
+func matMulInternal(state []fr.Element) {
+	var sum fr.Element
+	for i := range state {
+		sum.Add(&sum, &state[i])
+	}
+	last := len(state) - 1
+	for i := 0; i < last; i++ {
+		state[i].Add(&state[i], &sum)
+	}
+	state[last].Double(&state[last]).Add(&state[last], &sum)
+}
+
+// sBox sets x to x^7, the s-box of the permutation
+func sBox(x *fr.Element) {
+	var tmp fr.Element
+	var x3 fr.Element
+	tmp.Square(x)
+	x3.Mul(&tmp, x)
+	tmp.Square(&x3)
+	x.Mul(x, &tmp)
+}
@@ -59,14 +59,29 @@ func TestMapToCurveG1(t *testing.T) {
 	parameters.MinSuccessfulTests = 10
 
 	properties := gopter.NewProperties(parameters)
-	genFuzz1 := GenFp()
+
+	properties.Property("[G1] Svsw mapping should output point on the curve before clearing the cofactor", prop.ForAll(
+		func(a fp.Element) bool {
+			g := svdwMapG1(a)
+			return g.IsOnCurve()
+		},
+		GenFp(),
+	))
 
 	properties.Property("[G1] Svsw mapping should output point on the curve", prop.ForAll(
 		func(a fp.Element) bool {
 			g := MapToCurveG1Svdw(a)
 			return g.IsOnCurve()
 		},
-		genFuzz1,
+		GenFp(),
+	))
+
+	properties.Property("[G1] Svsw mapping should output point in the subgroup", prop.ForAll(
+		func(a fp.Element) bool {
+			g := MapToCurveG1Svdw(a)
+			return g.IsInSubGroup()
+		},
+		GenFp(),
 	))
 
 	properties.Property("[G1] Svsw mapping should be deterministic", prop.ForAll(
@@ -75,8 +90,10 @@ func TestMapToCurveG1(t *testing.T) {
 			g2 := MapToCurveG1Svdw(a)
 			return g1.Equal(&g2)
 		},
-		genFuzz1,
+		GenFp(),
 	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
 func TestG1AffineIsOnCurve(t *testing.T) {
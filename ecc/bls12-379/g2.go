@@ -19,12 +19,11 @@ package bls12379
 import (
 	"math"
 	"math/big"
-	"runtime"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-379/internal/fptower"
-	"github.com/consensys/gnark-crypto/internal/parallel"
+	"github.com/consensys/gnark-crypto/pool"
 )
 
 // G2Affine point in affine coordinates
@@ -859,7 +858,8 @@ func (p *g2Proj) FromAffine(Q *G2Affine) *g2Proj {
 // BatchScalarMultiplicationG2 multiplies the same base (generator) by all scalars
 // and return resulting points in affine coordinates
 // uses a simple windowed-NAF like exponentiation algorithm
-func BatchScalarMultiplicationG2(base *G2Affine, scalars []fr.Element) []G2Affine {
+// the work is run on pl[0] if set, or on the default pool
+func BatchScalarMultiplicationG2(base *G2Affine, scalars []fr.Element, pl ...*pool.Pool) []G2Affine {
 
 	// approximate cost in group ops is
 	// cost = 2^{c-1} + n(scalar.nbBits+nbChunks)
@@ -898,7 +898,11 @@ func BatchScalarMultiplicationG2(base *G2Affine, scalars []fr.Element) []G2Affin
 		baseTable[i].AddMixed(base)
 	}
 
-	pScalars := partitionScalars(scalars, c, false, runtime.NumCPU())
+	var _pool *pool.Pool
+	if len(pl) > 0 {
+		_pool = pl[0]
+	}
+	pScalars := partitionScalars(scalars, c, false, _pool.NbWorkers(), _pool)
 
 	// compute offset and word selector / shift to select the right bits of our windows
 	selectors := make([]selector, nbChunks)
@@ -919,7 +923,7 @@ func BatchScalarMultiplicationG2(base *G2Affine, scalars []fr.Element) []G2Affin
 	toReturn := make([]G2Affine, len(scalars))
 
 	// for each digit, take value in the base table, double it c time, voila.
-	parallel.Execute(len(pScalars), func(start, end int) {
+	_pool.Execute(len(pScalars), func(start, end int) {
 		var p G2Jac
 		for i := start; i < end; i++ {
 			p.Set(&g2Infinity)
@@ -66,9 +66,9 @@ func svdwMapG1(u fp.Element) G1Affine {
 	var z, c1, c2, c3, c4 fp.Element
 	z.SetOne()
 	c1.SetString("2")
-	c2.SetString("129332213006484547005326366847446766768196756377457330269942131333360234174170411387484444069786680062220160729088")
-	c3.SetString("97648839010665214827241242728596775338087731732850880761532715038339062821120154619091300503722809961039397351015")
-	c4.SetString("172442950675312729340435155796595689024262341836609773693256175111146978898893881849979258759715573416293547638782")
+	c2.SetString("323727912360057895999700974188931982474237749434284093899934506056261145715233258271091151776351970259588389797888")
+	c3.SetString("62851116629312981218964845696264972941530984959752814370897787687817381219255654018290821479607377485009195206774")
+	c4.SetString("431637216480077194666267965585242643298983665912378791866579341408348194286977677694788202368469293679451186397182")
 
 	var tv1, tv2, tv3, tv4, one, x1, gx1, x2, gx2, x3, x, gx, y fp.Element
 	one.SetOne()
@@ -123,6 +123,7 @@ func svdwMapG1(u fp.Element) G1Affine {
 // https://tools.ietf.org/html/draft-irtf-cfrg-hash-to-curve-06#section-2.2.1
 func MapToCurveG1Svdw(t fp.Element) G1Affine {
 	res := svdwMapG1(t)
+	res.ClearCofactor(&res)
 	return res
 }
 
@@ -168,16 +169,16 @@ func svdwMapG2(u fptower.E2) G2Affine {
 	// constants
 	// sage script to find z: https://tools.ietf.org/html/draft-irtf-cfrg-hash-to-curve-06#appendix-E.1
 	var z, c1, c2, c3, c4 fptower.E2
-	z.A0.SetString("2")
+	z.A0.SetString("0")
 	z.A1.SetString("1")
-	c1.A0.SetString("38")
-	c1.A1.SetString("103465770405187637604261093477957413414557405101965864215953705066688187339336329109987555255829344049776128583288")
-	c2.A0.SetString("258664426012969094010652733694893533536393512754914660539884262666720468348340822774968888139573360124440321458176")
-	c2.A1.SetString("129332213006484547005326366847446766768196756377457330269942131333360234174170411387484444069786680062220160729088")
-	c3.A0.SetString("141354814213091513024678201993701334402983894315682828752154607295963278662565530559432544731234911957814229457027")
-	c3.A1.SetString("105105533309732059517333177844331590042891296673681156116453121436104838422613314245519046656808355505493854902221")
-	c4.A0.SetString("86221475337656364670217577898297844512131170918304886846628087555573489449446940924989629379857786708146773819395")
-	c4.A1.SetString("137954360540250183472348124637276551219409873469287818954604940088917583119115105479983407007772458733034838111024")
+	c1.A0.SetString("539546520600096493332834956981553304123729582390473489833224176760435242858722097118485252960586617099313982996481")
+	c1.A1.SetString("280564190712050176533074177630407718144339382843046214713276571915426326286535490501612331539505040891643271158165")
+	c2.A0.SetString("0")
+	c2.A1.SetString("323727912360057895999700974188931982474237749434284093899934506056261145715233258271091151776351970259588389797888")
+	c3.A0.SetString("140508449369296409015033919021793695568402917427940852267774094337640260350469686726541482569977114639616234561688")
+	c3.A1.SetString("564712803730809692858882829390112924935162956282215835235122030696498547437238818591756290837960712886529236871630")
+	c4.A0.SetString("273370237104048889955303044870653674089356321744506568182166916225287189715085862540032528166697219330319084718217")
+	c4.A1.SetString("290635725763251977741953763494063379821315668381001719856830089881621117486564969647824056261435991077497132174103")
 
 	var tv1, tv2, tv3, tv4, one, x1, gx1, x2, gx2, x3, x, gx, y fptower.E2
 	one.SetOne()
@@ -0,0 +1,171 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12379
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestMultiExpStreamG1(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 2
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// size of the multiExps
+	const nbSamples = 50
+
+	// multi exp points
+	var samplePoints [nbSamples]G1Affine
+	var g G1Jac
+	g.Set(&g1Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g1Gen)
+	}
+
+	// compressed and raw encodings of the points
+	var compressed, raw bytes.Buffer
+	if err := NewEncoder(&compressed).Encode(samplePoints[:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewEncoder(&raw, RawEncoding()).Encode(samplePoints[:]); err != nil {
+		t.Fatal(err)
+	}
+
+	properties.Property("[G1] Multi exponentation of a stream of points should be consistant with MultiExp", prop.ForAll(
+		func(mixer fr.Element) bool {
+
+			var sampleScalars [nbSamples]fr.Element
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+			}
+
+			var expected G1Affine
+			expected.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{ScalarsMont: true})
+
+			for _, encoded := range [][]byte{compressed.Bytes(), raw.Bytes()} {
+				for _, chunkSize := range []int{0, 1, 7, nbSamples} {
+					dec := NewDecoder(bytes.NewReader(encoded))
+					var result G1Affine
+					if _, err := result.MultiExpStream(dec, sampleScalars[:], chunkSize, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+						return false
+					}
+					if !result.Equal(&expected) || dec.BytesRead() != int64(len(encoded)) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// wrong number of scalars, and truncated stream
+	var result G1Jac
+	scalars := make([]fr.Element, nbSamples)
+	if _, err := result.MultiExpStream(NewDecoder(bytes.NewReader(raw.Bytes())), scalars[1:], 0, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("wrong number of scalars should be rejected")
+	}
+	if _, err := result.MultiExpStream(NewDecoder(bytes.NewReader(raw.Bytes()[:raw.Len()-1])), scalars, 0, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("truncated stream should be rejected")
+	}
+}
+
+func TestMultiExpStreamG2(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 2
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// size of the multiExps
+	const nbSamples = 50
+
+	// multi exp points
+	var samplePoints [nbSamples]G2Affine
+	var g G2Jac
+	g.Set(&g2Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g2Gen)
+	}
+
+	// compressed and raw encodings of the points
+	var compressed, raw bytes.Buffer
+	if err := NewEncoder(&compressed).Encode(samplePoints[:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewEncoder(&raw, RawEncoding()).Encode(samplePoints[:]); err != nil {
+		t.Fatal(err)
+	}
+
+	properties.Property("[G2] Multi exponentation of a stream of points should be consistant with MultiExp", prop.ForAll(
+		func(mixer fr.Element) bool {
+
+			var sampleScalars [nbSamples]fr.Element
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+			}
+
+			var expected G2Affine
+			expected.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{ScalarsMont: true})
+
+			for _, encoded := range [][]byte{compressed.Bytes(), raw.Bytes()} {
+				for _, chunkSize := range []int{0, 1, 7, nbSamples} {
+					dec := NewDecoder(bytes.NewReader(encoded))
+					var result G2Affine
+					if _, err := result.MultiExpStream(dec, sampleScalars[:], chunkSize, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+						return false
+					}
+					if !result.Equal(&expected) || dec.BytesRead() != int64(len(encoded)) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// wrong number of scalars, and truncated stream
+	var result G2Jac
+	scalars := make([]fr.Element, nbSamples)
+	if _, err := result.MultiExpStream(NewDecoder(bytes.NewReader(raw.Bytes())), scalars[1:], 0, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("wrong number of scalars should be rejected")
+	}
+	if _, err := result.MultiExpStream(NewDecoder(bytes.NewReader(raw.Bytes()[:raw.Len()-1])), scalars, 0, ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("truncated stream should be rejected")
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestHashToCurve(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 20
+
+	properties := gopter.NewProperties(parameters)
+
+	genMsg := gopter.Gen(func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var b fr.Element
+		b.SetRandom()
+		buf := b.Bytes()
+		return gopter.NewGenResult(buf[:genParams.Rng.Intn(fr.Bytes)], gopter.NoShrinker)
+	})
+	dst := []byte("QUUX-V01-CS02-with-bls12-379_XMD:SHA-256_ELL2_RO_")
+
+	properties.Property("MapToCurve should output a point on the curve", prop.ForAll(
+		func(msg []byte) bool {
+			var u fr.Element
+			u.SetBytes(msg)
+			p := MapToCurve(u)
+			return p.IsOnCurve()
+		},
+		genMsg,
+	))
+
+	properties.Property("HashToCurve and EncodeToCurve should output a point of the prime order subgroup", prop.ForAll(
+		func(msg []byte) bool {
+			curveParams := GetEdwardsCurve()
+			for _, hash := range []func([]byte, []byte) (PointAffine, error){HashToCurve, EncodeToCurve} {
+				p, err := hash(msg, dst)
+				if err != nil || !p.IsOnCurve() {
+					return false
+				}
+				var q PointExtended
+				q.FromAffine(&p)
+				if !q.ScalarMul(&q, &curveParams.Order).IsZero() {
+					return false
+				}
+			}
+			return true
+		},
+		genMsg,
+	))
+
+	properties.Property("HashToCurve should be deterministic, and depend on the message and the domain separation tag", prop.ForAll(
+		func(msg []byte) bool {
+			p1, err1 := HashToCurve(msg, dst)
+			p2, err2 := HashToCurve(msg, dst)
+			p3, err3 := HashToCurve(append(msg, 1), dst)
+			p4, err4 := HashToCurve(msg, []byte("other dst"))
+			if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+				return false
+			}
+			return p1.Equal(&p2) && !p1.Equal(&p3) && !p1.Equal(&p4)
+		},
+		genMsg,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkHashToCurve(b *testing.B) {
+	msg := []byte("benchmark")
+	dst := []byte("QUUX-V01-CS02-with-bls12-379_XMD:SHA-256_ELL2_RO_")
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		HashToCurve(msg, dst)
+	}
+}
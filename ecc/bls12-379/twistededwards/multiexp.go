@@ -0,0 +1,311 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
+	"github.com/consensys/gnark-crypto/pool"
+)
+
+// MultiExp implements section 4 of https://eprint.iacr.org/2012/549.pdf
+// scalars are NOT reduced mod Order, and are in Montgomery form if config.ScalarsMont is set
+func (p *PointAffine) MultiExp(points []PointAffine, scalars []fr.Element, config ecc.MultiExpConfig) (*PointAffine, error) {
+	var _p PointExtended
+	if _, err := _p.MultiExp(points, scalars, config); err != nil {
+		return nil, err
+	}
+	p.FromExtended(&_p)
+	return p, nil
+}
+
+// MultiExp implements section 4 of https://eprint.iacr.org/2012/549.pdf
+// scalars are NOT reduced mod Order, and are in Montgomery form if config.ScalarsMont is set
+func (p *PointProj) MultiExp(points []PointAffine, scalars []fr.Element, config ecc.MultiExpConfig) (*PointProj, error) {
+	var _p PointExtended
+	if _, err := _p.MultiExp(points, scalars, config); err != nil {
+		return nil, err
+	}
+	p.X.Set(&_p.X)
+	p.Y.Set(&_p.Y)
+	p.Z.Set(&_p.Z)
+	return p, nil
+}
+
+// MultiExp implements section 4 of https://eprint.iacr.org/2012/549.pdf
+// scalars are NOT reduced mod Order, and are in Montgomery form if config.ScalarsMont is set
+func (p *PointExtended) MultiExp(points []PointAffine, scalars []fr.Element, config ecc.MultiExpConfig) (*PointExtended, error) {
+	// step 1
+	// we compute, for each scalars over c-bit wide windows, nbChunk digits
+	// if the digit is larger than 2^{c-1}, then, we borrow 2^c from the next window and substract
+	// 2^{c} to the current digit, making it negative.
+	// negative digits will be processed in the next step as adding -G into the bucket instead of G
+	// (computing -G is free on a twisted Edwards curve, and this saves us half of the buckets)
+	// step 2
+	// for each split of the points and each chunk, the points are added in extended coordinates
+	// to 2^{c-1} buckets, which are reduced into the weighted bucket sum of the chunk
+	// step 3
+	// reduce the weighted bucket sums of the chunks into our result
+
+	// ensure len(points) == len(scalars)
+	nbPoints := len(points)
+	if nbPoints != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+
+	// if nbTasks is not set, use all the workers of the pool
+	if config.NbTasks <= 0 {
+		config.NbTasks = config.Pool.NbWorkers()
+	}
+
+	// here, we compute the best C for nbPoints
+	// we split recursively until nbChunks(c) >= nbTasks,
+	bestC := func(nbPoints int) uint64 {
+		var C uint64
+		// approximate cost (in group operations)
+		// cost = bits/c * (nbPoints + 2^{c})
+		min := math.MaxFloat64
+		for c := uint64(msmMinC); c <= msmMaxC; c++ {
+			cc := fr.Bits * (nbPoints + (1 << (c)))
+			cost := float64(cc) / float64(c)
+			if cost < min {
+				min = cost
+				C = c
+			}
+		}
+		return C
+	}
+
+	var C uint64
+	nbSplits := 1
+	nbChunks := 0
+	for nbChunks < config.NbTasks {
+		C = bestC(nbPoints)
+		nbChunks = msmNbChunks(C) * nbSplits
+		if nbChunks < config.NbTasks {
+			nbSplits <<= 1
+			nbPoints >>= 1
+		}
+	}
+	nbChunks /= nbSplits
+
+	// nbSplits * nbChunks tasks are processed
+	ctx := newMSMContext(config, nbSplits*nbChunks)
+	if config.Context != nil {
+		if err := config.Context.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// partition the scalars
+	// note: we do that before the actual chunk processing, as for each c-bit window (starting from LSW)
+	// if it's larger than 2^{c-1}, we have a carry we need to propagate up to the higher window
+	digits := partitionScalars(scalars, C, nbChunks, config.ScalarsMont, config.NbTasks, config.Pool)
+
+	// weighted bucket sums of each chunk of each split
+	// the last split also processes the remaining points
+	sums := make([]PointExtended, nbSplits*nbChunks)
+	ctx.execute(nbSplits*nbChunks, func(start, end int) {
+		buckets := make([]PointExtended, 1<<(C-1))
+		for task := start; task < end; task++ {
+			split, chunk := task/nbChunks, task%nbChunks
+			first := split * nbPoints
+			last := first + nbPoints
+			if split == nbSplits-1 {
+				last = len(points)
+			}
+			msmProcessChunk(&sums[task], buckets, points[first:last], digits[first*nbChunks:last*nbChunks], chunk, nbChunks, ctx)
+		}
+	}, nbSplits*nbChunks)
+
+	// reduce the chunks, starting from the most significant one
+	p.setInfinity()
+	for chunk := nbChunks - 1; chunk >= 0; chunk-- {
+		for l := uint64(0); l < C; l++ {
+			p.Double(p)
+		}
+		for split := 0; split < nbSplits; split++ {
+			p.Add(p, &sums[split*nbChunks+chunk])
+		}
+	}
+	if config.Context != nil {
+		if err := config.Context.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// bounds of the window size of the multi exponentiation
+// the digits of the scalars fit in an int16
+const (
+	msmMinC = 2
+	msmMaxC = 16
+)
+
+// msmNbChunks returns the number of c-bit digits of the scalars
+// the scalars have fr.Bits bits, and the most significant digit absorbs the carry of the signed digits
+func msmNbChunks(c uint64) int {
+	return int((fr.Bits + c) / c)
+}
+
+// msmProcessChunk adds the points to the buckets according to their digit for chunk, and sets p to
+// the weighted sum of the buckets. digits[i*nbChunks+chunk] is the digit of the i-th point.
+func msmProcessChunk(p *PointExtended, buckets []PointExtended, points []PointAffine, digits []int16, chunk, nbChunks int, ctx *msmContext) {
+
+	for i := 0; i < len(buckets); i++ {
+		buckets[i].setInfinity()
+	}
+
+	var neg PointAffine
+	for i := 0; i < len(points); i++ {
+		if i%msmCancellationPeriod == 0 && ctx.cancelled() {
+			break
+		}
+		digit := digits[i*nbChunks+chunk]
+		if digit > 0 {
+			buckets[digit-1].MixedAdd(&buckets[digit-1], &points[i])
+		} else if digit < 0 {
+			neg.Neg(&points[i])
+			buckets[-digit-1].MixedAdd(&buckets[-digit-1], &neg)
+		}
+	}
+
+	// reduce buckets into total
+	// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+	var runningSum, total PointExtended
+	runningSum.setInfinity()
+	total.setInfinity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		if !buckets[k].IsZero() {
+			runningSum.Add(&runningSum, &buckets[k])
+		}
+		total.Add(&total, &runningSum)
+	}
+
+	ctx.taskDone()
+	p.Set(&total)
+}
+
+// partitionScalars computes, for each scalar, nbChunks signed digits over c-bit wide windows
+// if the digit is larger than 2^{c-1}, then, we borrow 2^c from the next window and substract
+// 2^{c} to the current digit, making it negative.
+// the digits of scalars[i] are res[i*nbChunks:(i+1)*nbChunks], least significant first
+// the work is split in nbTasks tasks run on pl (or the default pool if pl is nil)
+func partitionScalars(scalars []fr.Element, c uint64, nbChunks int, scalarsMont bool, nbTasks int, pl *pool.Pool) []int16 {
+	res := make([]int16, len(scalars)*nbChunks)
+	mask := uint64((1 << c) - 1)
+	max := 1 << (c - 1)
+
+	pl.Execute(len(scalars), func(start, end int) {
+		for i := start; i < end; i++ {
+			scalar := scalars[i]
+			if scalarsMont {
+				scalar.FromMont()
+			}
+
+			carry := 0
+			for chunk := 0; chunk < nbChunks; chunk++ {
+				// digit = value of the c-bit window, plus the carry
+				jc := uint64(chunk) * c
+				index, shift := jc/64, jc%64
+				var bits uint64
+				if index < fr.Limbs {
+					bits = scalar[index] >> shift
+				}
+				if shift+c > 64 && index < fr.Limbs-1 {
+					bits |= scalar[index+1] << (64 - shift)
+				}
+				digit := int(bits&mask) + carry
+				carry = 0
+
+				if digit >= max {
+					digit -= 1 << c
+					carry = 1
+				}
+				res[i*nbChunks+chunk] = int16(digit)
+			}
+		}
+	}, nbTasks)
+	return res
+}
+
+// msmContext carries the cancellation, the progress reporting and the pool of a multi exponentiation
+type msmContext struct {
+	done     <-chan struct{} // closed when the multi exponentiation is cancelled
+	progress func()          // called when a task is processed
+	pool     *pool.Pool      // workers running the tasks, or nil for the default pool
+}
+
+// newMSMContext returns the msmContext of config for total tasks, or nil if config
+// has no context, no progress callback and no pool
+func newMSMContext(config ecc.MultiExpConfig, total int) *msmContext {
+	if config.Context == nil && config.Progress == nil && config.Pool == nil {
+		return nil
+	}
+	ctx := &msmContext{pool: config.Pool}
+	if config.Context != nil {
+		ctx.done = config.Context.Done()
+	}
+	if config.Progress != nil {
+		var lock sync.Mutex
+		done := 0
+		ctx.progress = func() {
+			lock.Lock()
+			done++
+			config.Progress(done, total)
+			lock.Unlock()
+		}
+	}
+	return ctx
+}
+
+// cancelled returns true if the multi exponentiation is cancelled
+func (ctx *msmContext) cancelled() bool {
+	if ctx == nil || ctx.done == nil {
+		return false
+	}
+	select {
+	case <-ctx.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// taskDone reports that a task is processed
+func (ctx *msmContext) taskDone() {
+	if ctx != nil && ctx.progress != nil {
+		ctx.progress()
+	}
+}
+
+// execute runs work on the pool of the multi exponentiation, split in nbTasks tasks
+func (ctx *msmContext) execute(nbIterations int, work func(int, int), nbTasks int) {
+	var pl *pool.Pool
+	if ctx != nil {
+		pl = ctx.pool
+	}
+	pl.Execute(nbIterations, work, nbTasks)
+}
+
+// number of scalars processed between two checks of the cancellation
+const msmCancellationPeriod = 1 << 10
@@ -20,7 +20,7 @@ import (
 	"crypto/subtle"
 	"io"
 	"math/big"
-	"math/bits"
+	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-379/fr"
 )
@@ -35,6 +35,12 @@ type PointProj struct {
 	X, Y, Z fr.Element
 }
 
+// PointExtended point in extended coordinates (x=X/Z, y=Y/Z, T=XY/Z)
+// cf https://eprint.iacr.org/2008/522.pdf
+type PointExtended struct {
+	X, Y, Z, T fr.Element
+}
+
 const (
 	//following https://tools.ietf.org/html/rfc8032#section-3.1,
 	// an fr element x is negative if its binary encoding is
@@ -173,9 +179,9 @@ func (p *PointAffine) IsOnCurve() bool {
 	var lhs, rhs, tmp fr.Element
 
 	tmp.Mul(&p.Y, &p.Y)
-	lhs.Mul(&p.X, &p.X).
-		Neg(&lhs).
-		Add(&lhs, &tmp)
+	lhs.Mul(&p.X, &p.X)
+	mulByA(&lhs)
+	lhs.Add(&lhs, &tmp)
 
 	tmp.Mul(&p.X, &p.X).
 		Mul(&tmp, &p.Y).
@@ -199,8 +205,9 @@ func (p *PointAffine) Add(p1, p2 *PointAffine) *PointAffine {
 	pRes.X.Add(&xv, &yu)
 
 	xu.Mul(&p1.X, &p2.X)
+	mulByA(&xu)
 	yv.Mul(&p1.Y, &p2.Y)
-	pRes.Y.Add(&yv, &xu)
+	pRes.Y.Sub(&yv, &xu)
 
 	dxyuv.Mul(&xv, &yu).Mul(&dxyuv, &ecurve.D)
 	one.SetOne()
@@ -220,16 +227,17 @@ func (p *PointAffine) Double(p1 *PointAffine) *PointAffine {
 	p.Set(p1)
 	var xx, yy, xy, denum, two fr.Element
 	xx.Square(&p.X)
+	mulByA(&xx)
 	yy.Square(&p.Y)
 	xy.Mul(&p.X, &p.Y)
-	denum.Sub(&yy, &xx)
+	denum.Add(&xx, &yy)
 
 	p.X.Double(&xy).Div(&p.X, &denum)
 
 	two.SetOne().Double(&two)
 	denum.Neg(&denum).Add(&denum, &two)
 
-	p.Y.Add(&xx, &yy).Div(&p.Y, &denum)
+	p.Y.Sub(&yy, &xx).Div(&p.Y, &denum)
 
 	return p
 }
@@ -258,7 +266,7 @@ func (p *PointProj) FromAffine(p1 *PointAffine) *PointProj {
 }
 
 // Add adds points in projective coordinates
-// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-projective.html
+// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-projective.html#addition-add-2008-bbjlp
 func (p *PointProj) Add(p1, p2 *PointProj) *PointProj {
 
 	var res PointProj
@@ -278,10 +286,11 @@ func (p *PointProj) Add(p1, p2 *PointProj) *PointProj {
 	res.X.Mul(&H, &I).
 		Sub(&res.X, &C).
 		Sub(&res.X, &D).
-		Mul(&res.X, &p1.Z).
+		Mul(&res.X, &A).
 		Mul(&res.X, &F)
-	res.Y.Add(&D, &C).
-		Mul(&res.Y, &p.Z).
+	mulByA(&C)
+	res.Y.Sub(&D, &C).
+		Mul(&res.Y, &A).
 		Mul(&res.Y, &G)
 	res.Z.Mul(&F, &G)
 
@@ -290,7 +299,7 @@ func (p *PointProj) Add(p1, p2 *PointProj) *PointProj {
 }
 
 // Double adds points in projective coordinates
-// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-projective.html
+// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-projective.html#doubling-dbl-2008-bbjlp
 func (p *PointProj) Double(p1 *PointProj) *PointProj {
 
 	var res PointProj
@@ -300,7 +309,8 @@ func (p *PointProj) Double(p1 *PointProj) *PointProj {
 	B.Add(&p1.X, &p1.Y).Square(&B)
 	C.Square(&p1.X)
 	D.Square(&p1.Y)
-	E.Neg(&C)
+	E.Set(&C)
+	mulByA(&E)
 	F.Add(&E, &D)
 	H.Square(&p1.Z)
 	tmp.Double(&H)
@@ -324,39 +334,314 @@ func (p *PointAffine) Neg(p1 *PointAffine) *PointAffine {
 
 // ScalarMul scalar multiplication of a point
 // p1 points on the twisted Edwards curve
-// c parameters of the twisted Edwards curve
-// scal scalar NOT in Montgomery form
+// scalar NOT in Montgomery form, can be negative
 // modifies p
-//func (p *PointAffine) ScalarMul(p1 *PointAffine, scalar fr.Element) *PointAffine {
 func (p *PointAffine) ScalarMul(p1 *PointAffine, scalar *big.Int) *PointAffine {
+	var _p PointExtended
+	_p.FromAffine(p1)
+	_p.ScalarMul(&_p, scalar)
+	p.FromExtended(&_p)
+	return p
+}
+
+// ScalarMulBase sets p to [scalar]Base, where Base is the generator of the curve parameters,
+// using a precomputed table of multiples of Base
+func (p *PointAffine) ScalarMulBase(scalar *big.Int) *PointAffine {
+	var _p PointExtended
+	_p.ScalarMulBase(scalar)
+	p.FromExtended(&_p)
+	return p
+}
+
+// IsZero returns true if p is the neutral element (0, 1)
+func (p *PointAffine) IsZero() bool {
+	var one fr.Element
+	one.SetOne()
+	return p.X.IsZero() && p.Y.Equal(&one)
+}
+
+// FromExtended sets p in affine from p in extended coordinates
+func (p *PointAffine) FromExtended(p1 *PointExtended) *PointAffine {
+	var zInv fr.Element
+	zInv.Inverse(&p1.Z)
+	p.X.Mul(&p1.X, &zInv)
+	p.Y.Mul(&p1.Y, &zInv)
+	return p
+}
+
+// Set sets p to p1 and return it
+func (p *PointExtended) Set(p1 *PointExtended) *PointExtended {
+	p.X.Set(&p1.X)
+	p.Y.Set(&p1.Y)
+	p.Z.Set(&p1.Z)
+	p.T.Set(&p1.T)
+	return p
+}
 
-	var _scalar big.Int
-	_scalar.Set(scalar)
+// Equal returns true if p=p1 false otherwise
+func (p *PointExtended) Equal(p1 *PointExtended) bool {
+	var lhs, rhs fr.Element
+	lhs.Mul(&p.X, &p1.Z)
+	rhs.Mul(&p1.X, &p.Z)
+	if !lhs.Equal(&rhs) {
+		return false
+	}
+	lhs.Mul(&p.Y, &p1.Z)
+	rhs.Mul(&p1.Y, &p.Z)
+	return lhs.Equal(&rhs)
+}
+
+// IsZero returns true if p is the neutral element (0:1:1:0)
+func (p *PointExtended) IsZero() bool {
+	return p.X.IsZero() && p.Y.Equal(&p.Z)
+}
+
+// setInfinity sets p to the neutral element (0:1:1:0)
+func (p *PointExtended) setInfinity() *PointExtended {
+	p.X.SetZero()
+	p.Y.SetOne()
+	p.Z.SetOne()
+	p.T.SetZero()
+	return p
+}
+
+// Neg sets p to -p1 and returns it
+func (p *PointExtended) Neg(p1 *PointExtended) *PointExtended {
 	p.Set(p1)
-	if _scalar.Sign() == -1 {
-		_scalar.Neg(&_scalar)
-		p.Neg(p)
+	p.X.Neg(&p.X)
+	p.T.Neg(&p.T)
+	return p
+}
+
+// FromAffine sets p in extended from p in affine
+func (p *PointExtended) FromAffine(p1 *PointAffine) *PointExtended {
+	p.X.Set(&p1.X)
+	p.Y.Set(&p1.Y)
+	p.Z.SetOne()
+	p.T.Mul(&p1.X, &p1.Y)
+	return p
+}
+
+// Add adds points in extended coordinates
+// the formulas are complete, and assume a=-1
+// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-extended-1.html#addition-add-2008-hwcd-3
+func (p *PointExtended) Add(p1, p2 *PointExtended) *PointExtended {
+	var A, B, C, D, E, F, G, H, tmp fr.Element
+	A.Sub(&p1.Y, &p1.X)
+	tmp.Sub(&p2.Y, &p2.X)
+	A.Mul(&A, &tmp)
+	B.Add(&p1.Y, &p1.X)
+	tmp.Add(&p2.Y, &p2.X)
+	B.Mul(&B, &tmp)
+	C.Mul(&p1.T, &p2.T).
+		Mul(&C, &edwards.D).
+		Double(&C)
+	D.Mul(&p1.Z, &p2.Z).
+		Double(&D)
+	E.Sub(&B, &A)
+	F.Sub(&D, &C)
+	G.Add(&D, &C)
+	H.Add(&B, &A)
+
+	p.X.Mul(&E, &F)
+	p.Y.Mul(&G, &H)
+	p.T.Mul(&E, &H)
+	p.Z.Mul(&F, &G)
+	return p
+}
+
+// MixedAdd adds a point in extended coordinates and a point in affine coordinates
+// the formulas are complete, and assume a=-1
+// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-extended-1.html#addition-madd-2008-hwcd-3
+func (p *PointExtended) MixedAdd(p1 *PointExtended, p2 *PointAffine) *PointExtended {
+	var A, B, C, D, E, F, G, H, tmp fr.Element
+	A.Sub(&p1.Y, &p1.X)
+	tmp.Sub(&p2.Y, &p2.X)
+	A.Mul(&A, &tmp)
+	B.Add(&p1.Y, &p1.X)
+	tmp.Add(&p2.Y, &p2.X)
+	B.Mul(&B, &tmp)
+	C.Mul(&p2.X, &p2.Y).
+		Mul(&C, &p1.T).
+		Mul(&C, &edwards.D).
+		Double(&C)
+	D.Double(&p1.Z)
+	E.Sub(&B, &A)
+	F.Sub(&D, &C)
+	G.Add(&D, &C)
+	H.Add(&B, &A)
+
+	p.X.Mul(&E, &F)
+	p.Y.Mul(&G, &H)
+	p.T.Mul(&E, &H)
+	p.Z.Mul(&F, &G)
+	return p
+}
+
+// Double doubles a point in extended coordinates
+// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-extended.html#doubling-dbl-2008-hwcd
+func (p *PointExtended) Double(p1 *PointExtended) *PointExtended {
+	var A, B, C, D, E, F, G, H fr.Element
+	A.Square(&p1.X)
+	B.Square(&p1.Y)
+	C.Square(&p1.Z).
+		Double(&C)
+	D.Set(&A)
+	mulByA(&D)
+	E.Add(&p1.X, &p1.Y).
+		Square(&E).
+		Sub(&E, &A).
+		Sub(&E, &B)
+	G.Add(&D, &B)
+	F.Sub(&G, &C)
+	H.Sub(&D, &B)
+
+	p.X.Mul(&E, &F)
+	p.Y.Mul(&G, &H)
+	p.T.Mul(&E, &H)
+	p.Z.Mul(&F, &G)
+	return p
+}
+
+// mulByA multiplies x by the parameter a of the curve
+func mulByA(x *fr.Element) {
+	x.Neg(x)
+}
+
+// width of the NAF used in the scalar multiplication of arbitrary points
+const wnafWidth = 5
+
+// ScalarMul scalar multiplication of a point in extended coordinates
+// scalar NOT in Montgomery form, can be negative
+// it uses a width-5 NAF of the scalar, and is not constant time
+func (p *PointExtended) ScalarMul(p1 *PointExtended, scalar *big.Int) *PointExtended {
+
+	// odd multiples p1, [3]p1, ..., [2^(w-1)-1]p1
+	var table [1 << (wnafWidth - 2)]PointExtended
+	var double PointExtended
+	table[0].Set(p1)
+	double.Double(p1)
+	for i := 1; i < len(table); i++ {
+		table[i].Add(&table[i-1], &double)
 	}
-	var resProj, p1Proj PointProj
-	resProj.X.SetZero()
-	resProj.Y.SetOne()
-	resProj.Z.SetOne()
-	p1Proj.FromAffine(p)
-	const wordSize = bits.UintSize
-	sWords := _scalar.Bits()
-
-	for i := len(sWords) - 1; i >= 0; i-- {
-		ithWord := sWords[i]
-		for k := 0; k < wordSize; k++ {
-			resProj.Double(&resProj)
-			kthBit := (ithWord >> (wordSize - 1 - k)) & 1
-			if kthBit == 1 {
-				resProj.Add(&resProj, &p1Proj)
+
+	digits := wnaf(scalar, wnafWidth)
+
+	var res, neg PointExtended
+	res.setInfinity()
+	for i := len(digits) - 1; i >= 0; i-- {
+		res.Double(&res)
+		if digits[i] > 0 {
+			res.Add(&res, &table[digits[i]>>1])
+		} else if digits[i] < 0 {
+			neg.Neg(&table[(-digits[i])>>1])
+			res.Add(&res, &neg)
+		}
+	}
+	if scalar.Sign() == -1 {
+		res.Neg(&res)
+	}
+
+	p.Set(&res)
+	return p
+}
+
+// wnaf returns the width-w NAF of |scalar|, least significant digit first
+// the non-zero digits are odd, in ]-2^(w-1), 2^(w-1)[
+func wnaf(scalar *big.Int, w uint) []int8 {
+	var k, d big.Int
+	k.Abs(scalar)
+	digits := make([]int8, 0, k.BitLen()+1)
+	mask := uint64(1)<<w - 1
+	for k.Sign() > 0 {
+		var digit int64
+		if k.Bit(0) == 1 {
+			digit = int64(uint64(k.Bits()[0]) & mask)
+			if digit >= 1<<(w-1) {
+				digit -= 1 << w
 			}
+			k.Sub(&k, d.SetInt64(digit))
 		}
+		digits = append(digits, int8(digit))
+		k.Rsh(&k, 1)
 	}
+	return digits
+}
 
-	p.FromProj(&resProj)
+// width of the windows of the fixed-base table of the generator
+const baseTableWindow = 4
+
+var (
+	baseTableOnce sync.Once
+	// baseTable[i][j] = [(j+1)*2^(4i)]Base
+	baseTable [][1 << (baseTableWindow - 1)]PointAffine
+)
+
+// initBaseTable computes the multiples of the generator used by ScalarMulBase
+func initBaseTable() {
+	// the scalars are reduced mod Order, and the last window absorbs the carry of the signed digits
+	nbWindows := (edwards.Order.BitLen()+baseTableWindow-1)/baseTableWindow + 1
+	const nbColumns = 1 << (baseTableWindow - 1)
+
+	points := make([]PointExtended, nbWindows*nbColumns)
+	var q PointExtended
+	q.FromAffine(&edwards.Base)
+	for i := 0; i < nbWindows; i++ {
+		row := points[i*nbColumns : (i+1)*nbColumns]
+		row[0].Set(&q)
+		for j := 1; j < nbColumns; j++ {
+			row[j].Add(&row[j-1], &q)
+		}
+		// q = [2^4]q
+		q.Double(&row[nbColumns-1])
+	}
 
+	// batch conversion to affine coordinates
+	zs := make([]fr.Element, len(points))
+	for i := range points {
+		zs[i] = points[i].Z
+	}
+	zInvs := fr.BatchInvert(zs)
+	table := make([][nbColumns]PointAffine, nbWindows)
+	for i := range points {
+		table[i/nbColumns][i%nbColumns].X.Mul(&points[i].X, &zInvs[i])
+		table[i/nbColumns][i%nbColumns].Y.Mul(&points[i].Y, &zInvs[i])
+	}
+	baseTable = table
+}
+
+// ScalarMulBase sets p to [scalar]Base, where Base is the generator of the curve parameters,
+// and returns it. scalar NOT in Montgomery form, can be negative
+// it uses a precomputed table of multiples of Base (computed on first call), and is not
+// constant time
+func (p *PointExtended) ScalarMulBase(scalar *big.Int) *PointExtended {
+	baseTableOnce.Do(initBaseTable)
+
+	// Base has order Order
+	var k big.Int
+	k.Mod(scalar, &edwards.Order)
+
+	// signed digits in [-2^3, 2^3[
+	var res PointExtended
+	var neg PointAffine
+	res.setInfinity()
+	carry := 0
+	for i := range baseTable {
+		digit := carry
+		for b := 0; b < baseTableWindow; b++ {
+			digit += int(k.Bit(i*baseTableWindow+b)) << b
+		}
+		carry = (digit + 1<<(baseTableWindow-1)) >> baseTableWindow
+		digit -= carry << baseTableWindow
+
+		if digit > 0 {
+			res.MixedAdd(&res, &baseTable[i][digit-1])
+		} else if digit < 0 {
+			neg.Neg(&baseTable[i][-digit-1])
+			res.MixedAdd(&res, &neg)
+		}
+	}
+
+	p.Set(&res)
 	return p
 }
@@ -34,10 +34,10 @@ func GetEdwardsCurve() CurveParams {
 func init() {
 
 	edwards.A.SetOne().Neg(&edwards.A)
-	edwards.D.SetUint64(3021)
+	edwards.D.SetString("8160082918530293982265486372080594834520005727360845751511829954123904892305")
 	edwards.Cofactor.SetUint64(4).FromMont()
-	edwards.Order.SetString("2111115437357092606062206234695386632838870926408408195193685246394721360383", 10)
+	edwards.Order.SetString("3891893433017476224611476698964713785822781863615953791086717627802882852301", 10)
 
-	edwards.Base.X.SetString("717051916204163000937139483451426116831771857428389560441264442629694842243")
-	edwards.Base.Y.SetString("882565546457454111605105352482086902132191855952243170543452705048019814192")
+	edwards.Base.X.SetString("4047108706264297736208543891611453761698181796046164919953857490244060367469")
+	edwards.Base.Y.SetString("6862362681635583311315747098688976053520181818994730223438140691473095701241")
 }
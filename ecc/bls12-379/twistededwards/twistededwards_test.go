@@ -191,6 +191,66 @@ func TestReceiverIsOperand(t *testing.T) {
 		},
 	))
 
+	// extended
+	properties.Property("Add extended: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, p3 PointExtended
+			p1.FromAffine(&params.Base)
+			p2.FromAffine(&params.Base)
+			p3.FromAffine(&params.Base)
+
+			res := true
+
+			p3.Add(&p1, &p2)
+			p1.Add(&p1, &p2)
+			res = res && p3.Equal(&p1)
+
+			p1.FromAffine(&params.Base)
+			p2.Add(&p1, &p2)
+			res = res && p2.Equal(&p3)
+
+			return res
+		},
+	))
+
+	properties.Property("Double extended: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointExtended
+			p1.FromAffine(&params.Base)
+			p2.FromAffine(&params.Base)
+
+			p2.Double(&p1)
+			p1.Double(&p1)
+
+			return p2.Equal(&p1)
+		},
+	))
+
+	properties.Property("ScalarMul extended: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointExtended
+			p1.FromAffine(&params.Base)
+			p2.FromAffine(&params.Base)
+
+			var s big.Int
+			s.SetUint64(10)
+
+			p2.ScalarMul(&p1, &s)
+			p1.ScalarMul(&p1, &s)
+
+			return p2.Equal(&p1)
+		},
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 
 }
@@ -350,10 +410,203 @@ func TestOps(t *testing.T) {
 		genS1,
 	))
 
+	properties.Property("(projective) P+Q with non trivial Z is consistent with affine addition", prop.ForAll(
+		func(s1, s2 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, expected PointAffine
+			p1.ScalarMul(&params.Base, &s1)
+			p2.ScalarMul(&params.Base, &s2)
+			expected.Add(&p1, &p2)
+
+			var _p1, _p2 PointProj
+			_p1.FromAffine(&p1)
+			_p2.FromAffine(&p2)
+			_p1.Double(&_p1)
+			_p2.Double(&_p2)
+			_p1.Add(&_p1, &_p2)
+
+			var p PointAffine
+			p.FromProj(&_p1)
+			expected.Double(&expected)
+
+			return p.Equal(&expected)
+		},
+		genS1,
+		genS2,
+	))
+
+	// extended
+	properties.Property("(extended) P+(-P)=O", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointExtended
+			p1.FromAffine(&params.Base)
+			p1.ScalarMul(&p1, &s1)
+			p2.Neg(&p1)
+
+			p1.Add(&p1, &p2)
+
+			return p1.IsZero()
+		},
+		genS1,
+	))
+
+	properties.Property("(extended) P+Q and 2*P are consistent with affine arithmetic", prop.ForAll(
+		func(s1, s2 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, sum, double PointAffine
+			p1.ScalarMul(&params.Base, &s1)
+			p2.ScalarMul(&params.Base, &s2)
+			sum.Add(&p1, &p2)
+			double.Double(&p1)
+
+			var _p1, _p2, _sum, _double PointExtended
+			_p1.FromAffine(&p1)
+			_p2.FromAffine(&p2)
+			_p1.Double(&_p1).Add(&_p1, &_p1) // non trivial Z
+			_p2.Double(&_p2).Add(&_p2, &_p2)
+			_sum.Add(&_p1, &_p2)
+			_double.Double(&_p1)
+
+			var r1, r2, q1, q2 PointAffine
+			r1.FromExtended(&_sum)
+			r2.FromExtended(&_double)
+			q1.Double(&sum).Double(&q1)
+			q2.Double(&double).Double(&q2)
+
+			return r1.Equal(&q1) && r2.Equal(&q2)
+		},
+		genS1,
+		genS2,
+	))
+
+	properties.Property("(extended) MixedAdd is consistent with Add", prop.ForAll(
+		func(s1, s2 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointAffine
+			p1.ScalarMul(&params.Base, &s1)
+			p2.ScalarMul(&params.Base, &s2)
+
+			var _p1, _p2, r1, r2 PointExtended
+			_p1.FromAffine(&p1)
+			_p1.Double(&_p1)
+			_p2.FromAffine(&p2)
+			r1.Add(&_p1, &_p2)
+			r2.MixedAdd(&_p1, &p2)
+
+			return r1.Equal(&r2)
+		},
+		genS1,
+		genS2,
+	))
+
+	properties.Property("(extended) ScalarMul is consistent with double and add", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, expected, r PointAffine
+			p1.Set(&params.Base)
+			p1.Double(&p1).Add(&p1, &params.Base)
+			scalarMulReference(&expected, &p1, &s1)
+
+			r.ScalarMul(&p1, &s1)
+			res := r.Equal(&expected)
+
+			// negative scalar
+			s1.Neg(&s1)
+			expected.Neg(&expected)
+			r.ScalarMul(&p1, &s1)
+
+			return res && r.Equal(&expected)
+		},
+		genS1,
+	))
+
+	properties.Property("(extended) ScalarMulBase is consistent with ScalarMul", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointAffine
+			p1.ScalarMul(&params.Base, &s1)
+			p2.ScalarMulBase(&s1)
+			res := p1.Equal(&p2)
+
+			s1.Neg(&s1)
+			p1.ScalarMul(&params.Base, &s1)
+			p2.ScalarMulBase(&s1)
+
+			return res && p1.Equal(&p2)
+		},
+		genS1,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 
 }
 
+func TestCurveParams(t *testing.T) {
+	params := GetEdwardsCurve()
+
+	if !params.Base.IsOnCurve() {
+		t.Fatal("Base should be on the curve")
+	}
+	if params.Base.IsZero() {
+		t.Fatal("Base should not be the neutral element")
+	}
+
+	// double and add, independent of the scalar multiplication algorithms under test
+	var p PointAffine
+	if !scalarMulReference(&p, &params.Base, &params.Order).IsZero() {
+		t.Fatal("[Order]Base should be the neutral element")
+	}
+}
+
+func TestScalarMulEdgeCases(t *testing.T) {
+	params := GetEdwardsCurve()
+
+	var p PointAffine
+	var zero big.Int
+	if !p.ScalarMul(&params.Base, &zero).IsZero() || !p.ScalarMulBase(&zero).IsZero() {
+		t.Fatal("[0]Base should be the neutral element")
+	}
+	if !p.ScalarMul(&params.Base, &params.Order).IsZero() || !p.ScalarMulBase(&params.Order).IsZero() {
+		t.Fatal("[Order]Base should be the neutral element")
+	}
+
+	var s big.Int
+	s.Add(&params.Order, big.NewInt(3))
+	var expected PointAffine
+	expected.Double(&params.Base).Add(&expected, &params.Base)
+	if !p.ScalarMul(&params.Base, &s).Equal(&expected) || !p.ScalarMulBase(&s).Equal(&expected) {
+		t.Fatal("[Order+3]Base should be [3]Base")
+	}
+}
+
+// scalarMulReference computes [scalar]p1 with a double and add on affine points
+func scalarMulReference(p *PointAffine, p1 *PointAffine, scalar *big.Int) *PointAffine {
+	var res PointAffine
+	res.X.SetZero()
+	res.Y.SetOne()
+	for i := scalar.BitLen() - 1; i >= 0; i-- {
+		res.Double(&res)
+		if scalar.Bit(i) == 1 {
+			res.Add(&res, p1)
+		}
+	}
+	p.Set(&res)
+	return p
+}
+
 func TestMarshal(t *testing.T) {
 
 	var point, unmarshalPoint PointAffine
@@ -383,3 +636,35 @@ func GenBigInt() gopter.Gen {
 		return genResult
 	}
 }
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkScalarMul(b *testing.B) {
+	params := GetEdwardsCurve()
+	var s big.Int
+	s.SetString("52435875175126190479447705081859658376581184513", 10)
+	s.Mul(&s, &s)
+
+	var p PointExtended
+	p.FromAffine(&params.Base)
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		p.ScalarMul(&p, &s)
+	}
+}
+
+func BenchmarkScalarMulBase(b *testing.B) {
+	var s big.Int
+	s.SetString("52435875175126190479447705081859658376581184513", 10)
+	s.Mul(&s, &s)
+
+	var p PointExtended
+	p.ScalarMulBase(&s)
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		p.ScalarMulBase(&s)
+	}
+}
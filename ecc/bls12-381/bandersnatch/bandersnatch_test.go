@@ -0,0 +1,736 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bandersnatch
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+// ------------------------------------------------------------
+// tests
+
+func TestReceiverIsOperand(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+
+	properties := gopter.NewProperties(parameters)
+
+	// affine
+	properties.Property("Equal affine: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+			params := GetEdwardsCurve()
+			var p1 PointAffine
+			p1.Set(&params.Base)
+
+			return p1.Equal(&p1) && p1.Equal(&params.Base)
+		},
+	))
+
+	properties.Property("Add affine: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, p3 PointAffine
+			p1.Set(&params.Base)
+			p2.Set(&params.Base)
+			p3.Set(&params.Base)
+
+			res := true
+
+			p3.Add(&p1, &p2)
+			p1.Add(&p1, &p2)
+			res = res && p3.Equal(&p1)
+
+			p1.Set(&params.Base)
+			p2.Add(&p1, &p2)
+			res = res && p2.Equal(&p3)
+
+			return res
+		},
+	))
+
+	properties.Property("Double affine: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointAffine
+			p1.Set(&params.Base)
+			p2.Set(&params.Base)
+
+			p2.Double(&p1)
+			p1.Double(&p1)
+
+			return p2.Equal(&p1)
+		},
+	))
+
+	properties.Property("Neg affine: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointAffine
+			p1.Set(&params.Base)
+			p2.Set(&params.Base)
+
+			p2.Neg(&p1)
+			p1.Neg(&p1)
+
+			return p2.Equal(&p1)
+		},
+	))
+
+	properties.Property("Neg affine: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointAffine
+			p1.Set(&params.Base)
+			p2.Set(&params.Base)
+
+			var s big.Int
+			s.SetUint64(10)
+
+			p2.ScalarMul(&p1, &s)
+			p1.ScalarMul(&p1, &s)
+
+			return p2.Equal(&p1)
+		},
+	))
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// proj
+	properties.Property("Equal projective: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+			params := GetEdwardsCurve()
+			var p1, baseProj PointProj
+			p1.FromAffine(&params.Base)
+			baseProj.FromAffine(&params.Base)
+
+			return p1.Equal(&p1) && p1.Equal(&baseProj)
+		},
+	))
+
+	properties.Property("Add projective: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, p3 PointProj
+			p1.FromAffine(&params.Base)
+			p2.FromAffine(&params.Base)
+			p3.FromAffine(&params.Base)
+
+			res := true
+
+			p3.Add(&p1, &p2)
+			p1.Add(&p1, &p2)
+			res = res && p3.Equal(&p1)
+
+			p1.FromAffine(&params.Base)
+			p2.Add(&p1, &p2)
+			res = res && p2.Equal(&p3)
+
+			return res
+		},
+	))
+
+	properties.Property("Double projective: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointProj
+			p1.FromAffine(&params.Base)
+			p2.FromAffine(&params.Base)
+
+			p2.Double(&p1)
+			p1.Double(&p1)
+
+			return p2.Equal(&p1)
+		},
+	))
+
+	properties.Property("Neg projective: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointProj
+			p1.FromAffine(&params.Base)
+			p2.FromAffine(&params.Base)
+
+			p2.Neg(&p1)
+			p1.Neg(&p1)
+
+			return p2.Equal(&p1)
+		},
+	))
+
+	// extended
+	properties.Property("Add extended: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, p3 PointExtended
+			p1.FromAffine(&params.Base)
+			p2.FromAffine(&params.Base)
+			p3.FromAffine(&params.Base)
+
+			res := true
+
+			p3.Add(&p1, &p2)
+			p1.Add(&p1, &p2)
+			res = res && p3.Equal(&p1)
+
+			p1.FromAffine(&params.Base)
+			p2.Add(&p1, &p2)
+			res = res && p2.Equal(&p3)
+
+			return res
+		},
+	))
+
+	properties.Property("Double extended: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointExtended
+			p1.FromAffine(&params.Base)
+			p2.FromAffine(&params.Base)
+
+			p2.Double(&p1)
+			p1.Double(&p1)
+
+			return p2.Equal(&p1)
+		},
+	))
+
+	properties.Property("ScalarMul extended: having the receiver as operand should output the same result", prop.ForAll(
+		func() bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointExtended
+			p1.FromAffine(&params.Base)
+			p2.FromAffine(&params.Base)
+
+			var s big.Int
+			s.SetUint64(10)
+
+			p2.ScalarMul(&p1, &s)
+			p1.ScalarMul(&p1, &s)
+
+			return p2.Equal(&p1)
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+}
+
+func TestOps(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+
+	properties := gopter.NewProperties(parameters)
+	genS1 := GenBigInt()
+	genS2 := GenBigInt()
+
+	// affine
+	properties.Property("(affine) P+(-P)=O", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointAffine
+			p1.ScalarMul(&params.Base, &s1)
+			p2.Neg(&p1)
+
+			p1.Add(&p1, &p2)
+
+			var one fr.Element
+			one.SetOne()
+
+			return p1.X.IsZero() && p1.Y.Equal(&one)
+		},
+		genS1,
+	))
+
+	properties.Property("(affine) P+P=2*P", prop.ForAll(
+		func(s big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, inf PointAffine
+			p1.ScalarMul(&params.Base, &s)
+			p2.ScalarMul(&params.Base, &s)
+
+			p1.Add(&p1, &p2)
+			p2.Double(&p2)
+
+			return p1.Equal(&p2) && !p1.Equal(&inf)
+		},
+		genS1,
+	))
+
+	properties.Property("(affine) [a]P+[b]P = [a+b]P", prop.ForAll(
+		func(s1, s2 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, p3, inf PointAffine
+			inf.X.SetZero()
+			inf.Y.SetZero()
+			p1.ScalarMul(&params.Base, &s1)
+			p2.ScalarMul(&params.Base, &s2)
+			p3.Set(&params.Base)
+
+			p2.Add(&p1, &p2)
+
+			s1.Add(&s1, &s2)
+			p3.ScalarMul(&params.Base, &s1)
+
+			return p3.Equal(&p2) && !p3.Equal(&inf)
+		},
+		genS1,
+		genS2,
+	))
+
+	properties.Property("(affine) [a]P+[-a]P = O", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, inf PointAffine
+			inf.X.SetZero()
+			inf.Y.SetOne()
+			p1.ScalarMul(&params.Base, &s1)
+			s1.Neg(&s1)
+			p2.ScalarMul(&params.Base, &s1)
+
+			p2.Add(&p1, &p2)
+
+			return p2.Equal(&inf)
+		},
+		genS1,
+	))
+
+	properties.Property("[5]P=[2][2]P+P", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointAffine
+			p1.ScalarMul(&params.Base, &s1)
+
+			five := big.NewInt(5)
+			p2.Double(&p1).Double(&p2).Add(&p2, &p1)
+			p1.ScalarMul(&p1, five)
+
+			return p2.Equal(&p1)
+		},
+		genS1,
+	))
+
+	// proj
+	properties.Property("(projective) P+(-P)=O", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointAffine
+			p1.ScalarMul(&params.Base, &s1)
+			p2.Neg(&p1)
+
+			var _p1, _p2 PointProj
+			_p1.FromAffine(&p1)
+			_p2.FromAffine(&p2)
+			_p1.Add(&_p1, &_p2)
+
+			var p PointAffine
+			p.FromProj(&_p1)
+
+			var one fr.Element
+			one.SetOne()
+
+			return p.X.IsZero() && p.Y.Equal(&one)
+		},
+		genS1,
+	))
+
+	properties.Property("(projective) P+P=2*P", prop.ForAll(
+
+		func(s big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, inf PointAffine
+			p1.ScalarMul(&params.Base, &s)
+			p2.ScalarMul(&params.Base, &s)
+
+			var _p1, _p2 PointProj
+			_p1.FromAffine(&p1)
+			_p2.FromAffine(&p2)
+			_p1.Add(&_p1, &_p2)
+			_p2.Double(&_p2)
+
+			p1.FromProj(&_p1)
+			p2.FromProj(&_p2)
+
+			return p1.Equal(&p2) && !p1.Equal(&inf)
+		},
+		genS1,
+	))
+
+	properties.Property("(projective) P+Q with non trivial Z is consistent with affine addition", prop.ForAll(
+		func(s1, s2 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, expected PointAffine
+			p1.ScalarMul(&params.Base, &s1)
+			p2.ScalarMul(&params.Base, &s2)
+			expected.Add(&p1, &p2)
+
+			var _p1, _p2 PointProj
+			_p1.FromAffine(&p1)
+			_p2.FromAffine(&p2)
+			_p1.Double(&_p1)
+			_p2.Double(&_p2)
+			_p1.Add(&_p1, &_p2)
+
+			var p PointAffine
+			p.FromProj(&_p1)
+			expected.Double(&expected)
+
+			return p.Equal(&expected)
+		},
+		genS1,
+		genS2,
+	))
+
+	// extended
+	properties.Property("(extended) P+(-P)=O", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointExtended
+			p1.FromAffine(&params.Base)
+			p1.ScalarMul(&p1, &s1)
+			p2.Neg(&p1)
+
+			p1.Add(&p1, &p2)
+
+			return p1.IsZero()
+		},
+		genS1,
+	))
+
+	properties.Property("(extended) P+Q and 2*P are consistent with affine arithmetic", prop.ForAll(
+		func(s1, s2 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2, sum, double PointAffine
+			p1.ScalarMul(&params.Base, &s1)
+			p2.ScalarMul(&params.Base, &s2)
+			sum.Add(&p1, &p2)
+			double.Double(&p1)
+
+			var _p1, _p2, _sum, _double PointExtended
+			_p1.FromAffine(&p1)
+			_p2.FromAffine(&p2)
+			_p1.Double(&_p1).Add(&_p1, &_p1) // non trivial Z
+			_p2.Double(&_p2).Add(&_p2, &_p2)
+			_sum.Add(&_p1, &_p2)
+			_double.Double(&_p1)
+
+			var r1, r2, q1, q2 PointAffine
+			r1.FromExtended(&_sum)
+			r2.FromExtended(&_double)
+			q1.Double(&sum).Double(&q1)
+			q2.Double(&double).Double(&q2)
+
+			return r1.Equal(&q1) && r2.Equal(&q2)
+		},
+		genS1,
+		genS2,
+	))
+
+	properties.Property("(extended) MixedAdd is consistent with Add", prop.ForAll(
+		func(s1, s2 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointAffine
+			p1.ScalarMul(&params.Base, &s1)
+			p2.ScalarMul(&params.Base, &s2)
+
+			var _p1, _p2, r1, r2 PointExtended
+			_p1.FromAffine(&p1)
+			_p1.Double(&_p1)
+			_p2.FromAffine(&p2)
+			r1.Add(&_p1, &_p2)
+			r2.MixedAdd(&_p1, &p2)
+
+			return r1.Equal(&r2)
+		},
+		genS1,
+		genS2,
+	))
+
+	properties.Property("(extended) ScalarMul is consistent with double and add", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, expected, r PointAffine
+			p1.Set(&params.Base)
+			p1.Double(&p1).Add(&p1, &params.Base)
+			scalarMulReference(&expected, &p1, &s1)
+
+			r.ScalarMul(&p1, &s1)
+			res := r.Equal(&expected)
+
+			// negative scalar
+			s1.Neg(&s1)
+			expected.Neg(&expected)
+			r.ScalarMul(&p1, &s1)
+
+			return res && r.Equal(&expected)
+		},
+		genS1,
+	))
+
+	properties.Property("(extended) ScalarMulBase is consistent with ScalarMul", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var p1, p2 PointAffine
+			p1.ScalarMul(&params.Base, &s1)
+			p2.ScalarMulBase(&s1)
+			res := p1.Equal(&p2)
+
+			s1.Neg(&s1)
+			p1.ScalarMul(&params.Base, &s1)
+			p2.ScalarMulBase(&s1)
+
+			return res && p1.Equal(&p2)
+		},
+		genS1,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+}
+
+func TestCurveParams(t *testing.T) {
+	params := GetEdwardsCurve()
+
+	if !params.Base.IsOnCurve() {
+		t.Fatal("Base should be on the curve")
+	}
+	if params.Base.IsZero() {
+		t.Fatal("Base should not be the neutral element")
+	}
+
+	// double and add, independent of the scalar multiplication algorithms under test
+	var p PointAffine
+	if !scalarMulReference(&p, &params.Base, &params.Order).IsZero() {
+		t.Fatal("[Order]Base should be the neutral element")
+	}
+}
+
+func TestScalarMulEdgeCases(t *testing.T) {
+	params := GetEdwardsCurve()
+
+	var p PointAffine
+	var zero big.Int
+	if !p.ScalarMul(&params.Base, &zero).IsZero() || !p.ScalarMulBase(&zero).IsZero() {
+		t.Fatal("[0]Base should be the neutral element")
+	}
+	if !p.ScalarMul(&params.Base, &params.Order).IsZero() || !p.ScalarMulBase(&params.Order).IsZero() {
+		t.Fatal("[Order]Base should be the neutral element")
+	}
+
+	var s big.Int
+	s.Add(&params.Order, big.NewInt(3))
+	var expected PointAffine
+	expected.Double(&params.Base).Add(&expected, &params.Base)
+	if !p.ScalarMul(&params.Base, &s).Equal(&expected) || !p.ScalarMulBase(&s).Equal(&expected) {
+		t.Fatal("[Order+3]Base should be [3]Base")
+	}
+}
+
+func TestEndomorphism(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+
+	properties := gopter.NewProperties(parameters)
+	genS1 := GenBigInt()
+
+	properties.Property("phi(P) = [lambda]P on the prime order subgroup", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			var p1, phiP, expected PointExtended
+			p1.ScalarMulBase(&s1)
+			phiP.phi(&p1)
+			expected.ScalarMul(&p1, &edwards.lambda)
+
+			return phiP.Equal(&expected)
+		},
+		genS1,
+	))
+
+	properties.Property("ScalarMulGLV is consistent with ScalarMul", prop.ForAll(
+		func(s1, s2 big.Int) bool {
+
+			var p1, r1, r2 PointExtended
+			p1.ScalarMulBase(&s1)
+			r1.ScalarMul(&p1, &s2)
+			r2.ScalarMulGLV(&p1, &s2)
+			res := r1.Equal(&r2)
+
+			// negative scalar
+			s2.Neg(&s2)
+			r1.ScalarMul(&p1, &s2)
+			r2.ScalarMulGLV(&p1, &s2)
+
+			return res && r1.Equal(&r2)
+		},
+		genS1,
+		GenBigInt(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	var p1, r PointExtended
+	var zero big.Int
+	p1.FromAffine(&edwards.Base)
+	if !r.ScalarMulGLV(&p1, &zero).IsZero() || !r.ScalarMulGLV(&p1, &edwards.Order).IsZero() {
+		t.Fatal("[0]Base and [Order]Base should be the neutral element")
+	}
+}
+
+// scalarMulReference computes [scalar]p1 with a double and add on affine points
+func scalarMulReference(p *PointAffine, p1 *PointAffine, scalar *big.Int) *PointAffine {
+	var res PointAffine
+	res.X.SetZero()
+	res.Y.SetOne()
+	for i := scalar.BitLen() - 1; i >= 0; i-- {
+		res.Double(&res)
+		if scalar.Bit(i) == 1 {
+			res.Add(&res, p1)
+		}
+	}
+	p.Set(&res)
+	return p
+}
+
+func TestMarshal(t *testing.T) {
+
+	var point, unmarshalPoint PointAffine
+	point.Set(&edwards.Base)
+	for i := 0; i < 20; i++ {
+		b := point.Marshal()
+		unmarshalPoint.Unmarshal(b)
+		if !point.Equal(&unmarshalPoint) {
+			t.Fatal("error unmarshal(marshal(point))")
+		}
+		point.Add(&point, &edwards.Base)
+	}
+}
+
+// GenBigInt generates a big.Int
+// TODO @thomas we use fr size as max bound here
+func GenBigInt() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var s big.Int
+		var b [fr.Bytes]byte
+		_, err := rand.Read(b[:])
+		if err != nil {
+			panic(err)
+		}
+		s.SetBytes(b[:])
+		genResult := gopter.NewGenResult(s, gopter.NoShrinker)
+		return genResult
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkScalarMul(b *testing.B) {
+	params := GetEdwardsCurve()
+	var s big.Int
+	s.SetString("52435875175126190479447705081859658376581184513", 10)
+	s.Mul(&s, &s)
+
+	var p PointExtended
+	p.FromAffine(&params.Base)
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		p.ScalarMul(&p, &s)
+	}
+}
+
+func BenchmarkScalarMulGLV(b *testing.B) {
+	params := GetEdwardsCurve()
+	var s big.Int
+	s.SetString("52435875175126190479447705081859658376581184513", 10)
+	s.Mul(&s, &s)
+
+	var p PointExtended
+	p.FromAffine(&params.Base)
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		p.ScalarMulGLV(&p, &s)
+	}
+}
+
+func BenchmarkScalarMulBase(b *testing.B) {
+	var s big.Int
+	s.SetString("52435875175126190479447705081859658376581184513", 10)
+	s.Mul(&s, &s)
+
+	var p PointExtended
+	p.ScalarMulBase(&s)
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		p.ScalarMulBase(&s)
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package banderwagon
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// SizeElement is the size in bytes of the encoding of an Element
+const SizeElement = fr.Bytes
+
+// Element is an element of the banderwagon group, represented by one of its two Bandersnatch points.
+// The zero value is not a valid element, see Identity.
+type Element struct {
+	inner bandersnatch.PointExtended
+}
+
+var (
+	errNotInGroup      = errors.New("invalid encoding: not an element of the banderwagon group")
+	errNotCanonical    = errors.New("invalid encoding: x is not reduced")
+	errInvalidEncoding = errors.New("invalid encoding: wrong length")
+)
+
+// curve parameters of Bandersnatch, and (Order+1)/2, the inverse of 2 mod Order
+var (
+	curveParams = bandersnatch.GetEdwardsCurve()
+	inverseOf2  big.Int
+)
+
+func init() {
+	inverseOf2.Add(&curveParams.Order, big.NewInt(1)).Rsh(&inverseOf2, 1)
+}
+
+// Identity returns the neutral element of the group
+func Identity() Element {
+	var res Element
+	res.inner.X.SetZero()
+	res.inner.Y.SetOne()
+	res.inner.Z.SetOne()
+	res.inner.T.SetZero()
+	return res
+}
+
+// Generator returns the generator of the group, the image of the generator of Bandersnatch
+func Generator() Element {
+	var res Element
+	res.inner.FromAffine(&curveParams.Base)
+	return res
+}
+
+// Set sets e to e1 and returns it
+func (e *Element) Set(e1 *Element) *Element {
+	e.inner.Set(&e1.inner)
+	return e
+}
+
+// Equal returns true if e and e1 are the same element, i.e. x1·y2 = x2·y1
+func (e *Element) Equal(e1 *Element) bool {
+	var lhs, rhs fr.Element
+	lhs.Mul(&e.inner.X, &e1.inner.Y)
+	rhs.Mul(&e1.inner.X, &e.inner.Y)
+	return lhs.Equal(&rhs)
+}
+
+// IsIdentity returns true if e is the neutral element, represented by (0, 1) or (0, -1)
+func (e *Element) IsIdentity() bool {
+	return e.inner.X.IsZero()
+}
+
+// Add sets e to e1+e2 and returns it
+func (e *Element) Add(e1, e2 *Element) *Element {
+	e.inner.Add(&e1.inner, &e2.inner)
+	return e
+}
+
+// Sub sets e to e1-e2 and returns it
+func (e *Element) Sub(e1, e2 *Element) *Element {
+	var neg bandersnatch.PointExtended
+	neg.Neg(&e2.inner)
+	e.inner.Add(&e1.inner, &neg)
+	return e
+}
+
+// Double sets e to 2·e1 and returns it
+func (e *Element) Double(e1 *Element) *Element {
+	e.inner.Double(&e1.inner)
+	return e
+}
+
+// Neg sets e to -e1 and returns it
+func (e *Element) Neg(e1 *Element) *Element {
+	e.inner.Neg(&e1.inner)
+	return e
+}
+
+// ScalarMul sets e to [scalar]e1 and returns it
+// scalar NOT in Montgomery form, can be negative
+func (e *Element) ScalarMul(e1 *Element, scalar *big.Int) *Element {
+	// the representative of e1 is in the subgroup of order 2·Order, so [2]e1 is in the prime order
+	// subgroup of Bandersnatch, where GLV applies, and [scalar]e1 = [scalar/2 mod Order]([2]e1) up to
+	// the point of order 2
+	var k big.Int
+	k.Mul(scalar, &inverseOf2).Mod(&k, &curveParams.Order)
+	var p bandersnatch.PointExtended
+	p.Double(&e1.inner)
+	e.inner.ScalarMulGLV(&p, &k)
+	return e
+}
+
+// MultiExp computes the multi exponentiation of points by scalars, with a Bandersnatch multi exponentiation
+// scalars are in Montgomery form if config.ScalarsMont is set
+func (e *Element) MultiExp(points []Element, scalars []fr.Element, config ecc.MultiExpConfig) (*Element, error) {
+	if len(points) != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+	if _, err := e.inner.MultiExp(BatchToAffine(points), scalars, config); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// BatchToAffine returns the affine representatives of points, with one inversion
+func BatchToAffine(points []Element) []bandersnatch.PointAffine {
+	zs := make([]fr.Element, len(points))
+	for i := range points {
+		zs[i] = points[i].inner.Z
+	}
+	zInvs := fr.BatchInvert(zs)
+	res := make([]bandersnatch.PointAffine, len(points))
+	for i := range points {
+		res[i].X.Mul(&points[i].inner.X, &zInvs[i])
+		res[i].Y.Mul(&points[i].inner.Y, &zInvs[i])
+	}
+	return res
+}
+
+// MapToBaseField returns x/y, which doesn't depend on the representative of e, as used by Verkle trees
+// to commit to commitments
+func (e *Element) MapToBaseField() fr.Element {
+	var res fr.Element
+	res.Div(&e.inner.X, &e.inner.Y)
+	return res
+}
+
+// Bytes returns the canonical encoding of e: the x coordinate of its representative with y
+// lexicographically largest, in big endian
+func (e *Element) Bytes() [SizeElement]byte {
+	var p bandersnatch.PointAffine
+	p.FromExtended(&e.inner)
+	x := p.X
+	if !p.Y.LexicographicallyLargest() {
+		x.Neg(&x)
+	}
+	return x.Bytes()
+}
+
+// SetBytes sets e from its canonical encoding buf, see Bytes
+// It returns an error if buf is not the canonical encoding of an element of the group.
+func (e *Element) SetBytes(buf []byte) error {
+	if len(buf) != SizeElement {
+		return errInvalidEncoding
+	}
+	var bx big.Int
+	bx.SetBytes(buf)
+	if bx.Cmp(fr.Modulus()) >= 0 {
+		return errNotCanonical
+	}
+	var p bandersnatch.PointAffine
+	p.X.SetBigInt(&bx)
+
+	// (x, y) is on the curve for y^2 = (1-a·x^2)/(1-d·x^2), and in the subgroup of order 2·Order
+	// iff 1-a·x^2 is a square (1-d·x^2 is not zero, as d is not a square)
+	var num, den, one fr.Element
+	one.SetOne()
+	num.Square(&p.X)
+	den.Mul(&num, &curveParams.D)
+	den.Sub(&one, &den)
+	num.Mul(&num, &curveParams.A)
+	num.Sub(&one, &num)
+	if num.Legendre() != 1 {
+		return errNotInGroup
+	}
+	p.Y.Div(&num, &den)
+	if p.Y.Sqrt(&p.Y) == nil {
+		return errNotInGroup
+	}
+	if !p.Y.LexicographicallyLargest() {
+		p.Y.Neg(&p.Y)
+	}
+
+	e.inner.FromAffine(&p)
+	return nil
+}
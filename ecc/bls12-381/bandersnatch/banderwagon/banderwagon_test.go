@@ -0,0 +1,259 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package banderwagon
+
+import (
+	"encoding/hex"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestEncodingFixedVectors(t *testing.T) {
+	// encodings of the successive doublings of the generator, from the Verkle trees specification
+	expected := []string{
+		"4a2c7486fd924882bf02c6908de395122843e3e05264d7991e18e7985dad51e9",
+		"43aa74ef706605705989e8fd38df46873b7eae5921fbed115ac9d937399ce4d5",
+		"5e5f550494159f38aa54d2ed7f11a7e93e4968617990445cc93ac8e59808c126",
+		"0e7e3748db7c5c999a7bcd93d71d671f1f40090423792266f94cb27ca43fce5c",
+	}
+
+	e := Generator()
+	for i := range expected {
+		b := e.Bytes()
+		if hex.EncodeToString(b[:]) != expected[i] {
+			t.Fatalf("wrong encoding of [2^%d]G", i)
+		}
+		var decoded Element
+		if err := decoded.SetBytes(b[:]); err != nil || !decoded.Equal(&e) {
+			t.Fatalf("[2^%d]G: decoding should invert encoding", i)
+		}
+		e.Double(&e)
+	}
+}
+
+func TestElement(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+
+	properties := gopter.NewProperties(parameters)
+	genS1 := GenBigInt()
+	genS2 := GenBigInt()
+
+	properties.Property("(x, y) and (-x, -y) are the same element", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			g := Generator()
+			var e1, e2 Element
+			e1.ScalarMul(&g, &s1)
+			e2.Set(&e1)
+			e2.inner.Neg(&e2.inner)
+			e2.inner.Y.Neg(&e2.inner.Y)
+			e2.inner.T.Neg(&e2.inner.T)
+
+			b1, b2 := e1.Bytes(), e2.Bytes()
+			m1, m2 := e1.MapToBaseField(), e2.MapToBaseField()
+			return e1.Equal(&e2) && b1 == b2 && m1.Equal(&m2)
+		},
+		genS1,
+	))
+
+	properties.Property("SetBytes(Bytes(e)) = e", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			g := Generator()
+			var e1, e2 Element
+			e1.ScalarMul(&g, &s1)
+			b := e1.Bytes()
+			if err := e2.SetBytes(b[:]); err != nil {
+				return false
+			}
+			return e1.Equal(&e2)
+		},
+		genS1,
+	))
+
+	properties.Property("[a]G+[b]G = [a+b]G, [a]G-[b]G = [a-b]G", prop.ForAll(
+		func(s1, s2 big.Int) bool {
+
+			g := Generator()
+			var e1, e2, sum, diff, expectedSum, expectedDiff Element
+			e1.ScalarMul(&g, &s1)
+			e2.ScalarMul(&g, &s2)
+			sum.Add(&e1, &e2)
+			diff.Sub(&e1, &e2)
+
+			var s big.Int
+			expectedSum.ScalarMul(&g, s.Add(&s1, &s2))
+			expectedDiff.ScalarMul(&g, s.Sub(&s1, &s2))
+
+			return sum.Equal(&expectedSum) && diff.Equal(&expectedDiff) && !sum.Equal(&diff)
+		},
+		genS1,
+		genS2,
+	))
+
+	properties.Property("ScalarMul is consistent for both representatives", prop.ForAll(
+		func(s1 big.Int) bool {
+
+			g := Generator()
+			var e1, e2, r1, r2 Element
+			e1.Double(&g).Add(&e1, &g)
+			e2.Set(&e1)
+			e2.inner.X.Neg(&e2.inner.X)
+			e2.inner.Y.Neg(&e2.inner.Y)
+
+			r1.ScalarMul(&e1, &s1)
+			r2.ScalarMul(&e2, &s1)
+
+			// [s1]([3]G) = [3·s1]G
+			var s big.Int
+			var expected Element
+			expected.ScalarMul(&g, s.Mul(&s1, big.NewInt(3)))
+
+			return r1.Equal(&expected) && r2.Equal(&expected)
+		},
+		genS1,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// identity
+	id := Identity()
+	g := Generator()
+	var e Element
+	if !e.ScalarMul(&g, &curveParams.Order).IsIdentity() || !e.Equal(&id) || e.Equal(&g) {
+		t.Fatal("[Order]G should be the identity")
+	}
+	b := id.Bytes()
+	if b != [SizeElement]byte{} {
+		t.Fatal("identity should be encoded as 0")
+	}
+	if err := e.SetBytes(b[:]); err != nil || !e.IsIdentity() {
+		t.Fatal("0 should be decoded as the identity")
+	}
+}
+
+func TestSetBytesInvalid(t *testing.T) {
+	var e Element
+
+	// wrong length, x not reduced
+	if err := e.SetBytes(make([]byte, SizeElement-1)); err == nil {
+		t.Fatal("short encoding should be rejected")
+	}
+	m := fr.Modulus().Bytes()
+	if err := e.SetBytes(m); err == nil {
+		t.Fatal("non canonical encoding should be rejected")
+	}
+
+	// x such that 1-a·x^2 is not a square: not in the group
+	var x, tmp, one fr.Element
+	one.SetOne()
+	for {
+		x.SetRandom()
+		tmp.Square(&x).Mul(&tmp, &curveParams.A)
+		tmp.Sub(&one, &tmp)
+		if tmp.Legendre() == -1 {
+			break
+		}
+	}
+	b := x.Bytes()
+	if err := e.SetBytes(b[:]); err == nil {
+		t.Fatal("point out of the group should be rejected")
+	}
+}
+
+func TestMultiExp(t *testing.T) {
+	const nbSamples = 73
+
+	g := Generator()
+	points := make([]Element, nbSamples)
+	scalars := make([]fr.Element, nbSamples)
+	expected := Identity()
+	var tmp Element
+	var s big.Int
+	for i := range points {
+		points[i].ScalarMul(&g, big.NewInt(int64(i+1)))
+		// other representative
+		if i%2 == 1 {
+			points[i].inner.X.Neg(&points[i].inner.X)
+			points[i].inner.Y.Neg(&points[i].inner.Y)
+		}
+		scalars[i].SetRandom()
+		scalars[i].ToBigIntRegular(&s)
+		tmp.ScalarMul(&points[i], &s)
+		expected.Add(&expected, &tmp)
+	}
+
+	var result Element
+	if _, err := result.MultiExp(points, scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !result.Equal(&expected) {
+		t.Fatal("multi exponentiation should be consistent with the sum of scalar multiplications")
+	}
+	if _, err := result.MultiExp(points, scalars[1:], ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("wrong number of scalars should be rejected")
+	}
+}
+
+// GenBigInt generates a big.Int
+func GenBigInt() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var s big.Int
+		var b [fr.Bytes]byte
+		_, err := rand.Read(b[:])
+		if err != nil {
+			panic(err)
+		}
+		s.SetBytes(b[:])
+		genResult := gopter.NewGenResult(s, gopter.NoShrinker)
+		return genResult
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkScalarMul(b *testing.B) {
+	var s big.Int
+	s.SetString("52435875175126190479447705081859658376581184513", 10)
+	s.Mul(&s, &s)
+
+	e := Generator()
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		e.ScalarMul(&e, &s)
+	}
+}
+
+func BenchmarkSetBytes(b *testing.B) {
+	e := Generator()
+	buf := e.Bytes()
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		e.SetBytes(buf[:])
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package banderwagon provides the banderwagon group used by Verkle trees, built on the Bandersnatch
+// curve defined on bls12-381's fr.
+//
+// banderwagon is the quotient of the subgroup of order 2·Order of Bandersnatch by the point of
+// order 2 (0, -1): the points (x, y) and (-x, -y) are the same element. It is a group of prime
+// order, and its elements have a canonical encoding of one field element.
+package banderwagon
@@ -14,16 +14,8 @@
 
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
-package mockcommitment
-
-import "github.com/consensys/gnark-crypto/ecc/bw6-764/fr"
-
-// MockBatchProofsSinglePoint empty struct
-type MockBatchProofsSinglePoint struct {
-	Point         fr.Element
-	ClaimedValues []fr.Element
-}
-
-func (mp *MockBatchProofsSinglePoint) Marshal() []byte {
-	panic("not implemented")
-}
+// Package bandersnatch provides bls12-381's Bandersnatch twisted edwards "companion curve" defined on fr.
+//
+// Bandersnatch has an efficient endomorphism, used for GLV scalar multiplication on its prime order subgroup.
+// See https://eprint.iacr.org/2021/1152.pdf
+package bandersnatch
@@ -0,0 +1,717 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bandersnatch
+
+import (
+	"crypto/subtle"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// PointAffine point on a twisted Edwards curve
+type PointAffine struct {
+	X, Y fr.Element
+}
+
+// PointProj point in projective coordinates
+type PointProj struct {
+	X, Y, Z fr.Element
+}
+
+// PointExtended point in extended coordinates (x=X/Z, y=Y/Z, T=XY/Z)
+// cf https://eprint.iacr.org/2008/522.pdf
+type PointExtended struct {
+	X, Y, Z, T fr.Element
+}
+
+const (
+	//following https://tools.ietf.org/html/rfc8032#section-3.1,
+	// an fr element x is negative if its binary encoding is
+	// lexicographically larger than -x.
+	mCompressedNegative = 0x80
+	mCompressedPositive = 0x00
+	mUnmask             = 0x7f
+
+	// size in byte of a compressed point (point.Y --> fr.Element)
+	sizePointCompressed = fr.Limbs * 8
+)
+
+// Bytes returns the compressed point as a byte array
+// Follows https://tools.ietf.org/html/rfc8032#section-3.1,
+// as the twisted Edwards implementation is primarily used
+// for eddsa.
+func (p *PointAffine) Bytes() [sizePointCompressed]byte {
+
+	var res [sizePointCompressed]byte
+	var mask uint
+
+	y := p.Y.Bytes()
+
+	if p.X.LexicographicallyLargest() {
+		mask = mCompressedNegative
+	} else {
+		mask = mCompressedPositive
+	}
+	// p.Y must be in little endian
+	y[0] |= byte(mask) // msb of y
+	for i, j := 0, sizePointCompressed-1; i < j; i, j = i+1, j-1 {
+		y[i], y[j] = y[j], y[i]
+	}
+	subtle.ConstantTimeCopy(1, res[:], y[:])
+	return res
+}
+
+// Marshal converts p to a byte slice
+func (p *PointAffine) Marshal() []byte {
+	b := p.Bytes()
+	return b[:]
+}
+
+func computeX(y *fr.Element) (x fr.Element) {
+	var one, num, den fr.Element
+	one.SetOne()
+	num.Square(y)
+	den.Mul(&num, &edwards.D)
+	num.Sub(&one, &num)
+	den.Sub(&edwards.A, &den)
+	x.Div(&num, &den)
+	x.Sqrt(&x)
+	return
+}
+
+// SetBytes sets p from buf
+// len(buf) >= sizePointCompressed
+// buf contains the Y coordinate masked with a parity bit to recompute the X coordinate
+// from the curve equation. See Bytes() and https://tools.ietf.org/html/rfc8032#section-3.1
+// Returns the number of read bytes and an error if the buffer is too short.
+func (p *PointAffine) SetBytes(buf []byte) (int, error) {
+
+	if len(buf) < sizePointCompressed {
+		return 0, io.ErrShortBuffer
+	}
+	bufCopy := make([]byte, sizePointCompressed)
+	subtle.ConstantTimeCopy(1, bufCopy, buf[:sizePointCompressed])
+	for i, j := 0, sizePointCompressed-1; i < j; i, j = i+1, j-1 {
+		bufCopy[i], bufCopy[j] = bufCopy[j], bufCopy[i]
+	}
+	isLexicographicallyLargest := (mCompressedNegative&bufCopy[0])>>7 == 1
+	bufCopy[0] &= mUnmask
+	p.Y.SetBytes(bufCopy)
+	p.X = computeX(&p.Y)
+	if isLexicographicallyLargest {
+		if !p.X.LexicographicallyLargest() {
+			p.X.Neg(&p.X)
+		}
+	} else {
+		if p.X.LexicographicallyLargest() {
+			p.X.Neg(&p.X)
+		}
+	}
+
+	return sizePointCompressed, nil
+}
+
+// Unmarshal alias to SetBytes()
+func (p *PointAffine) Unmarshal(b []byte) error {
+	_, err := p.SetBytes(b)
+	return err
+}
+
+// Set sets p to p1 and return it
+func (p *PointProj) Set(p1 *PointProj) *PointProj {
+	p.X.Set(&p1.X)
+	p.Y.Set(&p1.Y)
+	p.Z.Set(&p1.Z)
+	return p
+}
+
+// Set sets p to p1 and return it
+func (p *PointAffine) Set(p1 *PointAffine) *PointAffine {
+	p.X.Set(&p1.X)
+	p.Y.Set(&p1.Y)
+	return p
+}
+
+// Equal returns true if p=p1 false otherwise
+func (p *PointAffine) Equal(p1 *PointAffine) bool {
+	return p.X.Equal(&p1.X) && p.Y.Equal(&p1.Y)
+}
+
+// Equal returns true if p=p1 false otherwise
+// If one point is on the affine chart Z=0 it returns false
+func (p *PointProj) Equal(p1 *PointProj) bool {
+	if p.Z.IsZero() || p1.Z.IsZero() {
+		return false
+	}
+	var pAffine, p1Affine PointAffine
+	pAffine.FromProj(p)
+	p1Affine.FromProj(p1)
+	return pAffine.Equal(&p1Affine)
+}
+
+// NewPointAffine creates a new instance of PointAffine
+func NewPointAffine(x, y fr.Element) PointAffine {
+	return PointAffine{x, y}
+}
+
+// IsOnCurve checks if a point is on the twisted Edwards curve
+func (p *PointAffine) IsOnCurve() bool {
+
+	ecurve := GetEdwardsCurve()
+
+	var lhs, rhs, tmp fr.Element
+
+	tmp.Mul(&p.Y, &p.Y)
+	lhs.Mul(&p.X, &p.X)
+	mulByA(&lhs)
+	lhs.Add(&lhs, &tmp)
+
+	tmp.Mul(&p.X, &p.X).
+		Mul(&tmp, &p.Y).
+		Mul(&tmp, &p.Y).
+		Mul(&tmp, &ecurve.D)
+	rhs.SetOne().Add(&rhs, &tmp)
+
+	return lhs.Equal(&rhs)
+}
+
+// Add adds two points (x,y), (u,v) on a twisted Edwards curve with parameters a, d
+// modifies p
+func (p *PointAffine) Add(p1, p2 *PointAffine) *PointAffine {
+
+	ecurve := GetEdwardsCurve()
+
+	var xu, yv, xv, yu, dxyuv, one, denx, deny fr.Element
+	pRes := new(PointAffine)
+	xv.Mul(&p1.X, &p2.Y)
+	yu.Mul(&p1.Y, &p2.X)
+	pRes.X.Add(&xv, &yu)
+
+	xu.Mul(&p1.X, &p2.X)
+	mulByA(&xu)
+	yv.Mul(&p1.Y, &p2.Y)
+	pRes.Y.Sub(&yv, &xu)
+
+	dxyuv.Mul(&xv, &yu).Mul(&dxyuv, &ecurve.D)
+	one.SetOne()
+	denx.Add(&one, &dxyuv)
+	deny.Sub(&one, &dxyuv)
+
+	p.X.Div(&pRes.X, &denx)
+	p.Y.Div(&pRes.Y, &deny)
+
+	return p
+}
+
+// Double doubles point (x,y) on a twisted Edwards curve with parameters a, d
+// modifies p
+func (p *PointAffine) Double(p1 *PointAffine) *PointAffine {
+
+	p.Set(p1)
+	var xx, yy, xy, denum, two fr.Element
+	xx.Square(&p.X)
+	mulByA(&xx)
+	yy.Square(&p.Y)
+	xy.Mul(&p.X, &p.Y)
+	denum.Add(&xx, &yy)
+
+	p.X.Double(&xy).Div(&p.X, &denum)
+
+	two.SetOne().Double(&two)
+	denum.Neg(&denum).Add(&denum, &two)
+
+	p.Y.Sub(&yy, &xx).Div(&p.Y, &denum)
+
+	return p
+}
+
+// Neg negates point (x,y) on a twisted Edwards curve with parameters a, d
+// modifies p
+func (p *PointProj) Neg(p1 *PointProj) *PointProj {
+	p.Set(p1)
+	p.X.Neg(&p.X)
+	return p
+}
+
+// FromProj sets p in affine from p in projective
+func (p *PointAffine) FromProj(p1 *PointProj) *PointAffine {
+	p.X.Div(&p1.X, &p1.Z)
+	p.Y.Div(&p1.Y, &p1.Z)
+	return p
+}
+
+// FromAffine sets p in projective from p in affine
+func (p *PointProj) FromAffine(p1 *PointAffine) *PointProj {
+	p.X.Set(&p1.X)
+	p.Y.Set(&p1.Y)
+	p.Z.SetOne()
+	return p
+}
+
+// Add adds points in projective coordinates
+// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-projective.html#addition-add-2008-bbjlp
+func (p *PointProj) Add(p1, p2 *PointProj) *PointProj {
+
+	var res PointProj
+
+	ecurve := GetEdwardsCurve()
+
+	var A, B, C, D, E, F, G, H, I fr.Element
+	A.Mul(&p1.Z, &p2.Z)
+	B.Square(&A)
+	C.Mul(&p1.X, &p2.X)
+	D.Mul(&p1.Y, &p2.Y)
+	E.Mul(&ecurve.D, &C).Mul(&E, &D)
+	F.Sub(&B, &E)
+	G.Add(&B, &E)
+	H.Add(&p1.X, &p1.Y)
+	I.Add(&p2.X, &p2.Y)
+	res.X.Mul(&H, &I).
+		Sub(&res.X, &C).
+		Sub(&res.X, &D).
+		Mul(&res.X, &A).
+		Mul(&res.X, &F)
+	mulByA(&C)
+	res.Y.Sub(&D, &C).
+		Mul(&res.Y, &A).
+		Mul(&res.Y, &G)
+	res.Z.Mul(&F, &G)
+
+	p.Set(&res)
+	return p
+}
+
+// Double adds points in projective coordinates
+// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-projective.html#doubling-dbl-2008-bbjlp
+func (p *PointProj) Double(p1 *PointProj) *PointProj {
+
+	var res PointProj
+
+	var B, C, D, E, F, H, J, tmp fr.Element
+
+	B.Add(&p1.X, &p1.Y).Square(&B)
+	C.Square(&p1.X)
+	D.Square(&p1.Y)
+	E.Set(&C)
+	mulByA(&E)
+	F.Add(&E, &D)
+	H.Square(&p1.Z)
+	tmp.Double(&H)
+	J.Sub(&F, &tmp)
+	res.X.Sub(&B, &C).
+		Sub(&res.X, &D).
+		Mul(&res.X, &J)
+	res.Y.Sub(&E, &D).Mul(&res.Y, &F)
+	res.Z.Mul(&F, &J)
+
+	p.Set(&res)
+	return p
+}
+
+// Neg sets p to -p1 and returns it
+func (p *PointAffine) Neg(p1 *PointAffine) *PointAffine {
+	p.Set(p1)
+	p.X.Neg(&p.X)
+	return p
+}
+
+// ScalarMul scalar multiplication of a point
+// p1 points on the twisted Edwards curve
+// scalar NOT in Montgomery form, can be negative
+// modifies p
+func (p *PointAffine) ScalarMul(p1 *PointAffine, scalar *big.Int) *PointAffine {
+	var _p PointExtended
+	_p.FromAffine(p1)
+	_p.ScalarMul(&_p, scalar)
+	p.FromExtended(&_p)
+	return p
+}
+
+// ScalarMulBase sets p to [scalar]Base, where Base is the generator of the curve parameters,
+// using a precomputed table of multiples of Base
+func (p *PointAffine) ScalarMulBase(scalar *big.Int) *PointAffine {
+	var _p PointExtended
+	_p.ScalarMulBase(scalar)
+	p.FromExtended(&_p)
+	return p
+}
+
+// IsZero returns true if p is the neutral element (0, 1)
+func (p *PointAffine) IsZero() bool {
+	var one fr.Element
+	one.SetOne()
+	return p.X.IsZero() && p.Y.Equal(&one)
+}
+
+// FromExtended sets p in affine from p in extended coordinates
+func (p *PointAffine) FromExtended(p1 *PointExtended) *PointAffine {
+	var zInv fr.Element
+	zInv.Inverse(&p1.Z)
+	p.X.Mul(&p1.X, &zInv)
+	p.Y.Mul(&p1.Y, &zInv)
+	return p
+}
+
+// Set sets p to p1 and return it
+func (p *PointExtended) Set(p1 *PointExtended) *PointExtended {
+	p.X.Set(&p1.X)
+	p.Y.Set(&p1.Y)
+	p.Z.Set(&p1.Z)
+	p.T.Set(&p1.T)
+	return p
+}
+
+// Equal returns true if p=p1 false otherwise
+func (p *PointExtended) Equal(p1 *PointExtended) bool {
+	var lhs, rhs fr.Element
+	lhs.Mul(&p.X, &p1.Z)
+	rhs.Mul(&p1.X, &p.Z)
+	if !lhs.Equal(&rhs) {
+		return false
+	}
+	lhs.Mul(&p.Y, &p1.Z)
+	rhs.Mul(&p1.Y, &p.Z)
+	return lhs.Equal(&rhs)
+}
+
+// IsZero returns true if p is the neutral element (0:1:1:0)
+func (p *PointExtended) IsZero() bool {
+	return p.X.IsZero() && p.Y.Equal(&p.Z)
+}
+
+// setInfinity sets p to the neutral element (0:1:1:0)
+func (p *PointExtended) setInfinity() *PointExtended {
+	p.X.SetZero()
+	p.Y.SetOne()
+	p.Z.SetOne()
+	p.T.SetZero()
+	return p
+}
+
+// Neg sets p to -p1 and returns it
+func (p *PointExtended) Neg(p1 *PointExtended) *PointExtended {
+	p.Set(p1)
+	p.X.Neg(&p.X)
+	p.T.Neg(&p.T)
+	return p
+}
+
+// FromAffine sets p in extended from p in affine
+func (p *PointExtended) FromAffine(p1 *PointAffine) *PointExtended {
+	p.X.Set(&p1.X)
+	p.Y.Set(&p1.Y)
+	p.Z.SetOne()
+	p.T.Mul(&p1.X, &p1.Y)
+	return p
+}
+
+// Add adds points in extended coordinates
+// the formulas are complete for points of odd order
+// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-extended.html#addition-add-2008-hwcd
+func (p *PointExtended) Add(p1, p2 *PointExtended) *PointExtended {
+	var A, B, C, D, E, F, G, H, tmp fr.Element
+	A.Mul(&p1.X, &p2.X)
+	B.Mul(&p1.Y, &p2.Y)
+	C.Mul(&p1.T, &p2.T).
+		Mul(&C, &edwards.D)
+	D.Mul(&p1.Z, &p2.Z)
+	E.Add(&p1.X, &p1.Y)
+	tmp.Add(&p2.X, &p2.Y)
+	E.Mul(&E, &tmp).
+		Sub(&E, &A).
+		Sub(&E, &B)
+	F.Sub(&D, &C)
+	G.Add(&D, &C)
+	mulByA(&A)
+	H.Sub(&B, &A)
+
+	p.X.Mul(&E, &F)
+	p.Y.Mul(&G, &H)
+	p.T.Mul(&E, &H)
+	p.Z.Mul(&F, &G)
+	return p
+}
+
+// MixedAdd adds a point in extended coordinates and a point in affine coordinates
+// the formulas are complete for points of odd order
+// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-extended.html#addition-madd-2008-hwcd
+func (p *PointExtended) MixedAdd(p1 *PointExtended, p2 *PointAffine) *PointExtended {
+	var A, B, C, E, F, G, H, tmp fr.Element
+	A.Mul(&p1.X, &p2.X)
+	B.Mul(&p1.Y, &p2.Y)
+	C.Mul(&p2.X, &p2.Y).
+		Mul(&C, &p1.T).
+		Mul(&C, &edwards.D)
+	E.Add(&p1.X, &p1.Y)
+	tmp.Add(&p2.X, &p2.Y)
+	E.Mul(&E, &tmp).
+		Sub(&E, &A).
+		Sub(&E, &B)
+	F.Sub(&p1.Z, &C)
+	G.Add(&p1.Z, &C)
+	mulByA(&A)
+	H.Sub(&B, &A)
+
+	p.X.Mul(&E, &F)
+	p.Y.Mul(&G, &H)
+	p.T.Mul(&E, &H)
+	p.Z.Mul(&F, &G)
+	return p
+}
+
+// Double doubles a point in extended coordinates
+// cf https://hyperelliptic.org/EFD/g1p/auto-twisted-extended.html#doubling-dbl-2008-hwcd
+func (p *PointExtended) Double(p1 *PointExtended) *PointExtended {
+	var A, B, C, D, E, F, G, H fr.Element
+	A.Square(&p1.X)
+	B.Square(&p1.Y)
+	C.Square(&p1.Z).
+		Double(&C)
+	D.Set(&A)
+	mulByA(&D)
+	E.Add(&p1.X, &p1.Y).
+		Square(&E).
+		Sub(&E, &A).
+		Sub(&E, &B)
+	G.Add(&D, &B)
+	F.Sub(&G, &C)
+	H.Sub(&D, &B)
+
+	p.X.Mul(&E, &F)
+	p.Y.Mul(&G, &H)
+	p.T.Mul(&E, &H)
+	p.Z.Mul(&F, &G)
+	return p
+}
+
+// mulByA multiplies x by the parameter a of the curve
+func mulByA(x *fr.Element) {
+	x.Mul(x, &edwards.A)
+}
+
+// width of the NAF used in the scalar multiplication of arbitrary points
+const wnafWidth = 5
+
+// ScalarMul scalar multiplication of a point in extended coordinates
+// scalar NOT in Montgomery form, can be negative
+// it uses a width-5 NAF of the scalar, and is not constant time
+func (p *PointExtended) ScalarMul(p1 *PointExtended, scalar *big.Int) *PointExtended {
+
+	// odd multiples p1, [3]p1, ..., [2^(w-1)-1]p1
+	var table [1 << (wnafWidth - 2)]PointExtended
+	var double PointExtended
+	table[0].Set(p1)
+	double.Double(p1)
+	for i := 1; i < len(table); i++ {
+		table[i].Add(&table[i-1], &double)
+	}
+
+	digits := wnaf(scalar, wnafWidth)
+
+	var res, neg PointExtended
+	res.setInfinity()
+	for i := len(digits) - 1; i >= 0; i-- {
+		res.Double(&res)
+		if digits[i] > 0 {
+			res.Add(&res, &table[digits[i]>>1])
+		} else if digits[i] < 0 {
+			neg.Neg(&table[(-digits[i])>>1])
+			res.Add(&res, &neg)
+		}
+	}
+	if scalar.Sign() == -1 {
+		res.Neg(&res)
+	}
+
+	p.Set(&res)
+	return p
+}
+
+// wnaf returns the width-w NAF of |scalar|, least significant digit first
+// the non-zero digits are odd, in ]-2^(w-1), 2^(w-1)[
+func wnaf(scalar *big.Int, w uint) []int8 {
+	var k, d big.Int
+	k.Abs(scalar)
+	digits := make([]int8, 0, k.BitLen()+1)
+	mask := uint64(1)<<w - 1
+	for k.Sign() > 0 {
+		var digit int64
+		if k.Bit(0) == 1 {
+			digit = int64(uint64(k.Bits()[0]) & mask)
+			if digit >= 1<<(w-1) {
+				digit -= 1 << w
+			}
+			k.Sub(&k, d.SetInt64(digit))
+		}
+		digits = append(digits, int8(digit))
+		k.Rsh(&k, 1)
+	}
+	return digits
+}
+
+// width of the windows of the fixed-base table of the generator
+const baseTableWindow = 4
+
+var (
+	baseTableOnce sync.Once
+	// baseTable[i][j] = [(j+1)*2^(4i)]Base
+	baseTable [][1 << (baseTableWindow - 1)]PointAffine
+)
+
+// initBaseTable computes the multiples of the generator used by ScalarMulBase
+func initBaseTable() {
+	// the scalars are reduced mod Order, and the last window absorbs the carry of the signed digits
+	nbWindows := (edwards.Order.BitLen()+baseTableWindow-1)/baseTableWindow + 1
+	const nbColumns = 1 << (baseTableWindow - 1)
+
+	points := make([]PointExtended, nbWindows*nbColumns)
+	var q PointExtended
+	q.FromAffine(&edwards.Base)
+	for i := 0; i < nbWindows; i++ {
+		row := points[i*nbColumns : (i+1)*nbColumns]
+		row[0].Set(&q)
+		for j := 1; j < nbColumns; j++ {
+			row[j].Add(&row[j-1], &q)
+		}
+		// q = [2^4]q
+		q.Double(&row[nbColumns-1])
+	}
+
+	// batch conversion to affine coordinates
+	zs := make([]fr.Element, len(points))
+	for i := range points {
+		zs[i] = points[i].Z
+	}
+	zInvs := fr.BatchInvert(zs)
+	table := make([][nbColumns]PointAffine, nbWindows)
+	for i := range points {
+		table[i/nbColumns][i%nbColumns].X.Mul(&points[i].X, &zInvs[i])
+		table[i/nbColumns][i%nbColumns].Y.Mul(&points[i].Y, &zInvs[i])
+	}
+	baseTable = table
+}
+
+// ScalarMulBase sets p to [scalar]Base, where Base is the generator of the curve parameters,
+// and returns it. scalar NOT in Montgomery form, can be negative
+// it uses a precomputed table of multiples of Base (computed on first call), and is not
+// constant time
+func (p *PointExtended) ScalarMulBase(scalar *big.Int) *PointExtended {
+	baseTableOnce.Do(initBaseTable)
+
+	// Base has order Order
+	var k big.Int
+	k.Mod(scalar, &edwards.Order)
+
+	// signed digits in [-2^3, 2^3[
+	var res PointExtended
+	var neg PointAffine
+	res.setInfinity()
+	carry := 0
+	for i := range baseTable {
+		digit := carry
+		for b := 0; b < baseTableWindow; b++ {
+			digit += int(k.Bit(i*baseTableWindow+b)) << b
+		}
+		carry = (digit + 1<<(baseTableWindow-1)) >> baseTableWindow
+		digit -= carry << baseTableWindow
+
+		if digit > 0 {
+			res.MixedAdd(&res, &baseTable[i][digit-1])
+		} else if digit < 0 {
+			neg.Neg(&baseTable[i][-digit-1])
+			res.MixedAdd(&res, &neg)
+		}
+	}
+
+	p.Set(&res)
+	return p
+}
+
+// phi sets p to phi(p1) and returns it, where phi is the endomorphism of the curve,
+// acting as [lambda] on the prime order subgroup
+func (p *PointExtended) phi(p1 *PointExtended) *PointExtended {
+	var zz, yy, xy, f, g, h fr.Element
+	zz.Square(&p1.Z)
+	yy.Square(&p1.Y)
+	xy.Mul(&p1.X, &p1.Y)
+	f.Sub(&zz, &yy).Mul(&f, &edwards.endo[1])
+	zz.Mul(&zz, &edwards.endo[0])
+	g.Add(&yy, &zz).Mul(&g, &edwards.endo[0])
+	h.Sub(&yy, &zz)
+
+	p.X.Mul(&f, &h)
+	p.Y.Mul(&g, &xy)
+	p.Z.Mul(&h, &xy)
+	p.T.Mul(&f, &g)
+	return p
+}
+
+// ScalarMulGLV scalar multiplication of a point in extended coordinates
+// scalar NOT in Montgomery form, can be negative
+// p1 MUST be in the prime order subgroup: the scalar is split in two half-size scalars
+// k0 + lambda*k1 = scalar mod Order (GLV), processed with interleaved width-5 NAFs
+func (p *PointExtended) ScalarMulGLV(p1 *PointExtended, scalar *big.Int) *PointExtended {
+
+	var k big.Int
+	k.Mod(scalar, &edwards.Order)
+	split := ecc.SplitScalar(&k, &edwards.glvBasis)
+
+	// odd multiples of p1 and phi(p1)
+	var tables [2][1 << (wnafWidth - 2)]PointExtended
+	var double PointExtended
+	tables[0][0].Set(p1)
+	tables[1][0].phi(p1)
+	var digits [2][]int8
+	for j := 0; j < 2; j++ {
+		if split[j].Sign() == -1 {
+			tables[j][0].Neg(&tables[j][0])
+		}
+		double.Double(&tables[j][0])
+		for i := 1; i < len(tables[j]); i++ {
+			tables[j][i].Add(&tables[j][i-1], &double)
+		}
+		digits[j] = wnaf(&split[j], wnafWidth)
+	}
+
+	n := len(digits[0])
+	if len(digits[1]) > n {
+		n = len(digits[1])
+	}
+	var res, neg PointExtended
+	res.setInfinity()
+	for i := n - 1; i >= 0; i-- {
+		res.Double(&res)
+		for j := 0; j < 2; j++ {
+			if i >= len(digits[j]) {
+				continue
+			}
+			if digits[j][i] > 0 {
+				res.Add(&res, &tables[j][digits[j][i]>>1])
+			} else if digits[j][i] < 0 {
+				neg.Neg(&tables[j][(-digits[j][i])>>1])
+				res.Add(&res, &neg)
+			}
+		}
+	}
+
+	p.Set(&res)
+	return p
+}
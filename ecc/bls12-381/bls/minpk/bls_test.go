@@ -0,0 +1,412 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package minpk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	crand "crypto/rand"
+
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+func Example() {
+	// create a bls key pair
+	privateKey, _ := signature.BLS_MINPK_BLS12_381.New(crand.Reader)
+	publicKey := privateKey.Public()
+
+	msg := []byte("message")
+
+	// sign the message, it is hashed to the curve by the scheme
+	signature, _ := privateKey.Sign(msg, nil)
+
+	// verifies signature
+	isValid, _ := publicKey.Verify(signature, msg, nil)
+	if !isValid {
+		fmt.Println("1. invalid signature")
+	} else {
+		fmt.Println("1. valid signature")
+	}
+
+	// Output: 1. valid signature
+}
+
+func TestSerialization(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	privKey1, err := signature.BLS_MINPK_BLS12_381.New(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey1 := privKey1.Public()
+
+	privKey2, err := signature.BLS_MINPK_BLS12_381.New(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey2 := privKey2.Public()
+
+	pubKeyBin1 := pubKey1.Bytes()
+	if _, err := pubKey2.SetBytes(pubKeyBin1); err != nil {
+		t.Fatal(err)
+	}
+	if !pubKey1.Equal(pubKey2) {
+		t.Fatal("Error serialize(deserialize(.))")
+	}
+
+	privKeyBin1 := privKey1.Bytes()
+	if _, err := privKey2.SetBytes(privKeyBin1); err != nil {
+		t.Fatal(err)
+	}
+	privKeyBin2 := privKey2.Bytes()
+	if len(privKeyBin1) != len(privKeyBin2) {
+		t.Fatal("Inconistent size")
+	}
+	for i := 0; i < len(privKeyBin1); i++ {
+		if privKeyBin1[i] != privKeyBin2[i] {
+			t.Fatal("Error serialize(deserialize(.))")
+		}
+	}
+
+	// the point at infinity is not a valid public key
+	var pk PublicKey
+	if _, err := pk.SetBytes(pk.Bytes()); err == nil {
+		t.Fatal("point at infinity should be rejected")
+	}
+}
+
+func TestKeyGen(t *testing.T) {
+
+	ikm := make([]byte, 32)
+	for i := 0; i < len(ikm); i++ {
+		ikm[i] = byte(i)
+	}
+
+	privKey1, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKey2, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !privKey1.PublicKey.Equal(&privKey2.PublicKey) {
+		t.Fatal("KeyGen should be deterministic")
+	}
+
+	privKey3, err := KeyGen(ikm, []byte("info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if privKey1.PublicKey.Equal(&privKey3.PublicKey) {
+		t.Fatal("KeyGen should depend on the key info")
+	}
+
+	if _, err := KeyGen(ikm[:31], nil); err == nil {
+		t.Fatal("KeyGen should reject short key material")
+	}
+}
+
+func TestBLS(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	privKey, err := GenerateKey(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := privKey.Public()
+
+	signature, err := privKey.Sign([]byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// verifies correct msg
+	res, err := pubKey.Verify(signature, []byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("Verifiy correct signature should return true")
+	}
+
+	// verifies wrong msg
+	res, err = pubKey.Verify(signature, []byte("wrong_message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("Verfiy wrong signature should be false")
+	}
+
+	// pre-hashed message
+	signature, err = privKey.Sign([]byte("message"), sha256.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = pubKey.Verify(signature, []byte("message"), sha256.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("Verifiy correct signature should return true")
+	}
+	res, err = pubKey.Verify(signature, []byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("Verfiy signature with a different hash should be false")
+	}
+
+	// proof of possession
+	proof, err := privKey.ProvePossession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = privKey.PublicKey.VerifyPossession(&proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("VerifyPossession of a correct proof should return true")
+	}
+	otherKey, err := GenerateKey(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = otherKey.PublicKey.VerifyPossession(&proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("VerifyPossession of a wrong proof should be false")
+	}
+}
+
+func TestAggregate(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	const nbSigners = 4
+	privKeys := make([]PrivateKey, nbSigners)
+	pubKeys := make([]PublicKey, nbSigners)
+	for i := 0; i < nbSigners; i++ {
+		var err error
+		privKeys[i], err = GenerateKey(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKeys[i] = privKeys[i].PublicKey
+	}
+
+	// same message
+	msg := []byte("message")
+	sigs := make([]Signature, nbSigners)
+	for i := 0; i < nbSigners; i++ {
+		var err error
+		sigs[i], err = privKeys[i].sign(msg, dstSignature)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	aggSig, err := Aggregate(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := FastAggregateVerify(pubKeys, msg, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("FastAggregateVerify of a correct signature should return true")
+	}
+	res, err = FastAggregateVerify(pubKeys[1:], msg, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("FastAggregateVerify with a missing signer should be false")
+	}
+
+	// distinct messages
+	msgs := make([][]byte, nbSigners)
+	for i := 0; i < nbSigners; i++ {
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		sigs[i], err = privKeys[i].sign(msgs[i], dstSignature)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	aggSig, err = Aggregate(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = AggregateVerify(pubKeys, msgs, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("AggregateVerify of a correct signature should return true")
+	}
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+	res, err = AggregateVerify(pubKeys, msgs, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("AggregateVerify with swapped messages should be false")
+	}
+
+	if _, err := AggregateVerify(pubKeys, msgs[1:], &aggSig); err == nil {
+		t.Fatal("AggregateVerify should reject lists of different lengths")
+	}
+	if _, err := Aggregate(nil); err == nil {
+		t.Fatal("Aggregate should reject an empty list")
+	}
+}
+
+// TestKnownAnswers checks KeyGen, Sign, ProvePossession and Aggregate against values computed
+// with an independent implementation (github.com/cloudflare/circl) of the ciphersuite
+// BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_.
+func TestKnownAnswers(t *testing.T) {
+
+	vectors := []struct {
+		ikm, msg         string // input keying material (hex), message
+		sk, pk, sig, pop string // expected secret scalar, public key, signature and proof of possession (hex)
+	}{
+		{
+			ikm: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+			msg: "",
+			sk:  "23360db7e337b0a32b264e06bc11c1b474d16f55665373de1ce93cf15ddb3456",
+			pk:  "9112a0386a2340714ba0c6d2df235377a8679c3899d03e6ef04dba7a50ef49e5a1dc93105e9374e93ed301b63487e17c",
+			sig: "899196e283b54fbaeab546500a454f03bcca077273b58411b364841a412a3d9fcd548271a1f9cff1575c9c662745a2e816f1bb6826768bb65da9bf6c483c2e6851ed6a2a113d13b2e7c2d7a693cddfa6bca8f466c18720459e26c759d1d8d3de",
+			pop: "915993b4e43e717ec8079234490be46018bdc7d70e81de1bbec515844a3754cc0a387ddf825a2faa0984fa794a96b5a20da605161aa42c1d4028abeb3c52ffbf35d41bd26398e7110d0b6566e0b74b30b3431c4b821cc85a9d61ad5ffd3f9042",
+		},
+		{
+			ikm: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f",
+			msg: "abc",
+			sk:  "35c64fa4ea102440bd883e0085a94ae24bbfe9a756fce8558eaf40220644ebb2",
+			pk:  "93936ce6a8e86787fd9038f20abf65075aaf4c52209afba0ec69833d3d37dc263db874146c85ca475c4b2d17ab8772ed",
+			sig: "b6e894079a213a67117ee80bb9ad318dceeb68e8664eb32b3a7ef0bb2fbc66efc0a6faefdd6b7f208703e589a4ab06731791e18af8657e0d984eba5243fcddb5d622d6c02f6a803a536a327ba1b51039f82965f3c0f91291062bf565dd6a0de2",
+			pop: "877b187309730d5fc78639ee60083ad242ec72b9b55d8f184ac0853e1aa82574dc29b9a7ccf6bbbda067c2dafd917742113db0ccd09196714cd33139da6a7a915fde65d5c5ca5301bd536de2080735482589c20bb77609325fc8d018763954a2",
+		},
+	}
+	// aggregate of the signatures of the vectors
+	const aggregate = "85c165df89c616b9d3cb9f07c9b2ad6b17feb722cf55a9a7c79597db2e5b2538bec9cbde0bd0825b55f4287e82adec740e72c02ca2e55c047447ccabef048b4326becb29547a0499dd22efd528bbc5210b3ea7e85876ffc676d4c814accd90a1"
+
+	pubKeys := make([]PublicKey, len(vectors))
+	msgs := make([][]byte, len(vectors))
+	sigs := make([]Signature, len(vectors))
+	for i, v := range vectors {
+		ikm, err := hex.DecodeString(v.ikm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		privKey, err := KeyGen(ikm, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(privKey.scalar[:]) != v.sk {
+			t.Fatalf("vector %d: wrong secret key", i)
+		}
+		if hex.EncodeToString(privKey.PublicKey.Bytes()) != v.pk {
+			t.Fatalf("vector %d: wrong public key", i)
+		}
+
+		msgs[i] = []byte(v.msg)
+		sig, err := privKey.Sign(msgs[i], nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(sig) != v.sig {
+			t.Fatalf("vector %d: wrong signature", i)
+		}
+		if _, err := sigs[i].SetBytes(sig); err != nil {
+			t.Fatal(err)
+		}
+
+		proof, err := privKey.ProvePossession()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(proof.Bytes()) != v.pop {
+			t.Fatalf("vector %d: wrong proof of possession", i)
+		}
+		pubKeys[i] = privKey.PublicKey
+	}
+
+	aggSig, err := Aggregate(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(aggSig.Bytes()) != aggregate {
+		t.Fatal("wrong aggregate signature")
+	}
+	res, err := AggregateVerify(pubKeys, msgs, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("AggregateVerify of the known aggregate signature should return true")
+	}
+}
+
+// benchmarks
+
+func BenchmarkSign(b *testing.B) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	privKey, err := GenerateKey(r)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.Sign([]byte("message"), nil)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	privKey, err := GenerateKey(r)
+	if err != nil {
+		b.Fatal(err)
+	}
+	pubKey := privKey.Public()
+	signature, _ := privKey.Sign([]byte("message"), nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pubKey.Verify(signature, []byte("message"), nil)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package minpk provides BLS signatures on bls12-381, with public keys in G1
+// and signatures in G2.
+//
+// It implements the proof of possession scheme of the IETF draft, with
+// ciphersuite ID BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_.
+// Signatures of a same message can be aggregated and verified with FastAggregateVerify,
+// provided each public key comes with a valid proof of possession.
+//
+// # See also
+//
+// https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05
+package minpk
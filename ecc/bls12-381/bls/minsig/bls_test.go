@@ -0,0 +1,412 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package minsig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	crand "crypto/rand"
+
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+func Example() {
+	// create a bls key pair
+	privateKey, _ := signature.BLS_MINSIG_BLS12_381.New(crand.Reader)
+	publicKey := privateKey.Public()
+
+	msg := []byte("message")
+
+	// sign the message, it is hashed to the curve by the scheme
+	signature, _ := privateKey.Sign(msg, nil)
+
+	// verifies signature
+	isValid, _ := publicKey.Verify(signature, msg, nil)
+	if !isValid {
+		fmt.Println("1. invalid signature")
+	} else {
+		fmt.Println("1. valid signature")
+	}
+
+	// Output: 1. valid signature
+}
+
+func TestSerialization(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	privKey1, err := signature.BLS_MINSIG_BLS12_381.New(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey1 := privKey1.Public()
+
+	privKey2, err := signature.BLS_MINSIG_BLS12_381.New(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey2 := privKey2.Public()
+
+	pubKeyBin1 := pubKey1.Bytes()
+	if _, err := pubKey2.SetBytes(pubKeyBin1); err != nil {
+		t.Fatal(err)
+	}
+	if !pubKey1.Equal(pubKey2) {
+		t.Fatal("Error serialize(deserialize(.))")
+	}
+
+	privKeyBin1 := privKey1.Bytes()
+	if _, err := privKey2.SetBytes(privKeyBin1); err != nil {
+		t.Fatal(err)
+	}
+	privKeyBin2 := privKey2.Bytes()
+	if len(privKeyBin1) != len(privKeyBin2) {
+		t.Fatal("Inconistent size")
+	}
+	for i := 0; i < len(privKeyBin1); i++ {
+		if privKeyBin1[i] != privKeyBin2[i] {
+			t.Fatal("Error serialize(deserialize(.))")
+		}
+	}
+
+	// the point at infinity is not a valid public key
+	var pk PublicKey
+	if _, err := pk.SetBytes(pk.Bytes()); err == nil {
+		t.Fatal("point at infinity should be rejected")
+	}
+}
+
+func TestKeyGen(t *testing.T) {
+
+	ikm := make([]byte, 32)
+	for i := 0; i < len(ikm); i++ {
+		ikm[i] = byte(i)
+	}
+
+	privKey1, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privKey2, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !privKey1.PublicKey.Equal(&privKey2.PublicKey) {
+		t.Fatal("KeyGen should be deterministic")
+	}
+
+	privKey3, err := KeyGen(ikm, []byte("info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if privKey1.PublicKey.Equal(&privKey3.PublicKey) {
+		t.Fatal("KeyGen should depend on the key info")
+	}
+
+	if _, err := KeyGen(ikm[:31], nil); err == nil {
+		t.Fatal("KeyGen should reject short key material")
+	}
+}
+
+func TestBLS(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	privKey, err := GenerateKey(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := privKey.Public()
+
+	signature, err := privKey.Sign([]byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// verifies correct msg
+	res, err := pubKey.Verify(signature, []byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("Verifiy correct signature should return true")
+	}
+
+	// verifies wrong msg
+	res, err = pubKey.Verify(signature, []byte("wrong_message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("Verfiy wrong signature should be false")
+	}
+
+	// pre-hashed message
+	signature, err = privKey.Sign([]byte("message"), sha256.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = pubKey.Verify(signature, []byte("message"), sha256.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("Verifiy correct signature should return true")
+	}
+	res, err = pubKey.Verify(signature, []byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("Verfiy signature with a different hash should be false")
+	}
+
+	// proof of possession
+	proof, err := privKey.ProvePossession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = privKey.PublicKey.VerifyPossession(&proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("VerifyPossession of a correct proof should return true")
+	}
+	otherKey, err := GenerateKey(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = otherKey.PublicKey.VerifyPossession(&proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("VerifyPossession of a wrong proof should be false")
+	}
+}
+
+func TestAggregate(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	const nbSigners = 4
+	privKeys := make([]PrivateKey, nbSigners)
+	pubKeys := make([]PublicKey, nbSigners)
+	for i := 0; i < nbSigners; i++ {
+		var err error
+		privKeys[i], err = GenerateKey(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKeys[i] = privKeys[i].PublicKey
+	}
+
+	// same message
+	msg := []byte("message")
+	sigs := make([]Signature, nbSigners)
+	for i := 0; i < nbSigners; i++ {
+		var err error
+		sigs[i], err = privKeys[i].sign(msg, dstSignature)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	aggSig, err := Aggregate(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := FastAggregateVerify(pubKeys, msg, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("FastAggregateVerify of a correct signature should return true")
+	}
+	res, err = FastAggregateVerify(pubKeys[1:], msg, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("FastAggregateVerify with a missing signer should be false")
+	}
+
+	// distinct messages
+	msgs := make([][]byte, nbSigners)
+	for i := 0; i < nbSigners; i++ {
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		sigs[i], err = privKeys[i].sign(msgs[i], dstSignature)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	aggSig, err = Aggregate(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = AggregateVerify(pubKeys, msgs, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("AggregateVerify of a correct signature should return true")
+	}
+	msgs[0], msgs[1] = msgs[1], msgs[0]
+	res, err = AggregateVerify(pubKeys, msgs, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res {
+		t.Fatal("AggregateVerify with swapped messages should be false")
+	}
+
+	if _, err := AggregateVerify(pubKeys, msgs[1:], &aggSig); err == nil {
+		t.Fatal("AggregateVerify should reject lists of different lengths")
+	}
+	if _, err := Aggregate(nil); err == nil {
+		t.Fatal("Aggregate should reject an empty list")
+	}
+}
+
+// TestKnownAnswers checks KeyGen, Sign, ProvePossession and Aggregate against values computed
+// with an independent implementation (github.com/cloudflare/circl) of the ciphersuite
+// BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_.
+func TestKnownAnswers(t *testing.T) {
+
+	vectors := []struct {
+		ikm, msg         string // input keying material (hex), message
+		sk, pk, sig, pop string // expected secret scalar, public key, signature and proof of possession (hex)
+	}{
+		{
+			ikm: "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+			msg: "",
+			sk:  "23360db7e337b0a32b264e06bc11c1b474d16f55665373de1ce93cf15ddb3456",
+			pk:  "acfd749941a5bea56796745d1fc91668d63f9522374cb6e9c033433e3216dcad48b4fc1ab7000a365f2861565daa6b0819fd041ac58eed8c441c8b3478df6ceeaf89cc02c8119f63891a1368d7ec1d0c7e2abaaae2ac8579b7eece473478dac7",
+			sig: "adfa9f0c4f37c2e9e7a38604b8cce24e8db028430175769e8e658a448c41c69d9bcdfd460e26ca5ee7d0cb89a326b0bf",
+			pop: "b99321d33a3c3b4e351b7d510b9b28b697b1727eb6d57b0982e5e95f7d2b4f91d40b676624eec9478b06b35ae67e6d98",
+		},
+		{
+			ikm: "202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f",
+			msg: "abc",
+			sk:  "35c64fa4ea102440bd883e0085a94ae24bbfe9a756fce8558eaf40220644ebb2",
+			pk:  "842706c5250b5dbafe4b4b497c00cdece55b807db08824c2c9a1ac73a88dc27bbd3616d5fa2894534a8270f1b2779d5615bce8be164022fb848d0bc87c1f0e151aad15fbdca6ad5d733af5e478443ea9f8655978625e7cc2bb22e581436ce11d",
+			sig: "88477d859e966a711d52bd8ad94c3a8731344e475ae59d075b4742131f7755c4e3970a6de7ed227d29e56787836131b9",
+			pop: "937baa9c58cd941657c2f8198dd2c90412eb1dc1c1523d2967ebf872b5fff8f3beb880fa86dc96b9528dcd553d0b6cc0",
+		},
+	}
+	// aggregate of the signatures of the vectors
+	const aggregate = "ab3c9e39e5931d6ef870acb6cfc4a85c2120ce2e1d3fb3cc39779a3f37b5ab75039f0bc6611e685abc089c4aaae2b257"
+
+	pubKeys := make([]PublicKey, len(vectors))
+	msgs := make([][]byte, len(vectors))
+	sigs := make([]Signature, len(vectors))
+	for i, v := range vectors {
+		ikm, err := hex.DecodeString(v.ikm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		privKey, err := KeyGen(ikm, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(privKey.scalar[:]) != v.sk {
+			t.Fatalf("vector %d: wrong secret key", i)
+		}
+		if hex.EncodeToString(privKey.PublicKey.Bytes()) != v.pk {
+			t.Fatalf("vector %d: wrong public key", i)
+		}
+
+		msgs[i] = []byte(v.msg)
+		sig, err := privKey.Sign(msgs[i], nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(sig) != v.sig {
+			t.Fatalf("vector %d: wrong signature", i)
+		}
+		if _, err := sigs[i].SetBytes(sig); err != nil {
+			t.Fatal(err)
+		}
+
+		proof, err := privKey.ProvePossession()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(proof.Bytes()) != v.pop {
+			t.Fatalf("vector %d: wrong proof of possession", i)
+		}
+		pubKeys[i] = privKey.PublicKey
+	}
+
+	aggSig, err := Aggregate(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(aggSig.Bytes()) != aggregate {
+		t.Fatal("wrong aggregate signature")
+	}
+	res, err := AggregateVerify(pubKeys, msgs, &aggSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res {
+		t.Fatal("AggregateVerify of the known aggregate signature should return true")
+	}
+}
+
+// benchmarks
+
+func BenchmarkSign(b *testing.B) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	privKey, err := GenerateKey(r)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.Sign([]byte("message"), nil)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	privKey, err := GenerateKey(r)
+	if err != nil {
+		b.Fatal(err)
+	}
+	pubKey := privKey.Public()
+	signature, _ := privKey.Sign([]byte("message"), nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pubKey.Verify(signature, []byte("message"), nil)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+func init() {
+	ecc.RegisterDomain(curve.ID, func(m uint64) ecc.Domain {
+		return genericDomain{NewDomain(m, 0, false)}
+	})
+}
+
+// genericDomain implements ecc.Domain
+type genericDomain struct {
+	domain *Domain
+}
+
+// Cardinality returns the size of the domain
+func (d genericDomain) Cardinality() uint64 {
+	return d.domain.Cardinality
+}
+
+// FFT evaluates in place the polynomial of coefficients a on the domain, in natural order
+func (d genericDomain) FFT(a []*big.Int) {
+	_a := toElements(a)
+	d.domain.FFT(_a, DIF, 0)
+	BitReverse(_a)
+	fromElements(a, _a)
+}
+
+// FFTInverse interpolates in place the evaluations a on the domain, in natural order
+func (d genericDomain) FFTInverse(a []*big.Int) {
+	_a := toElements(a)
+	d.domain.FFTInverse(_a, DIF, 0)
+	BitReverse(_a)
+	fromElements(a, _a)
+}
+
+func toElements(a []*big.Int) []fr.Element {
+	res := make([]fr.Element, len(a))
+	for i := 0; i < len(a); i++ {
+		res[i].SetBigInt(a[i])
+	}
+	return res
+}
+
+func fromElements(a []*big.Int, _a []fr.Element) {
+	for i := 0; i < len(a); i++ {
+		_a[i].ToBigIntRegular(a[i])
+	}
+}
@@ -0,0 +1,570 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+)
+
+// ----------------------------------------------------------------------------------------
+// EIP-4844 blobs
+//
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/deneb/polynomial-commitments.md
+//
+// A blob is a polynomial of degree < FieldElementsPerBlob given by its evaluations
+// on the FieldElementsPerBlob-th roots of unity, in bit-reversed order.
+
+const (
+	// BytesPerFieldElement size of a serialized field element
+	BytesPerFieldElement = fr.Bytes
+
+	// FieldElementsPerBlob number of field elements in a blob
+	FieldElementsPerBlob = 4096
+
+	// BytesPerBlob size of a serialized blob
+	BytesPerBlob = BytesPerFieldElement * FieldElementsPerBlob
+
+	// BytesPerCommitment size of a serialized commitment (compressed G1 point)
+	BytesPerCommitment = bls12381.SizeOfG1AffineCompressed
+
+	// BytesPerProof size of a serialized proof (compressed G1 point)
+	BytesPerProof = bls12381.SizeOfG1AffineCompressed
+)
+
+// domain separators of the Fiat-Shamir challenges
+const (
+	fiatShamirProtocolDomain      = "FSBLOBVERIFY_V1_"
+	randomChallengeKZGBatchDomain = "RCKZGBATCH___V1_"
+)
+
+// primitiveRootOfUnity generates the roots of unity used by the specs
+const primitiveRootOfUnity = 7
+
+var (
+	ErrInvalidFieldElement = errors.New("invalid field element: not canonical")
+	ErrInvalidTrustedSetup = errors.New("invalid trusted setup")
+	ErrInvalidNbBlobs      = errors.New("number of blobs, commitments and proofs differ")
+	ErrInvalidPoint        = errors.New("invalid point: not canonically encoded")
+)
+
+// Blob serialized blob, the concatenation of FieldElementsPerBlob big endian field elements
+type Blob [BytesPerBlob]byte
+
+// Bytes32 serialized field element, in big endian
+type Bytes32 [BytesPerFieldElement]byte
+
+// Commitment serialized KZG commitment to a blob
+type Commitment [BytesPerCommitment]byte
+
+// Proof serialized KZG opening proof
+type Proof [BytesPerProof]byte
+
+// BlobContext holds the trusted setup of the EIP-4844 ceremony, and precomputed values
+// to commit to, open and verify blobs.
+type BlobContext struct {
+	// g1Lagrange Lagrange basis of the setup, in bit-reversed order
+	g1Lagrange []bls12381.G1Affine
+
+	// g2 [gen, [alpha]gen]
+	g2 [2]bls12381.G2Affine
+
+	// roots FieldElementsPerBlob-th roots of unity, in bit-reversed order
+	roots []fr.Element
+
+	// invWidth 1/FieldElementsPerBlob
+	invWidth fr.Element
+}
+
+// NewBlobContext returns a BlobContext from the Lagrange basis of the setup in G1,
+// in natural order (as in the ceremony files), and the monomial setup in G2
+// (only the first two points are used).
+func NewBlobContext(g1Lagrange []bls12381.G1Affine, g2Monomial []bls12381.G2Affine) (*BlobContext, error) {
+	if len(g1Lagrange) != FieldElementsPerBlob || len(g2Monomial) < 2 {
+		return nil, ErrInvalidTrustedSetup
+	}
+
+	var ctx BlobContext
+
+	ctx.g1Lagrange = make([]bls12381.G1Affine, FieldElementsPerBlob)
+	copy(ctx.g1Lagrange, g1Lagrange)
+	bitReverseG1(ctx.g1Lagrange)
+	ctx.g2[0].Set(&g2Monomial[0])
+	ctx.g2[1].Set(&g2Monomial[1])
+
+	// roots of unity
+	var root fr.Element
+	exponent := new(big.Int).Sub(fr.Modulus(), big.NewInt(1))
+	exponent.Div(exponent, big.NewInt(FieldElementsPerBlob))
+	root.SetUint64(primitiveRootOfUnity).Exp(root, exponent)
+	ctx.roots = make([]fr.Element, FieldElementsPerBlob)
+	ctx.roots[0].SetOne()
+	for i := 1; i < FieldElementsPerBlob; i++ {
+		ctx.roots[i].Mul(&ctx.roots[i-1], &root)
+	}
+	fft.BitReverse(ctx.roots)
+
+	ctx.invWidth.SetUint64(FieldElementsPerBlob).Inverse(&ctx.invWidth)
+
+	return &ctx, nil
+}
+
+// LoadTrustedSetup reads the trusted setup in the text format of the ceremony (c-kzg-4844
+// trusted_setup.txt): the number of G1 points, the number of G2 points, then one hex encoded
+// compressed point per line: the G1 Lagrange basis, the G2 monomial basis, and optionally
+// the G1 monomial basis.
+func LoadTrustedSetup(r io.Reader) (*BlobContext, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) < 2 {
+		return nil, ErrInvalidTrustedSetup
+	}
+	nbG1, err := strconv.Atoi(lines[0])
+	if err != nil {
+		return nil, err
+	}
+	nbG2, err := strconv.Atoi(lines[1])
+	if err != nil {
+		return nil, err
+	}
+	lines = lines[2:]
+
+	// the G1 monomial basis, if present, comes last and is not used
+	if nbG1 < 0 || nbG2 < 0 || (len(lines) != nbG1+nbG2 && len(lines) != 2*nbG1+nbG2) {
+		return nil, ErrInvalidTrustedSetup
+	}
+	return newBlobContextFromHex(lines[:nbG1], lines[nbG1:nbG1+nbG2])
+}
+
+// LoadTrustedSetupJSON reads the trusted setup in the JSON format of the consensus specs,
+// with the fields "g1_lagrange" and "g2_monomial" holding hex encoded compressed points.
+func LoadTrustedSetupJSON(r io.Reader) (*BlobContext, error) {
+	var setup struct {
+		G1Lagrange []string `json:"g1_lagrange"`
+		G2Monomial []string `json:"g2_monomial"`
+	}
+	if err := json.NewDecoder(r).Decode(&setup); err != nil {
+		return nil, err
+	}
+	return newBlobContextFromHex(setup.G1Lagrange, setup.G2Monomial)
+}
+
+// newBlobContextFromHex decodes the hex encoded points of the setup and returns a BlobContext
+func newBlobContextFromHex(g1Lagrange, g2Monomial []string) (*BlobContext, error) {
+	if len(g1Lagrange) != FieldElementsPerBlob || len(g2Monomial) < 2 {
+		return nil, ErrInvalidTrustedSetup
+	}
+	g1 := make([]bls12381.G1Affine, len(g1Lagrange))
+	for i := 0; i < len(g1Lagrange); i++ {
+		b, err := hex.DecodeString(strings.TrimPrefix(g1Lagrange[i], "0x"))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := g1[i].SetBytes(b); err != nil {
+			return nil, err
+		}
+	}
+	var g2 [2]bls12381.G2Affine
+	for i := 0; i < 2; i++ {
+		b, err := hex.DecodeString(strings.TrimPrefix(g2Monomial[i], "0x"))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := g2[i].SetBytes(b); err != nil {
+			return nil, err
+		}
+	}
+	return NewBlobContext(g1, g2[:])
+}
+
+// BlobToKZGCommitment returns the commitment to the polynomial represented by blob.
+func (ctx *BlobContext) BlobToKZGCommitment(blob *Blob) (Commitment, error) {
+	polynomial, err := blobToPolynomial(blob)
+	if err != nil {
+		return Commitment{}, err
+	}
+	c, err := ctx.commit(polynomial)
+	if err != nil {
+		return Commitment{}, err
+	}
+	return c.Bytes(), nil
+}
+
+// ComputeKZGProof returns the proof that the polynomial represented by blob evaluates to y at z.
+func (ctx *BlobContext) ComputeKZGProof(blob *Blob, z Bytes32) (Proof, Bytes32, error) {
+	polynomial, err := blobToPolynomial(blob)
+	if err != nil {
+		return Proof{}, Bytes32{}, err
+	}
+	var _z fr.Element
+	if err := bytesToBLSField(&_z, z[:]); err != nil {
+		return Proof{}, Bytes32{}, err
+	}
+	proof, y, err := ctx.computeKZGProof(polynomial, &_z)
+	if err != nil {
+		return Proof{}, Bytes32{}, err
+	}
+	return proof.Bytes(), y.Bytes(), nil
+}
+
+// ComputeBlobKZGProof returns the proof of the evaluation of the polynomial represented by blob
+// at the Fiat-Shamir challenge derived from blob and its commitment.
+func (ctx *BlobContext) ComputeBlobKZGProof(blob *Blob, commitment Commitment) (Proof, error) {
+	if _, err := bytesToG1(commitment[:]); err != nil {
+		return Proof{}, err
+	}
+	polynomial, err := blobToPolynomial(blob)
+	if err != nil {
+		return Proof{}, err
+	}
+	z := computeChallenge(blob, &commitment)
+	proof, _, err := ctx.computeKZGProof(polynomial, &z)
+	if err != nil {
+		return Proof{}, err
+	}
+	return proof.Bytes(), nil
+}
+
+// VerifyKZGProof verifies that the polynomial committed in commitment evaluates to y at z.
+func (ctx *BlobContext) VerifyKZGProof(commitment Commitment, z, y Bytes32, proof Proof) error {
+	c, err := bytesToG1(commitment[:])
+	if err != nil {
+		return err
+	}
+	p, err := bytesToG1(proof[:])
+	if err != nil {
+		return err
+	}
+	var _z, _y fr.Element
+	if err := bytesToBLSField(&_z, z[:]); err != nil {
+		return err
+	}
+	if err := bytesToBLSField(&_y, y[:]); err != nil {
+		return err
+	}
+	return ctx.verifyKZGProof(&c, &_z, &_y, &p)
+}
+
+// VerifyBlobKZGProof verifies a proof computed with ComputeBlobKZGProof.
+func (ctx *BlobContext) VerifyBlobKZGProof(blob *Blob, commitment Commitment, proof Proof) error {
+	c, err := bytesToG1(commitment[:])
+	if err != nil {
+		return err
+	}
+	polynomial, err := blobToPolynomial(blob)
+	if err != nil {
+		return err
+	}
+	z := computeChallenge(blob, &commitment)
+	y := ctx.evaluate(polynomial, &z)
+	p, err := bytesToG1(proof[:])
+	if err != nil {
+		return err
+	}
+	return ctx.verifyKZGProof(&c, &z, &y, &p)
+}
+
+// VerifyBlobKZGProofBatch verifies several proofs computed with ComputeBlobKZGProof, using a
+// random linear combination so that only one pairing check is performed.
+func (ctx *BlobContext) VerifyBlobKZGProofBatch(blobs []Blob, commitments []Commitment, proofs []Proof) error {
+	if len(blobs) != len(commitments) || len(blobs) != len(proofs) {
+		return ErrInvalidNbBlobs
+	}
+	n := len(blobs)
+	if n == 0 {
+		return nil
+	}
+
+	zs := make([]fr.Element, n)
+	ys := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		polynomial, err := blobToPolynomial(&blobs[i])
+		if err != nil {
+			return err
+		}
+		zs[i] = computeChallenge(&blobs[i], &commitments[i])
+		ys[i] = ctx.evaluate(polynomial, &zs[i])
+	}
+
+	return ctx.verifyKZGProofBatch(commitments, zs, ys, proofs)
+}
+
+// commit returns Σ p_i * [L_i(alpha)]G1
+func (ctx *BlobContext) commit(polynomial []fr.Element) (bls12381.G1Affine, error) {
+	var res bls12381.G1Affine
+	_, err := res.MultiExp(ctx.g1Lagrange, polynomial, ecc.MultiExpConfig{ScalarsMont: true})
+	return res, err
+}
+
+// computeKZGProof returns the commitment to (p(X) - p(z)) / (X - z), and p(z)
+func (ctx *BlobContext) computeKZGProof(polynomial []fr.Element, z *fr.Element) (bls12381.G1Affine, fr.Element, error) {
+
+	y := ctx.evaluate(polynomial, z)
+
+	// quotient_i = (p_i - y) / (ω_i - z), except at ω_i == z
+	denominators := make([]fr.Element, FieldElementsPerBlob)
+	inDomain := -1
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		denominators[i].Sub(&ctx.roots[i], z)
+		if denominators[i].IsZero() {
+			inDomain = i
+		}
+	}
+	denominators = fr.BatchInvert(denominators)
+
+	quotient := make([]fr.Element, FieldElementsPerBlob)
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		if i == inDomain {
+			continue
+		}
+		quotient[i].Sub(&polynomial[i], &y).Mul(&quotient[i], &denominators[i])
+	}
+
+	// if z == ω_m, quotient_m = Σ_{i≠m} (p_i - y) * ω_i / (z * (z - ω_i))
+	// https://dankradfeist.de/ethereum/2021/06/18/pcs-multiproofs.html
+	if inDomain != -1 {
+		var t fr.Element
+		for i := 0; i < FieldElementsPerBlob; i++ {
+			if i == inDomain {
+				continue
+			}
+			// (p_i - y) / (ω_i - z) = - quotient_i, computed above
+			t.Mul(&quotient[i], &ctx.roots[i])
+			quotient[inDomain].Sub(&quotient[inDomain], &t)
+		}
+		var zInv fr.Element
+		zInv.Inverse(z)
+		quotient[inDomain].Mul(&quotient[inDomain], &zInv)
+	}
+
+	proof, err := ctx.commit(quotient)
+	return proof, y, err
+}
+
+// evaluate returns p(z), p being given in evaluation form, using the barycentric formula
+// p(z) = (z^n - 1) / n * Σ p_i * ω_i / (z - ω_i)
+func (ctx *BlobContext) evaluate(polynomial []fr.Element, z *fr.Element) fr.Element {
+
+	denominators := make([]fr.Element, FieldElementsPerBlob)
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		denominators[i].Sub(z, &ctx.roots[i])
+		if denominators[i].IsZero() {
+			return polynomial[i]
+		}
+	}
+	denominators = fr.BatchInvert(denominators)
+
+	var res, t fr.Element
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		t.Mul(&polynomial[i], &ctx.roots[i]).Mul(&t, &denominators[i])
+		res.Add(&res, &t)
+	}
+
+	var one fr.Element
+	one.SetOne()
+	t.Exp(*z, big.NewInt(FieldElementsPerBlob)).Sub(&t, &one)
+	res.Mul(&res, &t).Mul(&res, &ctx.invWidth)
+
+	return res
+}
+
+// verifyKZGProof checks e(commitment - [y]G1, G2) == e(proof, [alpha - z]G2)
+func (ctx *BlobContext) verifyKZGProof(commitment *bls12381.G1Affine, z, y *fr.Element, proof *bls12381.G1Affine) error {
+	srs := SRS{G1: []bls12381.G1Affine{g1Generator()}, G2: ctx.g2}
+	return Verify(commitment, &OpeningProof{H: *proof, Point: *z, ClaimedValue: *y}, &srs)
+}
+
+// verifyKZGProofBatch checks
+// e(Σ r^i proof_i, [alpha]G2) == e(Σ r^i (commitment_i - [y_i]G1 + z_i proof_i), G2)
+// where r is derived from all the inputs.
+func (ctx *BlobContext) verifyKZGProofBatch(commitments []Commitment, zs, ys []fr.Element, proofs []Proof) error {
+
+	n := len(commitments)
+
+	cs := make([]bls12381.G1Affine, n)
+	ps := make([]bls12381.G1Affine, n)
+	for i := 0; i < n; i++ {
+		var err error
+		if cs[i], err = bytesToG1(commitments[i][:]); err != nil {
+			return err
+		}
+		if ps[i], err = bytesToG1(proofs[i][:]); err != nil {
+			return err
+		}
+	}
+
+	// r = hash(domain || degree || n || (commitment_i || z_i || y_i || proof_i)_i)
+	data := make([]byte, 0, len(randomChallengeKZGBatchDomain)+16+n*(2*BytesPerCommitment+2*BytesPerFieldElement))
+	data = append(data, randomChallengeKZGBatchDomain...)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], FieldElementsPerBlob)
+	data = append(data, buf[:]...)
+	binary.BigEndian.PutUint64(buf[:], uint64(n))
+	data = append(data, buf[:]...)
+	for i := 0; i < n; i++ {
+		z := zs[i].Bytes()
+		y := ys[i].Bytes()
+		data = append(data, commitments[i][:]...)
+		data = append(data, z[:]...)
+		data = append(data, y[:]...)
+		data = append(data, proofs[i][:]...)
+	}
+	r := hashToBLSField(data)
+
+	rPowers := make([]fr.Element, n)
+	rPowers[0].SetOne()
+	for i := 1; i < n; i++ {
+		rPowers[i].Mul(&rPowers[i-1], &r)
+	}
+
+	// Σ r^i proof_i
+	var proofLincomb bls12381.G1Affine
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	if _, err := proofLincomb.MultiExp(ps, rPowers, config); err != nil {
+		return err
+	}
+
+	// Σ r^i (commitment_i + z_i proof_i) - [Σ r^i y_i]G1
+	points := make([]bls12381.G1Affine, 2*n)
+	scalars := make([]fr.Element, 2*n)
+	var sumY, t fr.Element
+	for i := 0; i < n; i++ {
+		points[i].Set(&cs[i])
+		scalars[i].Set(&rPowers[i])
+		points[n+i].Set(&ps[i])
+		scalars[n+i].Mul(&rPowers[i], &zs[i])
+		t.Mul(&rPowers[i], &ys[i])
+		sumY.Add(&sumY, &t)
+	}
+	var rhs bls12381.G1Affine
+	if _, err := rhs.MultiExp(points, scalars, config); err != nil {
+		return err
+	}
+	var sumYG1 bls12381.G1Affine
+	var bSumY big.Int
+	g1 := g1Generator()
+	sumYG1.ScalarMultiplication(&g1, sumY.ToBigIntRegular(&bSumY))
+	rhs.Sub(&rhs, &sumYG1)
+
+	proofLincomb.Neg(&proofLincomb)
+	check, err := bls12381.PairingCheck(
+		[]bls12381.G1Affine{proofLincomb, rhs},
+		[]bls12381.G2Affine{ctx.g2[1], ctx.g2[0]},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// computeChallenge returns the Fiat-Shamir challenge hash(domain || degree || blob || commitment)
+func computeChallenge(blob *Blob, commitment *Commitment) fr.Element {
+	data := make([]byte, 0, len(fiatShamirProtocolDomain)+16+BytesPerBlob+BytesPerCommitment)
+	data = append(data, fiatShamirProtocolDomain...)
+	var degree [16]byte
+	binary.BigEndian.PutUint64(degree[8:], FieldElementsPerBlob)
+	data = append(data, degree[:]...)
+	data = append(data, blob[:]...)
+	data = append(data, commitment[:]...)
+	return hashToBLSField(data)
+}
+
+// hashToBLSField returns sha256(data) mod r
+func hashToBLSField(data []byte) fr.Element {
+	h := sha256.Sum256(data)
+	var res fr.Element
+	res.SetBytes(h[:])
+	return res
+}
+
+// bytesToBLSField sets z from the big endian canonical encoding b
+func bytesToBLSField(z *fr.Element, b []byte) error {
+	var v big.Int
+	v.SetBytes(b)
+	if v.Cmp(fr.Modulus()) >= 0 {
+		return ErrInvalidFieldElement
+	}
+	z.SetBigInt(&v)
+	return nil
+}
+
+// blobToPolynomial deserializes the field elements of blob
+func blobToPolynomial(blob *Blob) ([]fr.Element, error) {
+	res := make([]fr.Element, FieldElementsPerBlob)
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		if err := bytesToBLSField(&res[i], blob[i*BytesPerFieldElement:(i+1)*BytesPerFieldElement]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// bytesToG1 decodes a compressed G1 point, checking it is in the subgroup
+// and canonically encoded
+func bytesToG1(b []byte) (bls12381.G1Affine, error) {
+	var p bls12381.G1Affine
+	if _, err := p.SetBytes(b); err != nil {
+		return p, err
+	}
+	if c := p.Bytes(); string(c[:]) != string(b) {
+		return p, ErrInvalidPoint
+	}
+	return p, nil
+}
+
+// g1Generator returns the generator of G1
+func g1Generator() bls12381.G1Affine {
+	_, _, g1, _ := bls12381.Generators()
+	return g1
+}
+
+// bitReverseG1 applies the bit-reversal permutation to a, len(a) must be a power of 2
+func bitReverseG1(a []bls12381.G1Affine) {
+	n := uint64(len(a))
+	nn := uint64(64 - bits.TrailingZeros64(n))
+
+	for i := uint64(0); i < n; i++ {
+		irev := bits.Reverse64(i) >> nn
+		if irev > i {
+			a[i], a[irev] = a[irev], a[i]
+		}
+	}
+}
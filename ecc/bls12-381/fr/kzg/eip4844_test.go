@@ -0,0 +1,538 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// testBlobAlpha secret of the test setup
+const testBlobAlpha = 42
+
+// testBlobSetup Lagrange basis (natural order) and G2 points of a setup with known secret
+var testBlobSetup struct {
+	g1Lagrange []bls12381.G1Affine
+	g2         []bls12381.G2Affine
+}
+
+// testBlobContext re-used accross tests of the blob API
+var testBlobContext *BlobContext
+
+func init() {
+	// [L_i(alpha)]G1 where L_i(alpha) = ω^i/n * (alpha^n - 1)/(alpha - ω^i)
+	var alpha, root, one, alphaN fr.Element
+	alpha.SetUint64(testBlobAlpha)
+	one.SetOne()
+	exponent := new(big.Int).Sub(fr.Modulus(), big.NewInt(1))
+	exponent.Div(exponent, big.NewInt(FieldElementsPerBlob))
+	root.SetUint64(primitiveRootOfUnity).Exp(root, exponent)
+	alphaN.Exp(alpha, big.NewInt(FieldElementsPerBlob)).Sub(&alphaN, &one)
+
+	var invN fr.Element
+	invN.SetUint64(FieldElementsPerBlob).Inverse(&invN)
+	alphaN.Mul(&alphaN, &invN)
+
+	roots := make([]fr.Element, FieldElementsPerBlob)
+	denominators := make([]fr.Element, FieldElementsPerBlob)
+	roots[0].SetOne()
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		if i > 0 {
+			roots[i].Mul(&roots[i-1], &root)
+		}
+		denominators[i].Sub(&alpha, &roots[i])
+	}
+	denominators = fr.BatchInvert(denominators)
+	scalars := make([]fr.Element, FieldElementsPerBlob)
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		scalars[i].Mul(&roots[i], &denominators[i]).Mul(&scalars[i], &alphaN).FromMont()
+	}
+
+	_, _, g1, g2 := bls12381.Generators()
+	testBlobSetup.g1Lagrange = bls12381.BatchScalarMultiplicationG1(&g1, scalars)
+	testBlobSetup.g2 = make([]bls12381.G2Affine, 2)
+	testBlobSetup.g2[0] = g2
+	testBlobSetup.g2[1].ScalarMultiplication(&g2, big.NewInt(testBlobAlpha))
+
+	testBlobContext, _ = NewBlobContext(testBlobSetup.g1Lagrange, testBlobSetup.g2)
+}
+
+// randomBlob returns a blob of random field elements
+func randomBlob() Blob {
+	var blob Blob
+	var e fr.Element
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		e.SetRandom()
+		b := e.Bytes()
+		copy(blob[i*BytesPerFieldElement:], b[:])
+	}
+	return blob
+}
+
+func TestBlobToKZGCommitment(t *testing.T) {
+
+	blob := randomBlob()
+	commitment, err := testBlobContext.BlobToKZGCommitment(&blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the commitment is [p(alpha)]G1
+	polynomial, err := blobToPolynomial(&blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var alpha fr.Element
+	alpha.SetUint64(testBlobAlpha)
+	pAlpha := testBlobContext.evaluate(polynomial, &alpha)
+	var expected bls12381.G1Affine
+	var bpAlpha big.Int
+	g1 := g1Generator()
+	expected.ScalarMultiplication(&g1, pAlpha.ToBigIntRegular(&bpAlpha))
+	if expected.Bytes() != commitment {
+		t.Fatal("commitment should be [p(alpha)]G1")
+	}
+
+	// non canonical field elements are rejected
+	for i := 0; i < BytesPerFieldElement; i++ {
+		blob[i] = 0xff
+	}
+	if _, err := testBlobContext.BlobToKZGCommitment(&blob); err != ErrInvalidFieldElement {
+		t.Fatal("non canonical field element should be rejected")
+	}
+}
+
+func TestComputeKZGProof(t *testing.T) {
+
+	blob := randomBlob()
+	commitment, err := testBlobContext.BlobToKZGCommitment(&blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// z outside the domain
+	var _z fr.Element
+	_z.SetRandom()
+	z := Bytes32(_z.Bytes())
+	proof, y, err := testBlobContext.ComputeKZGProof(&blob, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testBlobContext.VerifyKZGProof(commitment, z, y, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	// wrong evaluation
+	var _y fr.Element
+	if err := bytesToBLSField(&_y, y[:]); err != nil {
+		t.Fatal(err)
+	}
+	_y.Double(&_y)
+	if err := testBlobContext.VerifyKZGProof(commitment, z, Bytes32(_y.Bytes()), proof); err == nil {
+		t.Fatal("verifying a wrong evaluation should fail")
+	}
+
+	// z in the domain, the evaluation is an element of the blob
+	z = Bytes32(testBlobContext.roots[5].Bytes())
+	proof, y, err = testBlobContext.ComputeKZGProof(&blob, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(y[:], blob[5*BytesPerFieldElement:6*BytesPerFieldElement]) {
+		t.Fatal("evaluation in the domain should be the blob element")
+	}
+	if err := testBlobContext.VerifyKZGProof(commitment, z, y, proof); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBlobKZGProof(t *testing.T) {
+
+	const nbBlobs = 3
+	blobs := make([]Blob, nbBlobs)
+	commitments := make([]Commitment, nbBlobs)
+	proofs := make([]Proof, nbBlobs)
+	for i := 0; i < nbBlobs; i++ {
+		var err error
+		blobs[i] = randomBlob()
+		commitments[i], err = testBlobContext.BlobToKZGCommitment(&blobs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		proofs[i], err = testBlobContext.ComputeBlobKZGProof(&blobs[i], commitments[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := testBlobContext.VerifyBlobKZGProof(&blobs[i], commitments[i], proofs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := testBlobContext.VerifyBlobKZGProofBatch(blobs, commitments, proofs); err != nil {
+		t.Fatal(err)
+	}
+
+	// swap two proofs
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	if err := testBlobContext.VerifyBlobKZGProof(&blobs[0], commitments[0], proofs[0]); err == nil {
+		t.Fatal("verifying a wrong proof should fail")
+	}
+	if err := testBlobContext.VerifyBlobKZGProofBatch(blobs, commitments, proofs); err == nil {
+		t.Fatal("batch verifying a wrong proof should fail")
+	}
+
+	if err := testBlobContext.VerifyBlobKZGProofBatch(blobs, commitments[1:], proofs); err != ErrInvalidNbBlobs {
+		t.Fatal("inconsistent number of blobs should be rejected")
+	}
+}
+
+func TestLoadTrustedSetup(t *testing.T) {
+
+	g1 := make([]string, FieldElementsPerBlob)
+	for i := 0; i < len(g1); i++ {
+		b := testBlobSetup.g1Lagrange[i].Bytes()
+		g1[i] = hex.EncodeToString(b[:])
+	}
+	g2 := make([]string, len(testBlobSetup.g2))
+	for i := 0; i < len(g2); i++ {
+		b := testBlobSetup.g2[i].Bytes()
+		g2[i] = hex.EncodeToString(b[:])
+	}
+
+	blob := randomBlob()
+	expected, err := testBlobContext.BlobToKZGCommitment(&blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(ctx *BlobContext, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitment, err := ctx.BlobToKZGCommitment(&blob)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if commitment != expected {
+			t.Fatal("loaded setup should match the original one")
+		}
+	}
+
+	// text format
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%d\n%d\n", len(g1), len(g2))
+	for _, s := range append(g1, g2...) {
+		fmt.Fprintln(&buf, s)
+	}
+	check(LoadTrustedSetup(&buf))
+
+	// text format followed by the G1 monomial basis, which is ignored
+	buf.Reset()
+	fmt.Fprintf(&buf, "%d\n%d\n", len(g1), len(g2))
+	for _, s := range append(g1, g2...) {
+		fmt.Fprintln(&buf, s)
+	}
+	for i := len(g1) - 1; i >= 0; i-- {
+		fmt.Fprintln(&buf, g1[i])
+	}
+	check(LoadTrustedSetup(&buf))
+
+	// json format
+	buf.Reset()
+	for i := range g1 {
+		g1[i] = "0x" + g1[i]
+	}
+	for i := range g2 {
+		g2[i] = "0x" + g2[i]
+	}
+	if err := json.NewEncoder(&buf).Encode(map[string][]string{"g1_lagrange": g1, "g2_monomial": g2}); err != nil {
+		t.Fatal(err)
+	}
+	check(LoadTrustedSetupJSON(&buf))
+
+	// truncated setup
+	if _, err := LoadTrustedSetup(bytes.NewBufferString("4096\n65\n")); err != ErrInvalidTrustedSetup {
+		t.Fatal("truncated setup should be rejected")
+	}
+}
+
+// eip4844Vectors official c-kzg-4844 test vectors (v1.0.0), the blobs being stored once and
+// referenced by their index. The cases with blobs of invalid length, which can't be represented
+// as a Blob, are left out.
+type eip4844Vectors struct {
+	Blobs               []string `json:"blobs"`
+	BlobToKZGCommitment []struct {
+		Name  string
+		Input struct {
+			Blob int
+		}
+		Output *string
+	} `json:"blob_to_kzg_commitment"`
+	ComputeKZGProof []struct {
+		Name  string
+		Input struct {
+			Blob int
+			Z    string
+		}
+		Output *[2]string
+	} `json:"compute_kzg_proof"`
+	VerifyKZGProof []struct {
+		Name  string
+		Input struct {
+			Commitment, Z, Y, Proof string
+		}
+		Output *bool
+	} `json:"verify_kzg_proof"`
+	ComputeBlobKZGProof []struct {
+		Name  string
+		Input struct {
+			Blob       int
+			Commitment string
+		}
+		Output *string
+	} `json:"compute_blob_kzg_proof"`
+	VerifyBlobKZGProof []struct {
+		Name  string
+		Input struct {
+			Blob              int
+			Commitment, Proof string
+		}
+		Output *bool
+	} `json:"verify_blob_kzg_proof"`
+	VerifyBlobKZGProofBatch []struct {
+		Name  string
+		Input struct {
+			Blobs               []int
+			Commitments, Proofs []string
+		}
+		Output *bool
+	} `json:"verify_blob_kzg_proof_batch"`
+}
+
+// readGzipTestData returns the content of the gzip compressed file testdata/name
+func readGzipTestData(t *testing.T, name string) *gzip.Reader {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// decodeHexFixed decodes the 0x prefixed hex string s into dst, and returns false
+// if s is not a valid encoding of len(dst) bytes
+func decodeHexFixed(dst []byte, s string) bool {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(b) != len(dst) {
+		return false
+	}
+	copy(dst, b)
+	return true
+}
+
+func TestEIP4844Vectors(t *testing.T) {
+	ctx, err := LoadTrustedSetup(readGzipTestData(t, "trusted_setup.txt.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vectors eip4844Vectors
+	if err := json.NewDecoder(readGzipTestData(t, "eip4844_vectors.json.gz")).Decode(&vectors); err != nil {
+		t.Fatal(err)
+	}
+	blobs := make([]Blob, len(vectors.Blobs))
+	for i := range blobs {
+		if !decodeHexFixed(blobs[i][:], vectors.Blobs[i]) {
+			t.Fatal("invalid blob in the test vectors")
+		}
+	}
+
+	for _, v := range vectors.BlobToKZGCommitment {
+		commitment, err := ctx.BlobToKZGCommitment(&blobs[v.Input.Blob])
+		if v.Output == nil {
+			if err == nil {
+				t.Fatalf("%s: invalid input should be rejected", v.Name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", v.Name, err)
+		}
+		if "0x"+hex.EncodeToString(commitment[:]) != *v.Output {
+			t.Fatalf("%s: wrong commitment", v.Name)
+		}
+	}
+
+	for _, v := range vectors.ComputeKZGProof {
+		var z Bytes32
+		ok := decodeHexFixed(z[:], v.Input.Z)
+		var proof Proof
+		var y Bytes32
+		if ok {
+			proof, y, err = ctx.ComputeKZGProof(&blobs[v.Input.Blob], z)
+		}
+		if v.Output == nil {
+			if ok && err == nil {
+				t.Fatalf("%s: invalid input should be rejected", v.Name)
+			}
+			continue
+		}
+		if !ok || err != nil {
+			t.Fatalf("%s: valid input rejected: %v", v.Name, err)
+		}
+		if "0x"+hex.EncodeToString(proof[:]) != v.Output[0] || "0x"+hex.EncodeToString(y[:]) != v.Output[1] {
+			t.Fatalf("%s: wrong proof or evaluation", v.Name)
+		}
+	}
+
+	for _, v := range vectors.VerifyKZGProof {
+		var commitment Commitment
+		var z, y Bytes32
+		var proof Proof
+		ok := decodeHexFixed(commitment[:], v.Input.Commitment) &&
+			decodeHexFixed(z[:], v.Input.Z) &&
+			decodeHexFixed(y[:], v.Input.Y) &&
+			decodeHexFixed(proof[:], v.Input.Proof)
+		if ok {
+			err = ctx.VerifyKZGProof(commitment, z, y, proof)
+		}
+		switch {
+		case v.Output == nil:
+			if ok && (err == nil || err == ErrVerifyOpeningProof) {
+				t.Fatalf("%s: invalid input should be rejected", v.Name)
+			}
+		case *v.Output:
+			if !ok || err != nil {
+				t.Fatalf("%s: valid proof rejected: %v", v.Name, err)
+			}
+		default:
+			if !ok || err != ErrVerifyOpeningProof {
+				t.Fatalf("%s: wrong proof should fail to verify: %v", v.Name, err)
+			}
+		}
+	}
+
+	for _, v := range vectors.ComputeBlobKZGProof {
+		var commitment Commitment
+		ok := decodeHexFixed(commitment[:], v.Input.Commitment)
+		var proof Proof
+		if ok {
+			proof, err = ctx.ComputeBlobKZGProof(&blobs[v.Input.Blob], commitment)
+		}
+		if v.Output == nil {
+			if ok && err == nil {
+				t.Fatalf("%s: invalid input should be rejected", v.Name)
+			}
+			continue
+		}
+		if !ok || err != nil {
+			t.Fatalf("%s: valid input rejected: %v", v.Name, err)
+		}
+		if "0x"+hex.EncodeToString(proof[:]) != *v.Output {
+			t.Fatalf("%s: wrong proof", v.Name)
+		}
+	}
+
+	for _, v := range vectors.VerifyBlobKZGProof {
+		var commitment Commitment
+		var proof Proof
+		ok := decodeHexFixed(commitment[:], v.Input.Commitment) &&
+			decodeHexFixed(proof[:], v.Input.Proof)
+		if ok {
+			err = ctx.VerifyBlobKZGProof(&blobs[v.Input.Blob], commitment, proof)
+		}
+		switch {
+		case v.Output == nil:
+			if ok && (err == nil || err == ErrVerifyOpeningProof) {
+				t.Fatalf("%s: invalid input should be rejected", v.Name)
+			}
+		case *v.Output:
+			if !ok || err != nil {
+				t.Fatalf("%s: valid proof rejected: %v", v.Name, err)
+			}
+		default:
+			if !ok || err != ErrVerifyOpeningProof {
+				t.Fatalf("%s: wrong proof should fail to verify: %v", v.Name, err)
+			}
+		}
+	}
+
+	for _, v := range vectors.VerifyBlobKZGProofBatch {
+		batchBlobs := make([]Blob, len(v.Input.Blobs))
+		for i, b := range v.Input.Blobs {
+			batchBlobs[i] = blobs[b]
+		}
+		ok := true
+		commitments := make([]Commitment, len(v.Input.Commitments))
+		for i := range commitments {
+			ok = ok && decodeHexFixed(commitments[i][:], v.Input.Commitments[i])
+		}
+		proofs := make([]Proof, len(v.Input.Proofs))
+		for i := range proofs {
+			ok = ok && decodeHexFixed(proofs[i][:], v.Input.Proofs[i])
+		}
+		if ok {
+			err = ctx.VerifyBlobKZGProofBatch(batchBlobs, commitments, proofs)
+		}
+		switch {
+		case v.Output == nil:
+			if ok && (err == nil || err == ErrVerifyOpeningProof) {
+				t.Fatalf("%s: invalid input should be rejected", v.Name)
+			}
+		case *v.Output:
+			if !ok || err != nil {
+				t.Fatalf("%s: valid proofs rejected: %v", v.Name, err)
+			}
+		default:
+			if !ok || err != ErrVerifyOpeningProof {
+				t.Fatalf("%s: wrong proofs should fail to verify: %v", v.Name, err)
+			}
+		}
+	}
+}
+
+func BenchmarkBlobToKZGCommitment(b *testing.B) {
+	blob := randomBlob()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		testBlobContext.BlobToKZGCommitment(&blob)
+	}
+}
+
+func BenchmarkVerifyBlobKZGProof(b *testing.B) {
+	blob := randomBlob()
+	commitment, _ := testBlobContext.BlobToKZGCommitment(&blob)
+	proof, _ := testBlobContext.ComputeBlobKZGProof(&blob, commitment)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		testBlobContext.VerifyBlobKZGProof(&blob, commitment, proof)
+	}
+}
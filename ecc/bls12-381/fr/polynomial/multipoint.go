@@ -0,0 +1,165 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInterpolationSize            = errors.New("interpolation: there must be as many values as points")
+	ErrInterpolationDuplicatePoints = errors.New("interpolation: the points must be distinct")
+)
+
+// multiPointThreshold is the number of points below which the evaluations are computed with Horner's rule
+const multiPointThreshold = 32
+
+// subproductTree is the binary tree of the products of the (X - x[i]):
+// tree[0][i] = X - x[i], and tree[k+1][i] = tree[k][2i]*tree[k][2i+1], or tree[k][2i] if it has no sibling.
+// The node tree[k][i] is the product over the points x[i*2^k:(i+1)*2^k].
+type subproductTree [][]Polynomial
+
+// EvalMultiPoints evaluates p at the points x
+// For more than multiPointThreshold points, p is reduced modulo the nodes of the subproduct
+// tree of x, which costs O(M(n)log(n)) instead of O(n*deg(p)) with Horner's rule.
+// The computations run on pool.Default().
+func (p *Polynomial) EvalMultiPoints(x []fr.Element) []fr.Element {
+	res := make([]fr.Element, len(x))
+	if len(x) <= multiPointThreshold {
+		for i := 0; i < len(x); i++ {
+			res[i] = p.Eval(&x[i])
+		}
+		return res
+	}
+
+	tree := newSubproductTree(x)
+	tree.eval(*p, len(tree)-1, 0, x, res)
+	return res
+}
+
+// Interpolate returns the polynomial of degree < len(x) such that p(x[i]) = y[i], in O(M(n)log(n)).
+// With m the product of the (X - x[i]), p = Σ y[i]/m'(x[i]) * m/(X - x[i]), which is computed
+// bottom-up in the subproduct tree of x.
+// The computations run on pool.Default().
+func Interpolate(x, y []fr.Element) (Polynomial, error) {
+	if len(x) != len(y) {
+		return nil, ErrInterpolationSize
+	}
+	if len(x) == 0 {
+		return make(Polynomial, 1), nil
+	}
+
+	tree := newSubproductTree(x)
+
+	// weights y[i]/m'(x[i]), m'(x[i]) = ∏_{j≠i} (x[i]-x[j]) being zero iff x[i] is a duplicate
+	var dm Polynomial
+	dm.Derivative(tree[len(tree)-1][0])
+	weights := make([]fr.Element, len(x))
+	if len(x) <= multiPointThreshold {
+		for i := 0; i < len(x); i++ {
+			weights[i] = dm.Eval(&x[i])
+		}
+	} else {
+		tree.eval(dm, len(tree)-1, 0, x, weights)
+	}
+	for i := 0; i < len(weights); i++ {
+		if weights[i].IsZero() {
+			return nil, ErrInterpolationDuplicatePoints
+		}
+	}
+	weights = fr.BatchInvert(weights)
+
+	level := make([]Polynomial, len(x))
+	for i := 0; i < len(x); i++ {
+		level[i] = Polynomial{weights[i]}
+		level[i][0].Mul(&level[i][0], &y[i])
+	}
+
+	// the polynomial of a node is left*m_right + right*m_left
+	for k := 0; k < len(tree)-1; k++ {
+		next := make([]Polynomial, len(tree[k+1]))
+		nodes := tree[k]
+		parallel.Execute(len(next), func(start, end int) {
+			for i := start; i < end; i++ {
+				if 2*i+1 == len(level) {
+					next[i] = level[2*i]
+					continue
+				}
+				left := mul(level[2*i], nodes[2*i+1])
+				right := mul(level[2*i+1], nodes[2*i])
+				next[i].Add(left, right)
+			}
+		})
+		level = next
+	}
+
+	return level[0], nil
+}
+
+// newSubproductTree returns the subproduct tree of the points x, with len(x) > 0
+func newSubproductTree(x []fr.Element) subproductTree {
+	level := make([]Polynomial, len(x))
+	for i := 0; i < len(x); i++ {
+		level[i] = make(Polynomial, 2)
+		level[i][0].Neg(&x[i])
+		level[i][1].SetOne()
+	}
+
+	tree := subproductTree{level}
+	for len(level) > 1 {
+		next := make([]Polynomial, (len(level)+1)/2)
+		prev := level
+		parallel.Execute(len(next), func(start, end int) {
+			for i := start; i < end; i++ {
+				if 2*i+1 == len(prev) {
+					next[i] = prev[2*i]
+					continue
+				}
+				next[i] = mul(prev[2*i], prev[2*i+1])
+			}
+		})
+		tree = append(tree, next)
+		level = next
+	}
+
+	return tree
+}
+
+// eval sets res[j] to p(x[j]) for the points x[j] of the node tree[k][i]
+func (tree subproductTree) eval(p Polynomial, k, i int, x, res []fr.Element) {
+	start := i << k
+	end := start + 1<<k
+	if end > len(x) {
+		end = len(x)
+	}
+
+	_, r := DivMod(p, tree[k][i])
+	if end-start <= multiPointThreshold {
+		for j := start; j < end; j++ {
+			res[j] = r.Eval(&x[j])
+		}
+		return
+	}
+
+	tree.eval(r, k-1, 2*i, x, res)
+	if 2*i+1 < len(tree[k-1]) {
+		tree.eval(r, k-1, 2*i+1, x, res)
+	}
+}
@@ -18,8 +18,12 @@ package polynomial
 
 import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
 )
 
+// mulFFTThreshold is the number of coefficients of the smallest operand above which Mul uses FFTs
+const mulFFTThreshold = 64
+
 // Polynomial polynomial represented by coefficients bn254 fr field.
 type Polynomial []fr.Element
 
@@ -103,6 +107,84 @@ func (p *Polynomial) Add(p1, p2 Polynomial) *Polynomial {
 	return p
 }
 
+// Sub subs p2 from p1
+// This function allocates a new slice unless p == p1 and len(p1) >= len(p2)
+func (p *Polynomial) Sub(p1, p2 Polynomial) *Polynomial {
+
+	if len(p1) >= len(p2) && len(*p) == len(p1) && len(p1) != 0 && (&(*p)[0] == &p1[0]) {
+		for i := 0; i < len(p2); i++ {
+			(*p)[i].Sub(&(*p)[i], &p2[i])
+		}
+		return p
+	}
+
+	n := len(p1)
+	if len(p2) > n {
+		n = len(p2)
+	}
+	res := make(Polynomial, n)
+	copy(res, p1)
+	for i := 0; i < len(p2); i++ {
+		res[i].Sub(&res[i], &p2[i])
+	}
+	*p = res
+	return p
+}
+
+// Mul multiplies p1 by p2
+// When both operands have more than mulFFTThreshold coefficients, the product is computed
+// with FFTs on pool.Default(), and its number of coefficients must be at most the size of
+// the largest 2-adic subgroup of fr.
+// This function always allocates a new slice
+func (p *Polynomial) Mul(p1, p2 Polynomial) *Polynomial {
+	*p = mul(p1, p2)
+	return p
+}
+
+// Derivative sets p to the derivative of p1
+// This function allocates a new slice unless p == p1
+func (p *Polynomial) Derivative(p1 Polynomial) *Polynomial {
+	if len(p1) <= 1 {
+		*p = make(Polynomial, 1)
+		return p
+	}
+
+	res := *p
+	if !(len(*p) == len(p1) && (&(*p)[0] == &p1[0])) {
+		res = make(Polynomial, len(p1))
+	}
+
+	// res[i-1] is written after p1[i-1] has been read, so p1 can be res
+	var k fr.Element
+	for i := 1; i < len(p1); i++ {
+		k.SetUint64(uint64(i))
+		res[i-1].Mul(&p1[i], &k)
+	}
+	*p = res[:len(p1)-1]
+	return p
+}
+
+// Compose sets p to p1(p2(X)), using Horner's rule
+// This function always allocates a new slice
+func (p *Polynomial) Compose(p1, p2 Polynomial) *Polynomial {
+	if len(p1) == 0 {
+		*p = Polynomial{}
+		return p
+	}
+	if len(p2) == 0 {
+		*p = Polynomial{p1[0]}
+		return p
+	}
+
+	res := Polynomial{p1[len(p1)-1]}
+	for i := len(p1) - 2; i >= 0; i-- {
+		res = mul(res, p2)
+		res[0].Add(&res[0], &p1[i])
+	}
+	*p = res
+	return p
+}
+
 // Equal checks equality between two polynomials
 func (p *Polynomial) Equal(p1 Polynomial) bool {
 	if (*p == nil) != (p1 == nil) {
@@ -121,3 +203,39 @@ func (p *Polynomial) Equal(p1 Polynomial) bool {
 
 	return true
 }
+
+// mul returns p1*p2, with the schoolbook method for small operands and with FFTs otherwise
+func mul(p1, p2 Polynomial) Polynomial {
+	if len(p1) == 0 || len(p2) == 0 {
+		return Polynomial{}
+	}
+	n := len(p1) + len(p2) - 1
+
+	if len(p1) <= mulFFTThreshold || len(p2) <= mulFFTThreshold {
+		res := make(Polynomial, n)
+		var tmp fr.Element
+		for i := 0; i < len(p1); i++ {
+			for j := 0; j < len(p2); j++ {
+				tmp.Mul(&p1[i], &p2[j])
+				res[i+j].Add(&res[i+j], &tmp)
+			}
+		}
+		return res
+	}
+
+	domain := fft.NewDomain(uint64(n), 0, false)
+	a := make(Polynomial, domain.Cardinality)
+	b := make(Polynomial, domain.Cardinality)
+	copy(a, p1)
+	copy(b, p2)
+
+	// the evaluations are in bit reversed order, which doesn't matter for the pointwise product
+	domain.FFT(a, fft.DIF, 0)
+	domain.FFT(b, fft.DIF, 0)
+	for i := 0; i < len(a); i++ {
+		a[i].Mul(&a[i], &b[i])
+	}
+	domain.FFTInverse(a, fft.DIT, 0)
+
+	return a[:n]
+}
@@ -0,0 +1,24 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package poseidon provides the Poseidon permutation and hash function over the scalar field of bls12-381.
+//
+// The round constants and the MDS matrix are generated with the Grain LFSR of the reference implementation
+// https://extgit.iaik.tugraz.at/krypto/hadeshash, and the default number of rounds provides 128 bits of security.
+// On bn254, the parameters are the ones of circomlib.
+//
+// See https://eprint.iacr.org/2019/458.pdf
+package poseidon
@@ -0,0 +1,318 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func init() {
+	ecc.RegisterCurve(ID, g1Group{}, g2Group{}, genericPairing{})
+}
+
+// g1Group implements ecc.Group for G1
+type g1Group struct{}
+
+// GenericG1Affine is a G1Affine implementing ecc.Point.
+// Pointers to G1Affine and GenericG1Affine convert to each other.
+type GenericG1Affine G1Affine
+
+// toG1 returns the G1Affine of a, and panics if a is not a point of G1
+func toG1(a ecc.Point) *G1Affine {
+	return (*G1Affine)(a.(*GenericG1Affine))
+}
+
+// Curve returns the ID of the curve
+func (g1Group) Curve() ecc.ID {
+	return ID
+}
+
+// Order returns the order of G1
+func (g1Group) Order() *big.Int {
+	return fr.Modulus()
+}
+
+// NewPoint returns a new point set to the identity
+func (g1Group) NewPoint() ecc.Point {
+	return new(GenericG1Affine)
+}
+
+// Generator returns a new point set to the generator of G1
+func (g1Group) Generator() ecc.Point {
+	_, _, gen, _ := Generators()
+	return (*GenericG1Affine)(&gen)
+}
+
+// MultiExp returns Σ scalars[i]*points[i]
+func (g1Group) MultiExp(points []ecc.Point, scalars []*big.Int, config ecc.MultiExpConfig) (ecc.Point, error) {
+	_points := make([]G1Affine, len(points))
+	for i := range points {
+		_points[i] = *toG1(points[i])
+	}
+	_scalars := make([]fr.Element, len(scalars))
+	for i := range scalars {
+		_scalars[i].SetBigInt(scalars[i])
+	}
+	config.ScalarsMont = true
+
+	var res G1Affine
+	if _, err := res.MultiExp(_points, _scalars, config); err != nil {
+		return nil, err
+	}
+	return (*GenericG1Affine)(&res), nil
+}
+
+// Set sets p to a and returns p
+func (p *GenericG1Affine) Set(a ecc.Point) ecc.Point {
+	*p = *a.(*GenericG1Affine)
+	return p
+}
+
+// Add sets p to a+b and returns p
+func (p *GenericG1Affine) Add(a, b ecc.Point) ecc.Point {
+	var _p G1Jac
+	_p.FromAffine(toG1(a))
+	_p.AddMixed(toG1(b))
+	(*G1Affine)(p).FromJacobian(&_p)
+	return p
+}
+
+// Sub sets p to a-b and returns p
+func (p *GenericG1Affine) Sub(a, b ecc.Point) ecc.Point {
+	var _p G1Jac
+	var bNeg G1Affine
+	bNeg.Neg(toG1(b))
+	_p.FromAffine(toG1(a))
+	_p.AddMixed(&bNeg)
+	(*G1Affine)(p).FromJacobian(&_p)
+	return p
+}
+
+// Neg sets p to -a and returns p
+func (p *GenericG1Affine) Neg(a ecc.Point) ecc.Point {
+	(*G1Affine)(p).Neg(toG1(a))
+	return p
+}
+
+// ScalarMultiplication sets p to s*a and returns p
+func (p *GenericG1Affine) ScalarMultiplication(a ecc.Point, s *big.Int) ecc.Point {
+	var k big.Int
+	k.Mod(s, fr.Modulus())
+	var _p G1Jac
+	_p.FromAffine(toG1(a))
+	_p.ScalarMultiplication(&_p, &k)
+	(*G1Affine)(p).FromJacobian(&_p)
+	return p
+}
+
+// Equal returns true if p and a are the same point
+func (p *GenericG1Affine) Equal(a ecc.Point) bool {
+	return (*G1Affine)(p).Equal(toG1(a))
+}
+
+// IsInfinity returns true if p is the identity
+func (p *GenericG1Affine) IsInfinity() bool {
+	return (*G1Affine)(p).IsInfinity()
+}
+
+// IsOnCurve returns true if p is on the curve
+func (p *GenericG1Affine) IsOnCurve() bool {
+	return (*G1Affine)(p).IsOnCurve()
+}
+
+// IsInSubGroup returns true if p is on the curve and in G1
+func (p *GenericG1Affine) IsInSubGroup() bool {
+	return (*G1Affine)(p).IsInSubGroup()
+}
+
+// Bytes returns the compressed encoding of p
+func (p *GenericG1Affine) Bytes() []byte {
+	res := (*G1Affine)(p).Bytes()
+	return res[:]
+}
+
+// RawBytes returns the uncompressed encoding of p
+func (p *GenericG1Affine) RawBytes() []byte {
+	res := (*G1Affine)(p).RawBytes()
+	return res[:]
+}
+
+// SetBytes sets p from a compressed or uncompressed encoding and returns the number of bytes read
+func (p *GenericG1Affine) SetBytes(buf []byte) (int, error) {
+	return (*G1Affine)(p).SetBytes(buf)
+}
+
+// String returns the string representation of p
+func (p *GenericG1Affine) String() string {
+	return (*G1Affine)(p).String()
+}
+
+// g2Group implements ecc.Group for G2
+type g2Group struct{}
+
+// GenericG2Affine is a G2Affine implementing ecc.Point.
+// Pointers to G2Affine and GenericG2Affine convert to each other.
+type GenericG2Affine G2Affine
+
+// toG2 returns the G2Affine of a, and panics if a is not a point of G2
+func toG2(a ecc.Point) *G2Affine {
+	return (*G2Affine)(a.(*GenericG2Affine))
+}
+
+// Curve returns the ID of the curve
+func (g2Group) Curve() ecc.ID {
+	return ID
+}
+
+// Order returns the order of G2
+func (g2Group) Order() *big.Int {
+	return fr.Modulus()
+}
+
+// NewPoint returns a new point set to the identity
+func (g2Group) NewPoint() ecc.Point {
+	return new(GenericG2Affine)
+}
+
+// Generator returns a new point set to the generator of G2
+func (g2Group) Generator() ecc.Point {
+	_, _, _, gen := Generators()
+	return (*GenericG2Affine)(&gen)
+}
+
+// MultiExp returns Σ scalars[i]*points[i]
+func (g2Group) MultiExp(points []ecc.Point, scalars []*big.Int, config ecc.MultiExpConfig) (ecc.Point, error) {
+	_points := make([]G2Affine, len(points))
+	for i := range points {
+		_points[i] = *toG2(points[i])
+	}
+	_scalars := make([]fr.Element, len(scalars))
+	for i := range scalars {
+		_scalars[i].SetBigInt(scalars[i])
+	}
+	config.ScalarsMont = true
+
+	var res G2Affine
+	if _, err := res.MultiExp(_points, _scalars, config); err != nil {
+		return nil, err
+	}
+	return (*GenericG2Affine)(&res), nil
+}
+
+// Set sets p to a and returns p
+func (p *GenericG2Affine) Set(a ecc.Point) ecc.Point {
+	*p = *a.(*GenericG2Affine)
+	return p
+}
+
+// Add sets p to a+b and returns p
+func (p *GenericG2Affine) Add(a, b ecc.Point) ecc.Point {
+	var _p G2Jac
+	_p.FromAffine(toG2(a))
+	_p.AddMixed(toG2(b))
+	(*G2Affine)(p).FromJacobian(&_p)
+	return p
+}
+
+// Sub sets p to a-b and returns p
+func (p *GenericG2Affine) Sub(a, b ecc.Point) ecc.Point {
+	var _p G2Jac
+	var bNeg G2Affine
+	bNeg.Neg(toG2(b))
+	_p.FromAffine(toG2(a))
+	_p.AddMixed(&bNeg)
+	(*G2Affine)(p).FromJacobian(&_p)
+	return p
+}
+
+// Neg sets p to -a and returns p
+func (p *GenericG2Affine) Neg(a ecc.Point) ecc.Point {
+	(*G2Affine)(p).Neg(toG2(a))
+	return p
+}
+
+// ScalarMultiplication sets p to s*a and returns p
+func (p *GenericG2Affine) ScalarMultiplication(a ecc.Point, s *big.Int) ecc.Point {
+	var k big.Int
+	k.Mod(s, fr.Modulus())
+	var _p G2Jac
+	_p.FromAffine(toG2(a))
+	_p.ScalarMultiplication(&_p, &k)
+	(*G2Affine)(p).FromJacobian(&_p)
+	return p
+}
+
+// Equal returns true if p and a are the same point
+func (p *GenericG2Affine) Equal(a ecc.Point) bool {
+	return (*G2Affine)(p).Equal(toG2(a))
+}
+
+// IsInfinity returns true if p is the identity
+func (p *GenericG2Affine) IsInfinity() bool {
+	return (*G2Affine)(p).IsInfinity()
+}
+
+// IsOnCurve returns true if p is on the curve
+func (p *GenericG2Affine) IsOnCurve() bool {
+	return (*G2Affine)(p).IsOnCurve()
+}
+
+// IsInSubGroup returns true if p is on the curve and in G2
+func (p *GenericG2Affine) IsInSubGroup() bool {
+	return (*G2Affine)(p).IsInSubGroup()
+}
+
+// Bytes returns the compressed encoding of p
+func (p *GenericG2Affine) Bytes() []byte {
+	res := (*G2Affine)(p).Bytes()
+	return res[:]
+}
+
+// RawBytes returns the uncompressed encoding of p
+func (p *GenericG2Affine) RawBytes() []byte {
+	res := (*G2Affine)(p).RawBytes()
+	return res[:]
+}
+
+// SetBytes sets p from a compressed or uncompressed encoding and returns the number of bytes read
+func (p *GenericG2Affine) SetBytes(buf []byte) (int, error) {
+	return (*G2Affine)(p).SetBytes(buf)
+}
+
+// String returns the string representation of p
+func (p *GenericG2Affine) String() string {
+	return (*G2Affine)(p).String()
+}
+
+// genericPairing implements ecc.Pairing
+type genericPairing struct{}
+
+// PairingCheck returns true if ∏ e(P[i], Q[i]) = 1, with P in G1 and Q in G2
+func (genericPairing) PairingCheck(P, Q []ecc.Point) (bool, error) {
+	_P := make([]G1Affine, len(P))
+	for i := range P {
+		_P[i] = *toG1(P[i])
+	}
+	_Q := make([]G2Affine, len(Q))
+	for i := range Q {
+		_Q[i] = *toG2(Q[i])
+	}
+	return PairingCheck(_P, _Q)
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestGenericGroups(t *testing.T) {
+	for _, g := range []ecc.Group{ID.G1(), ID.G2()} {
+		if g.Curve() != ID || g.Order().Cmp(fr.Modulus()) != 0 {
+			t.Fatal("wrong curve or order")
+		}
+		gen := g.Generator()
+		if !gen.IsInSubGroup() || gen.IsInfinity() {
+			t.Fatal("generator is not in the group")
+		}
+
+		a, _ := rand.Int(rand.Reader, fr.Modulus())
+		b, _ := rand.Int(rand.Reader, fr.Modulus())
+		var aPlusB, aMinusB big.Int
+		aPlusB.Add(a, b)
+		aMinusB.Sub(a, b) // may be negative
+
+		A := g.NewPoint().ScalarMultiplication(gen, a)
+		B := g.NewPoint().ScalarMultiplication(gen, b)
+
+		if !g.NewPoint().Add(A, B).Equal(g.NewPoint().ScalarMultiplication(gen, &aPlusB)) {
+			t.Fatal("[a]G + [b]G != [a+b]G")
+		}
+		if !g.NewPoint().Sub(A, B).Equal(g.NewPoint().ScalarMultiplication(gen, &aMinusB)) {
+			t.Fatal("[a]G - [b]G != [a-b]G")
+		}
+		if !g.NewPoint().Add(A, g.NewPoint().Neg(A)).IsInfinity() {
+			t.Fatal("[a]G - [a]G is not the identity")
+		}
+
+		// [a]G + [a]([b]G) = [a(1+b)]G
+		res, err := g.MultiExp([]ecc.Point{gen, B}, []*big.Int{a, a}, ecc.MultiExpConfig{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var expected big.Int
+		expected.Add(b, big.NewInt(1)).Mul(&expected, a)
+		if !res.Equal(g.NewPoint().ScalarMultiplication(gen, &expected)) {
+			t.Fatal("wrong multi exponentiation")
+		}
+
+		for _, buf := range [][]byte{A.Bytes(), A.RawBytes()} {
+			C := g.NewPoint()
+			n, err := C.SetBytes(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(buf) || !C.Equal(A) {
+				t.Fatal("encoding round trip failed")
+			}
+		}
+	}
+}
+
+func TestGenericPairing(t *testing.T) {
+	g1, g2 := ID.G1(), ID.G2()
+	a, _ := rand.Int(rand.Reader, fr.Modulus())
+
+	// e([a]G1, G2) * e(-G1, [a]G2) = 1
+	P := []ecc.Point{g1.NewPoint().ScalarMultiplication(g1.Generator(), a), g1.NewPoint().Neg(g1.Generator())}
+	Q := []ecc.Point{g2.Generator(), g2.NewPoint().ScalarMultiplication(g2.Generator(), a)}
+	ok, err := ID.Pairing().PairingCheck(P, Q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("pairing check failed")
+	}
+
+	Q[0] = g2.NewPoint().Add(Q[0], g2.Generator())
+	ok, err = ID.Pairing().PairingCheck(P, Q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("pairing check should have failed")
+	}
+}
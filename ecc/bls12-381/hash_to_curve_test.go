@@ -0,0 +1,310 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bls12381
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/internal/fptower"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+type g1SSWUVector struct {
+	msg  string
+	x, y string
+}
+
+type g2SSWUVector struct {
+	msg            string
+	x0, x1, y0, y1 string
+}
+
+// hex encoded hash_to_curve test vectors from RFC 9380, appendix J.9.1
+var hashToCurveG1SSWUVectors = []g1SSWUVector{
+	{
+		msg: "",
+		x:   "052926add2207b76ca4fa57a8734416c8dc95e24501772c814278700eed6d1e4e8cf62d9c09db0fac349612b759e79a1",
+		y:   "08ba738453bfed09cb546dbb0783dbb3a5f1f566ed67bb6be0e8c67e2e81a4cc68ee29813bb7994998f3eae0c9c6a265",
+	},
+	{
+		msg: "abc",
+		x:   "03567bc5ef9c690c2ab2ecdf6a96ef1c139cc0b2f284dca0a9a7943388a49a3aee664ba5379a7655d3c68900be2f6903",
+		y:   "0b9c15f3fe6e5cf4211f346271d7b01c8f3b28be689c8429c85b67af215533311f0b8dfaaa154fa6b88176c229f2885d",
+	},
+	{
+		msg: "abcdef0123456789",
+		x:   "11e0b079dea29a68f0383ee94fed1b940995272407e3bb916bbf268c263ddd57a6a27200a784cbc248e84f357ce82d98",
+		y:   "03a87ae2caf14e8ee52e51fa2ed8eefe80f02457004ba4d486d6aa1f517c0889501dc7413753f9599b099ebcbbd2d709",
+	},
+	{
+		msg: "q128_" + strings.Repeat("q", 128),
+		x:   "15f68eaa693b95ccb85215dc65fa81038d69629f70aeee0d0f677cf22285e7bf58d7cb86eefe8f2e9bc3f8cb84fac488",
+		y:   "1807a1d50c29f430b8cafc4f8638dfeeadf51211e1602a5f184443076715f91bb90a48ba1e370edce6ae1062f5e6dd38",
+	},
+	{
+		msg: "a512_" + strings.Repeat("a", 512),
+		x:   "082aabae8b7dedb0e78aeb619ad3bfd9277a2f77ba7fad20ef6aabdc6c31d19ba5a6d12283553294c1825c4b3ca2dcfe",
+		y:   "05b84ae5a942248eea39e1d91030458c40153f3b654ab7872d779ad1e942856a20c438e8d99bc8abfbf74729ce1f7ac8",
+	},
+}
+
+// hex encoded encode_to_curve test vectors from RFC 9380, appendix J.9.2
+var encodeToCurveG1SSWUVectors = []g1SSWUVector{
+	{
+		msg: "",
+		x:   "184bb665c37ff561a89ec2122dd343f20e0f4cbcaec84e3c3052ea81d1834e192c426074b02ed3dca4e7676ce4ce48ba",
+		y:   "04407b8d35af4dacc809927071fc0405218f1401a6d15af775810e4e460064bcc9468beeba82fdc751be70476c888bf3",
+	},
+	{
+		msg: "abc",
+		x:   "009769f3ab59bfd551d53a5f846b9984c59b97d6842b20a2c565baa167945e3d026a3755b6345df8ec7e6acb6868ae6d",
+		y:   "1532c00cf61aa3d0ce3e5aa20c3b531a2abd2c770a790a2613818303c6b830ffc0ecf6c357af3317b9575c567f11cd2c",
+	},
+	{
+		msg: "abcdef0123456789",
+		x:   "1974dbb8e6b5d20b84df7e625e2fbfecb2cdb5f77d5eae5fb2955e5ce7313cae8364bc2fff520a6c25619739c6bdcb6a",
+		y:   "15f9897e11c6441eaa676de141c8d83c37aab8667173cbe1dfd6de74d11861b961dccebcd9d289ac633455dfcc7013a3",
+	},
+	{
+		msg: "q128_" + strings.Repeat("q", 128),
+		x:   "0a7a047c4a8397b3446450642c2ac64d7239b61872c9ae7a59707a8f4f950f101e766afe58223b3bff3a19a7f754027c",
+		y:   "1383aebba1e4327ccff7cf9912bda0dbc77de048b71ef8c8a81111d71dc33c5e3aa6edee9cf6f5fe525d50cc50b77cc9",
+	},
+	{
+		msg: "a512_" + strings.Repeat("a", 512),
+		x:   "0e7a16a975904f131682edbb03d9560d3e48214c9986bd50417a77108d13dc957500edf96462a3d01e62dc6cd468ef11",
+		y:   "0ae89e677711d05c30a48d6d75e76ca9fb70fe06c6dd6ff988683d89ccde29ac7d46c53bb97a59b1901abf1db66052db",
+	},
+}
+
+// hex encoded hash_to_curve test vectors from RFC 9380, appendix J.10.1
+var hashToCurveG2SSWUVectors = []g2SSWUVector{
+	{
+		msg: "",
+		x0:  "0141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a",
+		x1:  "05cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d",
+		y0:  "0503921d7f6a12805e72940b963c0cf3471c7b2a524950ca195d11062ee75ec076daf2d4bc358c4b190c0c98064fdd92",
+		y1:  "12424ac32561493f3fe3c260708a12b7c620e7be00099a974e259ddc7d1f6395c3c811cdd19f1e8dbf3e9ecfdcbab8d6",
+	},
+	{
+		msg: "abc",
+		x0:  "02c2d18e033b960562aae3cab37a27ce00d80ccd5ba4b7fe0e7a210245129dbec7780ccc7954725f4168aff2787776e6",
+		x1:  "139cddbccdc5e91b9623efd38c49f81a6f83f175e80b06fc374de9eb4b41dfe4ca3a230ed250fbe3a2acf73a41177fd8",
+		y0:  "1787327b68159716a37440985269cf584bcb1e621d3a7202be6ea05c4cfe244aeb197642555a0645fb87bf7466b2ba48",
+		y1:  "00aa65dae3c8d732d10ecd2c50f8a1baf3001578f71c694e03866e9f3d49ac1e1ce70dd94a733534f106d4cec0eddd16",
+	},
+	{
+		msg: "abcdef0123456789",
+		x0:  "121982811d2491fde9ba7ed31ef9ca474f0e1501297f68c298e9f4c0028add35aea8bb83d53c08cfc007c1e005723cd0",
+		x1:  "190d119345b94fbd15497bcba94ecf7db2cbfd1e1fe7da034d26cbba169fb3968288b3fafb265f9ebd380512a71c3f2c",
+		y0:  "05571a0f8d3c08d094576981f4a3b8eda0a8e771fcdcc8ecceaf1356a6acf17574518acb506e435b639353c2e14827c8",
+		y1:  "0bb5e7572275c567462d91807de765611490205a941a5a6af3b1691bfe596c31225d3aabdf15faff860cb4ef17c7c3be",
+	},
+	{
+		msg: "q128_" + strings.Repeat("q", 128),
+		x0:  "19a84dd7248a1066f737cc34502ee5555bd3c19f2ecdb3c7d9e24dc65d4e25e50d83f0f77105e955d78f4762d33c17da",
+		x1:  "0934aba516a52d8ae479939a91998299c76d39cc0c035cd18813bec433f587e2d7a4fef038260eef0cef4d02aae3eb91",
+		y0:  "14f81cd421617428bc3b9fe25afbb751d934a00493524bc4e065635b0555084dd54679df1536101b2c979c0152d09192",
+		y1:  "09bcccfa036b4847c9950780733633f13619994394c23ff0b32fa6b795844f4a0673e20282d07bc69641cee04f5e5662",
+	},
+	{
+		msg: "a512_" + strings.Repeat("a", 512),
+		x0:  "01a6ba2f9a11fa5598b2d8ace0fbe0a0eacb65deceb476fbbcb64fd24557c2f4b18ecfc5663e54ae16a84f5ab7f62534",
+		x1:  "11fca2ff525572795a801eed17eb12785887c7b63fb77a42be46ce4a34131d71f7a73e95fee3f812aea3de78b4d01569",
+		y0:  "0b6798718c8aed24bc19cb27f866f1c9effcdbf92397ad6448b5c9db90d2b9da6cbabf48adc1adf59a1a28344e79d57e",
+		y1:  "03a47f8e6d1763ba0cad63d6114c0accbef65707825a511b251a660a9b3994249ae4e63fac38b23da0c398689ee2ab52",
+	},
+}
+
+// hex encoded encode_to_curve test vectors from RFC 9380, appendix J.10.2
+var encodeToCurveG2SSWUVectors = []g2SSWUVector{
+	{
+		msg: "",
+		x0:  "00e7f4568a82b4b7dc1f14c6aaa055edf51502319c723c4dc2688c7fe5944c213f510328082396515734b6612c4e7bb7",
+		x1:  "126b855e9e69b1f691f816e48ac6977664d24d99f8724868a184186469ddfd4617367e94527d4b74fc86413483afb35b",
+		y0:  "0caead0fd7b6176c01436833c79d305c78be307da5f6af6c133c47311def6ff1e0babf57a0fb5539fce7ee12407b0a42",
+		y1:  "1498aadcf7ae2b345243e281ae076df6de84455d766ab6fcdaad71fab60abb2e8b980a440043cd305db09d283c895e3d",
+	},
+	{
+		msg: "abc",
+		x0:  "108ed59fd9fae381abfd1d6bce2fd2fa220990f0f837fa30e0f27914ed6e1454db0d1ee957b219f61da6ff8be0d6441f",
+		x1:  "0296238ea82c6d4adb3c838ee3cb2346049c90b96d602d7bb1b469b905c9228be25c627bffee872def773d5b2a2eb57d",
+		y0:  "033f90f6057aadacae7963b0a0b379dd46750c1c94a6357c99b65f63b79e321ff50fe3053330911c56b6ceea08fee656",
+		y1:  "153606c417e59fb331b7ae6bce4fbf7c5190c33ce9402b5ebe2b70e44fca614f3f1382a3625ed5493843d0b0a652fc3f",
+	},
+	{
+		msg: "abcdef0123456789",
+		x0:  "038af300ef34c7759a6caaa4e69363cafeed218a1f207e93b2c70d91a1263d375d6730bd6b6509dcac3ba5b567e85bf3",
+		x1:  "0da75be60fb6aa0e9e3143e40c42796edf15685cafe0279afd2a67c3dff1c82341f17effd402e4f1af240ea90f4b659b",
+		y0:  "19b148cbdf163cf0894f29660d2e7bfb2b68e37d54cc83fd4e6e62c020eaa48709302ef8e746736c0e19342cc1ce3df4",
+		y1:  "0492f4fed741b073e5a82580f7c663f9b79e036b70ab3e51162359cec4e77c78086fe879b65ca7a47d34374c8315ac5e",
+	},
+	{
+		msg: "q128_" + strings.Repeat("q", 128),
+		x0:  "0c5ae723be00e6c3f0efe184fdc0702b64588fe77dda152ab13099a3bacd3876767fa7bbad6d6fd90b3642e902b208f9",
+		x1:  "12c8c05c1d5fc7bfa847f4d7d81e294e66b9a78bc9953990c358945e1f042eedafce608b67fdd3ab0cb2e6e263b9b1ad",
+		y0:  "04e77ddb3ede41b5ec4396b7421dd916efc68a358a0d7425bddd253547f2fb4830522358491827265dfc5bcc1928a569",
+		y1:  "11c624c56dbe154d759d021eec60fab3d8b852395a89de497e48504366feedd4662d023af447d66926a28076813dd646",
+	},
+	{
+		msg: "a512_" + strings.Repeat("a", 512),
+		x0:  "0ea4e7c33d43e17cc516a72f76437c4bf81d8f4eac69ac355d3bf9b71b8138d55dc10fd458be115afa798b55dac34be1",
+		x1:  "1565c2f625032d232f13121d3cfb476f45275c303a037faa255f9da62000c2c864ea881e2bcddd111edc4a3c0da3e88d",
+		y0:  "043b6f5fe4e52c839148dc66f2b3751e69a0f6ebb3d056d6465d50d4108543ecd956e10fa1640dfd9bc0030cc2558d28",
+		y1:  "0f8991d2a1ad662e7b6f58ab787947f1fa607fce12dde171bc17903b012091b657e15333e11701edcf5b63ba2a561247",
+	},
+}
+
+func fpFromHex(s string) fp.Element {
+	var b big.Int
+	var res fp.Element
+	b.SetString(s, 16)
+	res.SetBigInt(&b)
+	return res
+}
+
+func TestHashToCurveG1SSWUVectors(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	testG1SSWUVectors(t, "HashToCurveG1SSWU", HashToCurveG1SSWU, dst, hashToCurveG1SSWUVectors)
+}
+
+func TestEncodeToCurveG1SSWUVectors(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_NU_")
+	testG1SSWUVectors(t, "EncodeToCurveG1SSWU", EncodeToCurveG1SSWU, dst, encodeToCurveG1SSWUVectors)
+}
+
+func TestHashToCurveG2SSWUVectors(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	testG2SSWUVectors(t, "HashToCurveG2SSWU", HashToCurveG2SSWU, dst, hashToCurveG2SSWUVectors)
+}
+
+func TestEncodeToCurveG2SSWUVectors(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_NU_")
+	testG2SSWUVectors(t, "EncodeToCurveG2SSWU", EncodeToCurveG2SSWU, dst, encodeToCurveG2SSWUVectors)
+}
+
+func testG1SSWUVectors(t *testing.T, name string, f func(msg, dst []byte) (G1Affine, error), dst []byte, vectors []g1SSWUVector) {
+	for _, v := range vectors {
+		p, err := f([]byte(v.msg), dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, y := fpFromHex(v.x), fpFromHex(v.y)
+		if !p.X.Equal(&x) || !p.Y.Equal(&y) {
+			t.Fatalf("%s(%q) doesn't match the RFC test vector", name, v.msg)
+		}
+	}
+}
+
+func testG2SSWUVectors(t *testing.T, name string, f func(msg, dst []byte) (G2Affine, error), dst []byte, vectors []g2SSWUVector) {
+	for _, v := range vectors {
+		p, err := f([]byte(v.msg), dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var x, y fptower.E2
+		x.A0, x.A1 = fpFromHex(v.x0), fpFromHex(v.x1)
+		y.A0, y.A1 = fpFromHex(v.y0), fpFromHex(v.y1)
+		if !p.X.Equal(&x) || !p.Y.Equal(&y) {
+			t.Fatalf("%s(%q) doesn't match the RFC test vector", name, v.msg)
+		}
+	}
+}
+
+func TestMapToCurveSSWU(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 10
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[G1] SSWU mapping should output point on the isogenous curve", prop.ForAll(
+		func(a fp.Element) bool {
+			g := sswuMapG1(a)
+			var rhs, lhs fp.Element
+			lhs.Square(&g.Y)
+			rhs.Square(&g.X).Add(&rhs, &sswuG1.A).Mul(&rhs, &g.X).Add(&rhs, &sswuG1.B)
+			return lhs.Equal(&rhs)
+		},
+		GenFp(),
+	))
+
+	properties.Property("[G1] isogeny should map E1' to the curve", prop.ForAll(
+		func(a fp.Element) bool {
+			g := sswuMapG1(a)
+			g = isogenyG1(&g)
+			return g.IsOnCurve()
+		},
+		GenFp(),
+	))
+
+	properties.Property("[G1] SSWU mapping should output point in the subgroup", prop.ForAll(
+		func(a fp.Element) bool {
+			g := MapToCurveG1SSWU(a)
+			return g.IsInSubGroup()
+		},
+		GenFp(),
+	))
+
+	properties.Property("[G2] SSWU mapping should output point on the isogenous curve", prop.ForAll(
+		func(a fptower.E2) bool {
+			g := sswuMapG2(a)
+			var rhs, lhs fptower.E2
+			lhs.Square(&g.Y)
+			rhs.Square(&g.X).Add(&rhs, &sswuG2.A).Mul(&rhs, &g.X).Add(&rhs, &sswuG2.B)
+			return lhs.Equal(&rhs)
+		},
+		GenE2(),
+	))
+
+	properties.Property("[G2] isogeny should map E2' to the curve", prop.ForAll(
+		func(a fptower.E2) bool {
+			g := sswuMapG2(a)
+			g = isogenyG2(&g)
+			return g.IsOnCurve()
+		},
+		GenE2(),
+	))
+
+	properties.Property("[G2] SSWU mapping should output point in the subgroup", prop.ForAll(
+		func(a fptower.E2) bool {
+			g := MapToCurveG2SSWU(a)
+			return g.IsInSubGroup()
+		},
+		GenE2(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func BenchmarkHashToCurveG1SSWU(b *testing.B) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		HashToCurveG1SSWU([]byte("abc"), dst)
+	}
+}
+
+func BenchmarkHashToCurveG2SSWU(b *testing.B) {
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		HashToCurveG2SSWU([]byte("abc"), dst)
+	}
+}
@@ -19,10 +19,11 @@ package bls12381
 import (
 	"errors"
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
-	"github.com/consensys/gnark-crypto/internal/parallel"
+	"github.com/consensys/gnark-crypto/pool"
 	"math"
-	"runtime"
+	"sync"
 )
 
 // selector stores the index, mask and shifts needed to select bits from a scalar
@@ -37,13 +38,92 @@ type selector struct {
 	shiftHigh       uint64 // same than shift, for index+1
 }
 
+// newSelector returns the selector of the c-bit window chunk of a scalar
+func newSelector(chunk, c uint64) selector {
+	jc := uint64(chunk * c)
+	s := selector{}
+	s.index = jc / 64
+	s.shift = jc - (s.index * 64)
+	s.mask = uint64((1<<c)-1) << s.shift
+	s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
+	if s.multiWordSelect {
+		nbBitsHigh := s.shift - uint64(64-c)
+		s.maskHigh = (1 << nbBitsHigh) - 1
+		s.shiftHigh = (c - nbBitsHigh)
+	}
+	return s
+}
+
+// msmContext carries the cancellation, the progress reporting and the pool of a multi exponentiation
+type msmContext struct {
+	done     <-chan struct{} // closed when the multi exponentiation is cancelled
+	progress func()          // called when a task is processed
+	pool     *pool.Pool      // workers running the tasks, or nil for the default pool
+}
+
+// newMSMContext returns the msmContext of config for total tasks, or nil if config
+// has no context, no progress callback and no pool
+func newMSMContext(config ecc.MultiExpConfig, total int) *msmContext {
+	if config.Context == nil && config.Progress == nil && config.Pool == nil {
+		return nil
+	}
+	ctx := &msmContext{pool: config.Pool}
+	if config.Context != nil {
+		ctx.done = config.Context.Done()
+	}
+	if config.Progress != nil {
+		var lock sync.Mutex
+		done := 0
+		ctx.progress = func() {
+			lock.Lock()
+			done++
+			config.Progress(done, total)
+			lock.Unlock()
+		}
+	}
+	return ctx
+}
+
+// cancelled returns true if the multi exponentiation is cancelled
+func (ctx *msmContext) cancelled() bool {
+	if ctx == nil || ctx.done == nil {
+		return false
+	}
+	select {
+	case <-ctx.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// taskDone reports that a task is processed
+func (ctx *msmContext) taskDone() {
+	if ctx != nil && ctx.progress != nil {
+		ctx.progress()
+	}
+}
+
+// execute runs work on the pool of the multi exponentiation, split in nbTasks tasks
+func (ctx *msmContext) execute(nbIterations int, work func(int, int), nbTasks int) {
+	var pl *pool.Pool
+	if ctx != nil {
+		pl = ctx.pool
+	}
+	pl.Execute(nbIterations, work, nbTasks)
+}
+
+// number of scalars processed between two checks of the cancellation
+const msmCancellationPeriod = 1 << 10
+
 // partitionScalars  compute, for each scalars over c-bit wide windows, nbChunk digits
 // if the digit is larger than 2^{c-1}, then, we borrow 2^c from the next window and substract
 // 2^{c} to the current digit, making it negative.
 // negative digits can be processed in a later step as adding -G into the bucket instead of G
 // (computing -G is cheap, and this saves us half of the buckets in the MultiExp or BatchScalarMul)
 // scalarsMont indicates wheter the provided scalars are in montgomery form
-func partitionScalars(scalars []fr.Element, c uint64, scalarsMont bool, nbTasks int) []fr.Element {
+// the work is split in nbTasks tasks run on pl (or the default pool if pl is nil)
+func partitionScalars(scalars []fr.Element, c uint64, scalarsMont bool, nbTasks int, pl *pool.Pool) []fr.Element {
 	toReturn := make([]fr.Element, len(scalars))
 
 	// number of c-bit radixes in a scalar
@@ -74,7 +154,7 @@ func partitionScalars(scalars []fr.Element, c uint64, scalarsMont bool, nbTasks
 		selectors[chunk] = d
 	}
 
-	parallel.Execute(len(scalars), func(start, end int) {
+	pl.Execute(len(scalars), func(start, end int) {
 		for i := start; i < end; i++ {
 			var carry int
 
@@ -168,9 +248,9 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 		return nil, errors.New("len(points) != len(scalars)")
 	}
 
-	// if nbTasks is not set, use all available CPUs
+	// if nbTasks is not set, use all the workers of the pool
 	if config.NbTasks <= 0 {
-		config.NbTasks = runtime.NumCPU()
+		config.NbTasks = config.Pool.NbWorkers()
 	}
 
 	// here, we compute the best C for nbPoints
@@ -215,83 +295,96 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 		}
 	}
 
+	// nbChunks tasks are processed
+	ctx := newMSMContext(config, nbChunks)
+	if config.Context != nil {
+		if err := config.Context.Err(); err != nil {
+			return nil, err
+		}
+	}
+
 	// partition the scalars
 	// note: we do that before the actual chunk processing, as for each c-bit window (starting from LSW)
 	// if it's larger than 2^{c-1}, we have a carry we need to propagate up to the higher window
 	// TODO nbTasks
-	scalars = partitionScalars(scalars, C, config.ScalarsMont, config.NbTasks)
+	scalars = partitionScalars(scalars, C, config.ScalarsMont, config.NbTasks, config.Pool)
 
 	// we have nbSplits intermediate results that we must sum together.
-	_p := make([]G1Jac, nbSplits-1)
-	chDone := make(chan int, nbSplits-1)
-	for i := 0; i < nbSplits-1; i++ {
-		start := i * nbPoints
-		end := start + nbPoints
-		go func(start, end, i int) {
-			msmInnerG1Jac(&_p[i], int(C), points[start:end], scalars[start:end], config.NbTasks/nbSplits)
-			chDone <- i
-		}(start, end, i)
-	}
-
-	msmInnerG1Jac(p, int(C), points[(nbSplits-1)*nbPoints:], scalars[(nbSplits-1)*nbPoints:], config.NbTasks/nbSplits)
-	for i := 0; i < nbSplits-1; i++ {
-		done := <-chDone
-		p.AddAssign(&_p[done])
-	}
-	close(chDone)
+	// the last split also processes the remaining points
+	_p := make([]G1Jac, nbSplits)
+	ctx.execute(nbSplits, func(startSplit, endSplit int) {
+		for i := startSplit; i < endSplit; i++ {
+			start := i * nbPoints
+			end := start + nbPoints
+			if i == nbSplits-1 {
+				end = len(points)
+			}
+			msmInnerG1Jac(&_p[i], int(C), points[start:end], scalars[start:end], config.NbTasks/nbSplits, ctx)
+		}
+	}, nbSplits)
+
+	p.Set(&_p[0])
+	for i := 1; i < nbSplits; i++ {
+		p.AddAssign(&_p[i])
+	}
+	if config.Context != nil {
+		if err := config.Context.Err(); err != nil {
+			return nil, err
+		}
+	}
 	return p, nil
 }
 
-func msmInnerG1Jac(p *G1Jac, c int, points []G1Affine, scalars []fr.Element, nbTasks int) {
+func msmInnerG1Jac(p *G1Jac, c int, points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) {
 	switch c {
 
 	case 4:
-		p.msmC4(points, scalars, nbTasks)
+		p.msmC4(points, scalars, nbTasks, ctx)
 
 	case 5:
-		p.msmC5(points, scalars, nbTasks)
+		p.msmC5(points, scalars, nbTasks, ctx)
 
 	case 6:
-		p.msmC6(points, scalars, nbTasks)
+		p.msmC6(points, scalars, nbTasks, ctx)
 
 	case 7:
-		p.msmC7(points, scalars, nbTasks)
+		p.msmC7(points, scalars, nbTasks, ctx)
 
 	case 8:
-		p.msmC8(points, scalars, nbTasks)
+		p.msmC8(points, scalars, nbTasks, ctx)
 
 	case 9:
-		p.msmC9(points, scalars, nbTasks)
+		p.msmC9(points, scalars, nbTasks, ctx)
 
 	case 10:
-		p.msmC10(points, scalars, nbTasks)
+		p.msmC10(points, scalars, nbTasks, ctx)
 
 	case 11:
-		p.msmC11(points, scalars, nbTasks)
+		p.msmC11(points, scalars, nbTasks, ctx)
 
 	case 12:
-		p.msmC12(points, scalars, nbTasks)
+		p.msmC12(points, scalars, nbTasks, ctx)
 
 	case 13:
-		p.msmC13(points, scalars, nbTasks)
+		p.msmC13(points, scalars, nbTasks, ctx)
 
 	case 14:
-		p.msmC14(points, scalars, nbTasks)
+		p.msmC14(points, scalars, nbTasks, ctx)
 
 	case 15:
-		p.msmC15(points, scalars, nbTasks)
+		p.msmC15(points, scalars, nbTasks, ctx)
 
 	case 16:
-		p.msmC16(points, scalars, nbTasks)
+		p.msmC16(points, scalars, nbTasks, ctx)
 
 	case 20:
-		p.msmC20(points, scalars, nbTasks)
+		p.msmC20(points, scalars, nbTasks, ctx)
 
 	case 21:
-		p.msmC21(points, scalars, nbTasks)
+		p.msmC21(points, scalars, nbTasks, ctx)
 
 	case 22:
-		p.msmC22(points, scalars, nbTasks)
+		p.msmC22(points, scalars, nbTasks, ctx)
 
 	default:
 		panic("not implemented")
@@ -319,29 +412,22 @@ func msmProcessChunkG1Affine(chunk uint64,
 	buckets []g1JacExtended,
 	c uint64,
 	points []G1Affine,
-	scalars []fr.Element) {
+	scalars []fr.Element,
+	ctx *msmContext) {
 
-	mask := uint64((1 << c) - 1) // low c bits are 1
 	msbWindow := uint64(1 << (c - 1))
 
 	for i := 0; i < len(buckets); i++ {
 		buckets[i].setInfinity()
 	}
 
-	jc := uint64(chunk * c)
-	s := selector{}
-	s.index = jc / 64
-	s.shift = jc - (s.index * 64)
-	s.mask = mask << s.shift
-	s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
-	if s.multiWordSelect {
-		nbBitsHigh := s.shift - uint64(64-c)
-		s.maskHigh = (1 << nbBitsHigh) - 1
-		s.shiftHigh = (c - nbBitsHigh)
-	}
+	s := newSelector(chunk, c)
 
 	// for each scalars, get the digit corresponding to the chunk we're processing.
 	for i := 0; i < len(scalars); i++ {
+		if i%msmCancellationPeriod == 0 && ctx.cancelled() {
+			break
+		}
 		bits := (scalars[i][s.index] & s.mask) >> s.shift
 		if s.multiWordSelect {
 			bits += (scalars[i][s.index+1] & s.maskHigh) << s.shiftHigh
@@ -374,530 +460,769 @@ func msmProcessChunkG1Affine(chunk uint64,
 		total.add(&runningSum)
 	}
 
+	ctx.taskDone()
 	chRes <- total
 	close(chRes)
 }
 
-func (p *G1Jac) msmC4(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+// bucketAdditionG1 is an addition to a bucket, queued when the bucket is already in the current batch
+type bucketAdditionG1 struct {
+	bucket uint32
+	point  G1Affine
+}
+
+// msmProcessChunkG1AffineBatchAffine is msmProcessChunkG1Affine with the buckets in affine coordinates.
+// The additions are collected in batches of distinct buckets, and each batch is computed
+// with one shared inversion. An addition to a bucket which is already in the current batch
+// is queued and processed with a later batch.
+func msmProcessChunkG1AffineBatchAffine(chunk uint64,
+	chRes chan<- g1JacExtended,
+	c uint64,
+	points []G1Affine,
+	scalars []fr.Element,
+	ctx *msmContext) {
+
+	msbWindow := uint64(1 << (c - 1))
+	nbBuckets := 1 << (c - 1)
+
+	// the probability that an addition conflicts with the current batch is at most 1/8
+	batchSize := nbBuckets / 8
+	if batchSize == 0 {
+		batchSize = 1
+	}
+
+	buckets := make([]G1Affine, nbBuckets)
+	inBatch := make([]bool, nbBuckets)
+	batchBuckets := make([]uint32, 0, batchSize)
+	batchPoints := make([]G1Affine, 0, batchSize)
+	denominators := make([]fp.Element, batchSize)
+	var queue []bucketAdditionG1
+
+	// executeBatch adds batchPoints[i] to buckets[batchBuckets[i]], with the affine formulas
+	// λ = (y2-y1)/(x2-x1), x3 = λ²-x1-x2, y3 = λ(x1-x3)-y1
+	executeBatch := func() {
+		for i, b := range batchBuckets {
+			denominators[i].Sub(&batchPoints[i].X, &buckets[b].X)
+		}
+		inverses := fp.BatchInvert(denominators[:len(batchBuckets)])
+
+		var lambda, x, y fp.Element
+		for i, b := range batchBuckets {
+			bucket := &buckets[b]
+			lambda.Sub(&batchPoints[i].Y, &bucket.Y).Mul(&lambda, &inverses[i])
+			x.Square(&lambda).Sub(&x, &bucket.X).Sub(&x, &batchPoints[i].X)
+			y.Sub(&bucket.X, &x).Mul(&y, &lambda).Sub(&y, &bucket.Y)
+			bucket.X = x
+			bucket.Y = y
+			inBatch[b] = false
+		}
+		batchBuckets = batchBuckets[:0]
+		batchPoints = batchPoints[:0]
+	}
+
+	// add adds p to buckets[b], which must not be in the current batch
+	add := func(b uint32, p *G1Affine) {
+		bucket := &buckets[b]
+		if p.IsInfinity() {
+			return
+		}
+		if bucket.IsInfinity() {
+			*bucket = *p
+			return
+		}
+		if bucket.X.Equal(&p.X) {
+			// doubling or p = -bucket, the affine formulas don't apply
+			var tmp G1Jac
+			tmp.FromAffine(bucket)
+			tmp.AddMixed(p)
+			bucket.FromJacobian(&tmp)
+			return
+		}
+		inBatch[b] = true
+		batchBuckets = append(batchBuckets, b)
+		batchPoints = append(batchPoints, *p)
+		if len(batchBuckets) == batchSize {
+			executeBatch()
+		}
+	}
+
+	// processQueue adds the queued points whose buckets are no longer in the current batch
+	processQueue := func() {
+		kept := queue[:0]
+		for i := range queue {
+			if inBatch[queue[i].bucket] {
+				kept = append(kept, queue[i])
+				continue
+			}
+			add(queue[i].bucket, &queue[i].point)
+		}
+		queue = kept
+	}
+
+	s := newSelector(chunk, c)
+
+	// for each scalars, get the digit corresponding to the chunk we're processing.
+	var p G1Affine
+	for i := 0; i < len(scalars); i++ {
+		if i%msmCancellationPeriod == 0 && ctx.cancelled() {
+			break
+		}
+		bits := (scalars[i][s.index] & s.mask) >> s.shift
+		if s.multiWordSelect {
+			bits += (scalars[i][s.index+1] & s.maskHigh) << s.shiftHigh
+		}
+
+		if bits == 0 {
+			continue
+		}
+
+		// if msbWindow bit is set, we need to substract
+		var b uint32
+		if bits&msbWindow == 0 {
+			// add
+			b = uint32(bits - 1)
+			p = points[i]
+		} else {
+			// sub
+			b = uint32(bits & ^msbWindow)
+			p.Neg(&points[i])
+		}
+
+		if inBatch[b] {
+			queue = append(queue, bucketAdditionG1{bucket: b, point: p})
+			continue
+		}
+		add(b, &p)
+		if len(batchBuckets) == 0 && len(queue) != 0 {
+			// a batch was just executed
+			processQueue()
+		}
+	}
+
+	// each round adds at least one queued point per bucket
+	for len(batchBuckets) != 0 || len(queue) != 0 {
+		executeBatch()
+		processQueue()
+	}
+
+	// reduce buckets into total
+	// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+
+	var runningSum, total g1JacExtended
+	runningSum.setInfinity()
+	total.setInfinity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		if !buckets[k].IsInfinity() {
+			runningSum.addMixed(&buckets[k])
+		}
+		total.add(&runningSum)
+	}
+
+	ctx.taskDone()
+	chRes <- total
+	close(chRes)
+}
+
+func (p *G1Jac) msmC4(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 4                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		var buckets [1 << (c - 1)]g1JacExtended
+		msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC5(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC5(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 5                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g1JacExtended
+		msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC6(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC6(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 6                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g1JacExtended
+		msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC7(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC7(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 7                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g1JacExtended
+		msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC8(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC8(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 8                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		var buckets [1 << (c - 1)]g1JacExtended
+		msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC9(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC9(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 9                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g1JacExtended
+		msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC10(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC10(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 10                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g1JacExtended
+		msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC11(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC11(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 11                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g1JacExtended
+		msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC12(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC12(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 12                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		// for large c, the affine bucket accumulation is faster
+		msmProcessChunkG1AffineBatchAffine(uint64(j), chChunks[j], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC13(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC13(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 13                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		// for large c, the affine bucket accumulation is faster
+		msmProcessChunkG1AffineBatchAffine(uint64(j), chChunks[j], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC14(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC14(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 14                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		// for large c, the affine bucket accumulation is faster
+		msmProcessChunkG1AffineBatchAffine(uint64(j), chChunks[j], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC15(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC15(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 15                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		// for large c, the affine bucket accumulation is faster
+		msmProcessChunkG1AffineBatchAffine(uint64(j), chChunks[j], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC16(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC16(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 16                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		// for large c, the affine bucket accumulation is faster
+		msmProcessChunkG1AffineBatchAffine(uint64(j), chChunks[j], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC20(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC20(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 20                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		// for large c, the affine bucket accumulation is faster
+		msmProcessChunkG1AffineBatchAffine(uint64(j), chChunks[j], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC21(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC21(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 21                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		// for large c, the affine bucket accumulation is faster
+		msmProcessChunkG1AffineBatchAffine(uint64(j), chChunks[j], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC22(points []G1Affine, scalars []fr.Element, nbTasks int) *G1Jac {
+func (p *G1Jac) msmC22(points []G1Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G1Jac {
 	const (
 		c        = 22                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g1JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g1JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G1Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G1Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g1JacExtended
-			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g1JacExtended
+			msmProcessChunkG1Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		// for large c, the affine bucket accumulation is faster
+		msmProcessChunkG1AffineBatchAffine(uint64(j), chChunks[j], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
@@ -945,9 +1270,9 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 		return nil, errors.New("len(points) != len(scalars)")
 	}
 
-	// if nbTasks is not set, use all available CPUs
+	// if nbTasks is not set, use all the workers of the pool
 	if config.NbTasks <= 0 {
-		config.NbTasks = runtime.NumCPU()
+		config.NbTasks = config.Pool.NbWorkers()
 	}
 
 	// here, we compute the best C for nbPoints
@@ -992,83 +1317,96 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 		}
 	}
 
+	// nbChunks tasks are processed
+	ctx := newMSMContext(config, nbChunks)
+	if config.Context != nil {
+		if err := config.Context.Err(); err != nil {
+			return nil, err
+		}
+	}
+
 	// partition the scalars
 	// note: we do that before the actual chunk processing, as for each c-bit window (starting from LSW)
 	// if it's larger than 2^{c-1}, we have a carry we need to propagate up to the higher window
 	// TODO nbTasks
-	scalars = partitionScalars(scalars, C, config.ScalarsMont, config.NbTasks)
+	scalars = partitionScalars(scalars, C, config.ScalarsMont, config.NbTasks, config.Pool)
 
 	// we have nbSplits intermediate results that we must sum together.
-	_p := make([]G2Jac, nbSplits-1)
-	chDone := make(chan int, nbSplits-1)
-	for i := 0; i < nbSplits-1; i++ {
-		start := i * nbPoints
-		end := start + nbPoints
-		go func(start, end, i int) {
-			msmInnerG2Jac(&_p[i], int(C), points[start:end], scalars[start:end], config.NbTasks/nbSplits)
-			chDone <- i
-		}(start, end, i)
-	}
-
-	msmInnerG2Jac(p, int(C), points[(nbSplits-1)*nbPoints:], scalars[(nbSplits-1)*nbPoints:], config.NbTasks/nbSplits)
-	for i := 0; i < nbSplits-1; i++ {
-		done := <-chDone
-		p.AddAssign(&_p[done])
-	}
-	close(chDone)
+	// the last split also processes the remaining points
+	_p := make([]G2Jac, nbSplits)
+	ctx.execute(nbSplits, func(startSplit, endSplit int) {
+		for i := startSplit; i < endSplit; i++ {
+			start := i * nbPoints
+			end := start + nbPoints
+			if i == nbSplits-1 {
+				end = len(points)
+			}
+			msmInnerG2Jac(&_p[i], int(C), points[start:end], scalars[start:end], config.NbTasks/nbSplits, ctx)
+		}
+	}, nbSplits)
+
+	p.Set(&_p[0])
+	for i := 1; i < nbSplits; i++ {
+		p.AddAssign(&_p[i])
+	}
+	if config.Context != nil {
+		if err := config.Context.Err(); err != nil {
+			return nil, err
+		}
+	}
 	return p, nil
 }
 
-func msmInnerG2Jac(p *G2Jac, c int, points []G2Affine, scalars []fr.Element, nbTasks int) {
+func msmInnerG2Jac(p *G2Jac, c int, points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) {
 	switch c {
 
 	case 4:
-		p.msmC4(points, scalars, nbTasks)
+		p.msmC4(points, scalars, nbTasks, ctx)
 
 	case 5:
-		p.msmC5(points, scalars, nbTasks)
+		p.msmC5(points, scalars, nbTasks, ctx)
 
 	case 6:
-		p.msmC6(points, scalars, nbTasks)
+		p.msmC6(points, scalars, nbTasks, ctx)
 
 	case 7:
-		p.msmC7(points, scalars, nbTasks)
+		p.msmC7(points, scalars, nbTasks, ctx)
 
 	case 8:
-		p.msmC8(points, scalars, nbTasks)
+		p.msmC8(points, scalars, nbTasks, ctx)
 
 	case 9:
-		p.msmC9(points, scalars, nbTasks)
+		p.msmC9(points, scalars, nbTasks, ctx)
 
 	case 10:
-		p.msmC10(points, scalars, nbTasks)
+		p.msmC10(points, scalars, nbTasks, ctx)
 
 	case 11:
-		p.msmC11(points, scalars, nbTasks)
+		p.msmC11(points, scalars, nbTasks, ctx)
 
 	case 12:
-		p.msmC12(points, scalars, nbTasks)
+		p.msmC12(points, scalars, nbTasks, ctx)
 
 	case 13:
-		p.msmC13(points, scalars, nbTasks)
+		p.msmC13(points, scalars, nbTasks, ctx)
 
 	case 14:
-		p.msmC14(points, scalars, nbTasks)
+		p.msmC14(points, scalars, nbTasks, ctx)
 
 	case 15:
-		p.msmC15(points, scalars, nbTasks)
+		p.msmC15(points, scalars, nbTasks, ctx)
 
 	case 16:
-		p.msmC16(points, scalars, nbTasks)
+		p.msmC16(points, scalars, nbTasks, ctx)
 
 	case 20:
-		p.msmC20(points, scalars, nbTasks)
+		p.msmC20(points, scalars, nbTasks, ctx)
 
 	case 21:
-		p.msmC21(points, scalars, nbTasks)
+		p.msmC21(points, scalars, nbTasks, ctx)
 
 	case 22:
-		p.msmC22(points, scalars, nbTasks)
+		p.msmC22(points, scalars, nbTasks, ctx)
 
 	default:
 		panic("not implemented")
@@ -1096,29 +1434,22 @@ func msmProcessChunkG2Affine(chunk uint64,
 	buckets []g2JacExtended,
 	c uint64,
 	points []G2Affine,
-	scalars []fr.Element) {
+	scalars []fr.Element,
+	ctx *msmContext) {
 
-	mask := uint64((1 << c) - 1) // low c bits are 1
 	msbWindow := uint64(1 << (c - 1))
 
 	for i := 0; i < len(buckets); i++ {
 		buckets[i].setInfinity()
 	}
 
-	jc := uint64(chunk * c)
-	s := selector{}
-	s.index = jc / 64
-	s.shift = jc - (s.index * 64)
-	s.mask = mask << s.shift
-	s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
-	if s.multiWordSelect {
-		nbBitsHigh := s.shift - uint64(64-c)
-		s.maskHigh = (1 << nbBitsHigh) - 1
-		s.shiftHigh = (c - nbBitsHigh)
-	}
+	s := newSelector(chunk, c)
 
 	// for each scalars, get the digit corresponding to the chunk we're processing.
 	for i := 0; i < len(scalars); i++ {
+		if i%msmCancellationPeriod == 0 && ctx.cancelled() {
+			break
+		}
 		bits := (scalars[i][s.index] & s.mask) >> s.shift
 		if s.multiWordSelect {
 			bits += (scalars[i][s.index+1] & s.maskHigh) << s.shiftHigh
@@ -1151,529 +1482,610 @@ func msmProcessChunkG2Affine(chunk uint64,
 		total.add(&runningSum)
 	}
 
+	ctx.taskDone()
 	chRes <- total
 	close(chRes)
 }
 
-func (p *G2Jac) msmC4(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC4(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 4                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC5(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC5(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 5                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC6(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC6(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 6                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC7(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC7(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 7                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC8(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC8(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 8                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC9(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC9(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 9                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC10(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC10(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 10                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC11(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC11(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 11                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC12(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC12(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 12                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC13(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC13(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 13                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC14(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC14(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 14                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC15(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC15(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 15                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC16(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC16(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 16                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC20(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC20(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 20                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC21(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC21(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 21                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC22(points []G2Affine, scalars []fr.Element, nbTasks int) *G2Jac {
+func (p *G2Jac) msmC22(points []G2Affine, scalars []fr.Element, nbTasks int, ctx *msmContext) *G2Jac {
 	const (
 		c        = 22                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
 	)
 
-	// for each chunk, spawn one go routine that'll loop through all the scalars in the
+	// for each chunk, run one task on the pool that'll loop through all the scalars in the
 	// corresponding bit-window
 	// note that buckets is an array allocated on the stack (for most sizes of c) and this is
 	// critical for performance
 
-	// each go routine sends its result in chChunks[i] channel
+	// each task sends its result in chChunks[i] channel
 	var chChunks [nbChunks + 1]chan g2JacExtended
 	for i := 0; i < len(chChunks); i++ {
 		chChunks[i] = make(chan g2JacExtended, 1)
 	}
 
-	// c doesn't divide 256, last window is smaller we can allocate less buckets
-	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
-	go func(j uint64, points []G2Affine, scalars []fr.Element) {
-		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
-	}(uint64(nbChunks), points, scalars)
-
-	for j := int(nbChunks - 1); j >= 0; j-- {
-		go func(j int, points []G2Affine, scalars []fr.Element) {
-			var buckets [1 << (c - 1)]g2JacExtended
-			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars)
-		}(j, points, scalars)
+	processChunk := func(j int) {
+		if j == nbChunks {
+			// c doesn't divide 256, last window is smaller we can allocate less buckets
+			const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
+			var buckets [1 << (lastC - 1)]g2JacExtended
+			msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
+			return
+		}
+		var buckets [1 << (c - 1)]g2JacExtended
+		msmProcessChunkG2Affine(uint64(j), chChunks[j], buckets[:], c, points, scalars, ctx)
 	}
 
+	// the channels are buffered, so the tasks don't wait for the reduction
+	ctx.execute(len(chChunks), func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(j)
+		}
+	}, len(chChunks))
+
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
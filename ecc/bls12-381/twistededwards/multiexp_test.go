@@ -0,0 +1,206 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestMultiExp(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 3
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenBigInt()
+
+	// size of the multiExps
+	const nbSamples = 143
+
+	// multi exp points
+	var samplePoints [nbSamples]PointAffine
+	var g PointExtended
+	g.FromAffine(&edwards.Base)
+	for i := 0; i < nbSamples; i++ {
+		samplePoints[i].FromExtended(&g)
+		g.MixedAdd(&g, &edwards.Base)
+	}
+
+	properties.Property("Multi exponentation should be consistant with the sum of scalar multiplications", prop.ForAll(
+		func(mixer big.Int) bool {
+
+			var mixerFr fr.Element
+			mixerFr.SetBigInt(&mixer)
+
+			var sampleScalars, sampleScalarsMont [nbSamples]fr.Element
+			var expected, tmp PointExtended
+			var s big.Int
+			expected.setInfinity()
+			for i := 0; i < nbSamples; i++ {
+				sampleScalarsMont[i].SetUint64(uint64(i+1)).
+					Mul(&sampleScalarsMont[i], &mixerFr)
+				sampleScalars[i] = sampleScalarsMont[i]
+				sampleScalars[i].FromMont()
+
+				sampleScalarsMont[i].ToBigIntRegular(&s)
+				tmp.FromAffine(&samplePoints[i])
+				tmp.ScalarMul(&tmp, &s)
+				expected.Add(&expected, &tmp)
+			}
+
+			var result PointExtended
+			for _, nbTasks := range []int{0, 1, 5, 128} {
+				if _, err := result.MultiExp(samplePoints[:], sampleScalarsMont[:], ecc.MultiExpConfig{ScalarsMont: true, NbTasks: nbTasks}); err != nil || !result.Equal(&expected) {
+					return false
+				}
+			}
+			if _, err := result.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{}); err != nil || !result.Equal(&expected) {
+				return false
+			}
+
+			var resultAffine, expectedAffine PointAffine
+			expectedAffine.FromExtended(&expected)
+			if _, err := resultAffine.MultiExp(samplePoints[:], sampleScalarsMont[:], ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+				return false
+			}
+			var resultProj PointProj
+			if _, err := resultProj.MultiExp(samplePoints[:], sampleScalarsMont[:], ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+				return false
+			}
+			var fromProj PointAffine
+			fromProj.FromProj(&resultProj)
+			return resultAffine.Equal(&expectedAffine) && fromProj.Equal(&expectedAffine)
+		},
+		genScalar,
+	))
+
+	properties.Property("Multi exponentation of edge case scalars should be consistant with the sum of scalar multiplications", prop.ForAll(
+		func(mixer big.Int) bool {
+
+			// scalars with all the bits set in some windows, and -1, to propagate the carries
+			var sampleScalars [nbSamples]fr.Element
+			var expected, tmp PointExtended
+			var s big.Int
+			expected.setInfinity()
+			for i := 0; i < nbSamples; i++ {
+				switch i % 3 {
+				case 0:
+					sampleScalars[i].SetOne().Neg(&sampleScalars[i])
+				case 1:
+					s.Lsh(big.NewInt(1), uint(i)).Sub(&s, big.NewInt(1))
+					sampleScalars[i].SetBigInt(&s)
+				default:
+					sampleScalars[i].SetBigInt(&mixer)
+				}
+				sampleScalars[i].ToBigIntRegular(&s)
+				tmp.FromAffine(&samplePoints[i])
+				tmp.ScalarMul(&tmp, &s)
+				expected.Add(&expected, &tmp)
+			}
+
+			var result PointExtended
+			if _, err := result.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+				return false
+			}
+			return result.Equal(&expected)
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// wrong number of scalars, and cancelled context
+	var result PointExtended
+	scalars := make([]fr.Element, nbSamples)
+	if _, err := result.MultiExp(samplePoints[:], scalars[1:], ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("wrong number of scalars should be rejected")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := result.MultiExp(samplePoints[:], scalars, ecc.MultiExpConfig{Context: ctx}); err != context.Canceled {
+		t.Fatal("cancelled multi exponentiation should return the error of the context")
+	}
+}
+
+func TestMultiExpProgress(t *testing.T) {
+	const nbSamples = 100
+	points := make([]PointAffine, nbSamples)
+	scalars := make([]fr.Element, nbSamples)
+	for i := range points {
+		points[i].Set(&edwards.Base)
+		scalars[i].SetRandom()
+	}
+
+	var last, total, nbCalls int
+	config := ecc.MultiExpConfig{ScalarsMont: true, Progress: func(done, t int) {
+		nbCalls++
+		last, total = done, t
+	}}
+	var result PointExtended
+	if _, err := result.MultiExp(points, scalars, config); err != nil {
+		t.Fatal(err)
+	}
+	if nbCalls == 0 || last != total || nbCalls != total {
+		t.Fatal("progress should be reported for each task")
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkMultiExp(b *testing.B) {
+
+	const (
+		pow       = 15
+		nbSamples = 1 << pow
+	)
+
+	var (
+		samplePoints  [nbSamples]PointAffine
+		sampleScalars [nbSamples]fr.Element
+	)
+
+	var g PointExtended
+	g.FromAffine(&edwards.Base)
+	for i := 0; i < nbSamples; i++ {
+		sampleScalars[i].SetRandom()
+		samplePoints[i].FromExtended(&g)
+		g.MixedAdd(&g, &edwards.Base)
+	}
+
+	var testPoint PointExtended
+
+	for i := 5; i <= pow; i++ {
+		using := 1 << i
+
+		b.Run(fmt.Sprintf("%d points", using), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				testPoint.MultiExp(samplePoints[:using], sampleScalars[:using], ecc.MultiExpConfig{ScalarsMont: true})
+			}
+		})
+	}
+}
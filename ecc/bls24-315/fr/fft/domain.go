@@ -29,6 +29,7 @@ import (
 	curve "github.com/consensys/gnark-crypto/ecc/bls24-315"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/pool"
 )
 
 // Domain with a power of 2 cardinality
@@ -63,6 +64,19 @@ type Domain struct {
 	// CosetTableInv = fft.BitReverse(CosetTableInv)
 	CosetTableInv         [][]fr.Element
 	CosetTableInvReversed [][]fr.Element // optional, this is computed on demand at the creation of the domain
+
+	// pool on which the FFTs are run, pool.Default() if nil. It is not serialized.
+	pool *pool.Pool
+}
+
+// Option configures a domain created with NewDomain
+type Option func(*Domain)
+
+// WithPool sets the pool on which the FFTs of the domain are run, instead of pool.Default()
+func WithPool(p *pool.Pool) Option {
+	return func(d *Domain) {
+		d.pool = p
+	}
 }
 
 // NewDomain returns a subgroup with a power of 2 cardinality
@@ -79,7 +93,8 @@ type Domain struct {
 // * NewDomain(m, 2, false) outputs a new domain to perform fft on Z/mZ, plus a primitive
 // 2**2*m=4m-th root of 1 and associated data to compute fft/fftinv on the cosets of
 // (Z/4mZ)/(Z/mZ).
-func NewDomain(m, depth uint64, precomputeReversedTable bool) *Domain {
+// * NewDomain(m, 0, false, WithPool(p)) runs the FFTs of the domain on the pool p.
+func NewDomain(m, depth uint64, precomputeReversedTable bool, opts ...Option) *Domain {
 
 	// generator of the largest 2-adic subgroup
 	var rootOfUnity fr.Element
@@ -94,6 +109,9 @@ func NewDomain(m, depth uint64, precomputeReversedTable bool) *Domain {
 	if precomputeReversedTable {
 		domain.PrecomputeReversedTable = 1
 	}
+	for _, opt := range opts {
+		opt(domain)
+	}
 
 	// find generator for Z/2^(log(m))Z  and Z/2^(log(m)+cosets)Z
 	logx := uint64(bits.TrailingZeros64(x))
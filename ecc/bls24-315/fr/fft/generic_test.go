@@ -0,0 +1,70 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-315"
+)
+
+func TestGenericDomain(t *testing.T) {
+	const size = 1 << 5
+
+	domain := curve.ID.NewDomain(size)
+	if domain.Cardinality() != size {
+		t.Fatal("wrong cardinality")
+	}
+
+	p := make([]*big.Int, size)
+	a := make([]*big.Int, size)
+	for i := 0; i < size; i++ {
+		p[i], _ = rand.Int(rand.Reader, fr.Modulus())
+		a[i] = new(big.Int).Set(p[i])
+	}
+
+	// FFT evaluates p on the successive powers of the generator
+	domain.FFT(a)
+	generator := NewDomain(size, 0, false).Generator
+	var x fr.Element
+	x.SetOne()
+	for i := 0; i < size; i++ {
+		var eval fr.Element
+		for j := size - 1; j >= 0; j-- {
+			var c fr.Element
+			c.SetBigInt(p[j])
+			eval.Mul(&eval, &x).Add(&eval, &c)
+		}
+		var expected big.Int
+		eval.ToBigIntRegular(&expected)
+		if expected.Cmp(a[i]) != 0 {
+			t.Fatal("FFT is not the evaluation on the domain")
+		}
+		x.Mul(&x, &generator)
+	}
+
+	domain.FFTInverse(a)
+	for i := 0; i < size; i++ {
+		if a[i].Cmp(p[i]) != 0 {
+			t.Fatal("FFTInverse(FFT(p)) != p")
+		}
+	}
+}
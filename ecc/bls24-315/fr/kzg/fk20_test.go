@@ -0,0 +1,166 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/polynomial"
+)
+
+func TestOpenAll(t *testing.T) {
+
+	domain := fft.NewDomain(64, 0, false)
+	f := randomPolynomial(60)
+	digest, err := Commit(f, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proofs, err := OpenAll(f, domain, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proofs) != int(domain.Cardinality) {
+		t.Fatal("wrong number of proofs")
+	}
+
+	var point fr.Element
+	point.SetOne()
+	for i := 0; i < len(proofs); i++ {
+		expected, err := Open(f, &point, domain, testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proofs[i].Point.Equal(&expected.Point) || !proofs[i].ClaimedValue.Equal(&expected.ClaimedValue) ||
+			!proofs[i].H.Equal(&expected.H) {
+			t.Fatal("proof differs from the one computed by Open")
+		}
+		if err := Verify(&digest, &proofs[i], testSRS); err != nil {
+			t.Fatal(err)
+		}
+		point.Mul(&point, &domain.Generator)
+	}
+
+	// polynomial larger than the domain
+	if _, err := OpenAll(randomPolynomial(65), domain, testSRS); err != ErrInvalidDomain {
+		t.Fatal("polynomial larger than the domain should be rejected")
+	}
+}
+
+func TestComputeCellProofs(t *testing.T) {
+
+	// extended domain twice as large as the polynomial, as in Danksharding, srs smaller than the
+	// domain, and a polynomial which doesn't fill the cells
+	for _, c := range []struct {
+		polynomialSize, domainSize, cellSize int
+	}{
+		{128, 256, 8},
+		{100, 128, 4},
+		{256, 512, 16},
+		{16, 64, 16},
+		{32, 32, 32},
+	} {
+		domain := fft.NewDomain(uint64(c.domainSize), 0, false)
+		setup, err := NewFK20Setup(testSRS, domain, uint64(c.cellSize))
+		if err != nil {
+			t.Fatal(err)
+		}
+		f := randomPolynomial(c.polynomialSize)
+		proofs, err := setup.ComputeCellProofs(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nbCells := c.domainSize / c.cellSize
+		if len(proofs) != nbCells {
+			t.Fatal("wrong number of proofs")
+		}
+
+		// the proof of the i-th cell commits to the quotient of f by the vanishing polynomial of
+		// ω^i·<ω^(n/k)>
+		var omegaCell fr.Element
+		omegaCell.Exp(domain.Generator, big.NewInt(int64(nbCells)))
+		for i := 0; i < nbCells; i++ {
+			points := make([]fr.Element, c.cellSize)
+			points[0].Exp(domain.Generator, big.NewInt(int64(i)))
+			for j := 1; j < c.cellSize; j++ {
+				points[j].Mul(&points[j-1], &omegaCell)
+			}
+			q := divideByMonic(f.Clone(), vanishingPolynomial(points))
+			expected, err := Commit(q, testSRS)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !proofs[i].Equal(&expected) {
+				t.Fatal("cell proof differs from the commitment to the quotient")
+			}
+		}
+	}
+
+	// invalid parameters
+	domain := fft.NewDomain(64, 0, false)
+	if _, err := NewFK20Setup(testSRS, domain, 3); err != ErrInvalidCellSize {
+		t.Fatal("cell size not dividing the domain cardinality should be rejected")
+	}
+	setup, err := NewFK20Setup(testSRS, domain, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := setup.ComputeCellProofs(polynomial.Polynomial{}); err != ErrInvalidPolynomialSize {
+		t.Fatal("empty polynomial should be rejected")
+	}
+	if _, err := setup.ComputeCellProofs(randomPolynomial(65)); err != ErrInvalidPolynomialSize {
+		t.Fatal("polynomial larger than the domain should be rejected")
+	}
+}
+
+func BenchmarkOpenAll(b *testing.B) {
+	const size = 1 << 10
+	benchSRS, err := NewSRS(ecc.NextPowerOfTwo(size), new(big.Int).SetInt64(42))
+	if err != nil {
+		b.Fatal(err)
+	}
+	domain := fft.NewDomain(size, 0, false)
+	p := randomPolynomial(size)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = OpenAll(p, domain, benchSRS)
+	}
+}
+
+func BenchmarkComputeCellProofs(b *testing.B) {
+	const size = 1 << 12
+	benchSRS, err := NewSRS(ecc.NextPowerOfTwo(size), new(big.Int).SetInt64(42))
+	if err != nil {
+		b.Fatal(err)
+	}
+	setup, err := NewFK20Setup(benchSRS, fft.NewDomain(2*size, 0, false), 64)
+	if err != nil {
+		b.Fatal(err)
+	}
+	p := randomPolynomial(size)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = setup.ComputeCellProofs(p)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/polynomial"
+)
+
+func init() {
+	ecc.RegisterKZG(bls24315.ID, genericKZG{})
+}
+
+// genericKZG implements ecc.KZG
+type genericKZG struct{}
+
+// NewSRS returns a new SRS using alpha as randomness source
+func (genericKZG) NewSRS(size uint64, alpha *big.Int) (ecc.SRS, error) {
+	srs, err := NewSRS(size, alpha)
+	if err != nil {
+		return nil, err
+	}
+	return srs, nil
+}
+
+// NewEmptySRS returns an empty SRS
+func (genericKZG) NewEmptySRS() ecc.SRS {
+	return &SRS{}
+}
+
+// Commit commits to the polynomial p
+func (genericKZG) Commit(p []*big.Int, srs ecc.SRS) (ecc.Point, error) {
+	digest, err := Commit(toPolynomial(p), srs.(*SRS))
+	if err != nil {
+		return nil, err
+	}
+	return (*bls24315.GenericG1Affine)(&digest), nil
+}
+
+// Open computes an opening proof of the polynomial p at point
+func (genericKZG) Open(p []*big.Int, point *big.Int, srs ecc.SRS) (ecc.OpeningProof, error) {
+	var res ecc.OpeningProof
+	_p := toPolynomial(p)
+	var _point fr.Element
+	_point.SetBigInt(point)
+
+	// Open only uses the cardinality of the domain, to check the size of p
+	domain := fft.Domain{Cardinality: uint64(len(p))}
+
+	proof, err := Open(_p, &_point, &domain, srs.(*SRS))
+	if err != nil {
+		return res, err
+	}
+	res.H = (*bls24315.GenericG1Affine)(&proof.H)
+	proof.Point.ToBigIntRegular(&res.Point)
+	proof.ClaimedValue.ToBigIntRegular(&res.ClaimedValue)
+	return res, nil
+}
+
+// Verify verifies an opening proof of the polynomial of the given commitment
+func (genericKZG) Verify(commitment ecc.Point, proof *ecc.OpeningProof, srs ecc.SRS) error {
+	_proof := OpeningProof{
+		H: *(*bls24315.G1Affine)(proof.H.(*bls24315.GenericG1Affine)),
+	}
+	_proof.Point.SetBigInt(&proof.Point)
+	_proof.ClaimedValue.SetBigInt(&proof.ClaimedValue)
+	return Verify((*Digest)(commitment.(*bls24315.GenericG1Affine)), &_proof, srs.(*SRS))
+}
+
+func toPolynomial(p []*big.Int) polynomial.Polynomial {
+	res := make(polynomial.Polynomial, len(p))
+	for i := 0; i < len(p); i++ {
+		res[i].SetBigInt(p[i])
+	}
+	return res
+}
@@ -17,10 +17,10 @@
 package kzg
 
 import (
+	"context"
 	"errors"
 	"hash"
 	"math/big"
-	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315"
@@ -28,6 +28,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/polynomial"
 	"github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/pool"
 )
 
 var (
@@ -37,6 +38,16 @@ var (
 	ErrVerifyBatchOpeningSinglePoint = errors.New("can't verify batch opening proof at single point")
 	ErrInvalidDomain                 = errors.New("domain cardinality is smaller than polynomial degree")
 	ErrMinSRSSize                    = errors.New("minimum srs size is 2")
+	ErrLagrangeSRSSize               = errors.New("srs is smaller than the domain cardinality")
+	ErrInvalidNbEvaluations          = errors.New("number of evaluations is not the domain cardinality")
+	ErrInvalidContributionSize       = errors.New("contribution size differs from the previous one")
+	ErrInvalidContributionHash       = errors.New("contribution hash doesn't chain the transcript")
+	ErrInvalidPoK                    = errors.New("invalid proof of knowledge of the contribution")
+	ErrInvalidContributionUpdate     = errors.New("contribution doesn't update the previous secret")
+	ErrInvalidPowers                 = errors.New("srs points are not successive powers of the secret")
+	ErrInvalidNbPoints               = errors.New("number of points is not the same as the number of polynomials or claimed values")
+	ErrInvalidPoints                 = errors.New("points of a polynomial must be a non empty set of distinct points")
+	ErrInvalidCellSize               = errors.New("cell size must divide the domain cardinality")
 )
 
 // Digest commitment of a polynomial.
@@ -110,8 +121,40 @@ type BatchOpeningProof struct {
 	ClaimedValues []fr.Element
 }
 
+// Option configures CommitCtx, Open, OpenCtx, BatchOpenSinglePoint, NewLagrangeSRS, OpenLagrange,
+// NewFK20Setup, OpenAll and the PowersOfTau methods
+type Option func(*options)
+
+type options struct {
+	progress ecc.Progress
+	pool     *pool.Pool
+}
+
+// WithProgress reports the progress of the multi exponentiations to progress
+func WithProgress(progress ecc.Progress) Option {
+	return func(o *options) {
+		o.progress = progress
+	}
+}
+
+// WithPool runs the computations on the pool p, instead of pool.Default()
+func WithPool(p *pool.Pool) Option {
+	return func(o *options) {
+		o.pool = p
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
 // Commit commits to a polynomial using a multi exponentiation with the SRS.
 // It is assumed that the polynomial is in canonical form, in Montgomery form.
+// The multi exponentiation runs on pool.Default(); see CommitCtx to set the pool.
 func Commit(p polynomial.Polynomial, srs *SRS, nbTasks ...int) (Digest, error) {
 
 	if len(p) == 0 || len(p) > len(srs.G1) {
@@ -131,9 +174,33 @@ func Commit(p polynomial.Polynomial, srs *SRS, nbTasks ...int) (Digest, error) {
 	return res, nil
 }
 
+// CommitCtx is Commit, and stops early when ctx is done, in which case it returns ctx.Err().
+// The progress and the pool of the multi exponentiation are set with WithProgress and WithPool.
+func CommitCtx(ctx context.Context, p polynomial.Polynomial, srs *SRS, opts ...Option) (Digest, error) {
+
+	if len(p) == 0 || len(p) > len(srs.G1) {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+
+	var res bls24315.G1Affine
+
+	o := newOptions(opts)
+	config := ecc.MultiExpConfig{ScalarsMont: true, Context: ctx, Progress: o.progress, Pool: o.pool}
+	if _, err := res.MultiExp(srs.G1[:len(p)], p, config); err != nil {
+		return Digest{}, err
+	}
+
+	return res, nil
+}
+
 // Open computes an opening proof of polynomial p at given point.
 // fft.Domain Cardinality must be larger than p.Degree()
-func Open(p polynomial.Polynomial, point *fr.Element, domain *fft.Domain, srs *SRS) (OpeningProof, error) {
+func Open(p polynomial.Polynomial, point *fr.Element, domain *fft.Domain, srs *SRS, opts ...Option) (OpeningProof, error) {
+	return OpenCtx(context.Background(), p, point, domain, srs, opts...)
+}
+
+// OpenCtx is Open, and stops early when ctx is done, in which case it returns ctx.Err().
+func OpenCtx(ctx context.Context, p polynomial.Polynomial, point *fr.Element, domain *fft.Domain, srs *SRS, opts ...Option) (OpeningProof, error) {
 	if len(p) == 0 || len(p) > len(srs.G1) {
 		return OpeningProof{}, ErrInvalidPolynomialSize
 	}
@@ -155,7 +222,7 @@ func Open(p polynomial.Polynomial, point *fr.Element, domain *fft.Domain, srs *S
 	_p = nil // h re-use this memory
 
 	// commit to H
-	hCommit, err := Commit(h, srs)
+	hCommit, err := CommitCtx(ctx, h, srs, opts...)
 	if err != nil {
 		return OpeningProof{}, err
 	}
@@ -220,7 +287,7 @@ func Verify(commitment *Digest, proof *OpeningProof, srs *SRS) error {
 // point is the point at which the polynomials are opened.
 // digests is the list of committed polynomials to open, need to derive the challenge using Fiat Shamir.
 // polynomials is the list of polynomials to open.
-func BatchOpenSinglePoint(polynomials []polynomial.Polynomial, digests []Digest, point *fr.Element, hf hash.Hash, domain *fft.Domain, srs *SRS) (BatchOpeningProof, error) {
+func BatchOpenSinglePoint(polynomials []polynomial.Polynomial, digests []Digest, point *fr.Element, hf hash.Hash, domain *fft.Domain, srs *SRS, opts ...Option) (BatchOpeningProof, error) {
 
 	// check for invalid sizes
 	nbDigests := len(digests)
@@ -244,14 +311,11 @@ func BatchOpenSinglePoint(polynomials []polynomial.Polynomial, digests []Digest,
 
 	// compute the purported values
 	res.ClaimedValues = make([]fr.Element, len(polynomials))
-	var wg sync.WaitGroup
-	wg.Add(len(polynomials))
-	for i := 0; i < len(polynomials); i++ {
-		go func(at int) {
-			res.ClaimedValues[at] = polynomials[at].Eval(point)
-			wg.Done()
-		}(i)
-	}
+	newOptions(opts).pool.Execute(len(polynomials), func(start, end int) {
+		for i := start; i < end; i++ {
+			res.ClaimedValues[i] = polynomials[i].Eval(point)
+		}
+	})
 
 	// set the point at which the evaluation is done
 	res.Point = *point
@@ -263,18 +327,11 @@ func BatchOpenSinglePoint(polynomials []polynomial.Polynomial, digests []Digest,
 	}
 
 	// compute sum_i gamma**i*f(a)
-	var sumGammaiTimesEval fr.Element
-	chSumGammai := make(chan struct{}, 1)
-	go func() {
-		// wait for polynomial evaluations to be completed (res.ClaimedValues)
-		wg.Wait()
-		sumGammaiTimesEval = res.ClaimedValues[nbDigests-1]
-		for i := nbDigests - 2; i >= 0; i-- {
-			sumGammaiTimesEval.Mul(&sumGammaiTimesEval, &gamma).
-				Add(&sumGammaiTimesEval, &res.ClaimedValues[i])
-		}
-		close(chSumGammai)
-	}()
+	sumGammaiTimesEval := res.ClaimedValues[nbDigests-1]
+	for i := nbDigests - 2; i >= 0; i-- {
+		sumGammaiTimesEval.Mul(&sumGammaiTimesEval, &gamma).
+			Add(&sumGammaiTimesEval, &res.ClaimedValues[i])
+	}
 
 	// compute sum_i gamma**i*f
 	// that is p0 + gamma * p1 + gamma^2 * p2 + ... gamma^n * pn
@@ -293,11 +350,10 @@ func BatchOpenSinglePoint(polynomials []polynomial.Polynomial, digests []Digest,
 	}
 
 	// compute H
-	<-chSumGammai
 	h := dividePolyByXminusA(sumGammaiTimesPol, sumGammaiTimesEval, res.Point)
 	sumGammaiTimesPol = nil // same memory as h
 
-	res.H, err = Commit(h, srs)
+	res.H, err = CommitCtx(context.Background(), h, srs, opts...)
 	if err != nil {
 		return BatchOpeningProof{}, err
 	}
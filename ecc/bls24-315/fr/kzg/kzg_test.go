@@ -18,6 +18,7 @@ package kzg
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
 	"math/big"
 	"reflect"
@@ -28,6 +29,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/polynomial"
+	"github.com/consensys/gnark-crypto/pool"
 )
 
 // testSRS re-used accross tests of the KZG scheme
@@ -141,6 +143,108 @@ func TestCommit(t *testing.T) {
 
 }
 
+func TestCommitCtx(t *testing.T) {
+
+	f := randomPolynomial(60)
+	expected, err := Commit(f, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a live context gives the same commitment, and the progress reaches the total
+	lastDone, lastTotal, monotonic := 0, 0, true
+	digest, err := CommitCtx(context.Background(), f, testSRS, WithProgress(func(done, total int) {
+		monotonic = monotonic && done > lastDone && done <= total
+		lastDone, lastTotal = done, total
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !digest.Equal(&expected) {
+		t.Fatal("CommitCtx differs from Commit")
+	}
+	if !monotonic || lastDone == 0 || lastDone != lastTotal {
+		t.Fatal("progress should increase up to total")
+	}
+
+	// a cancelled context stops the commitment and the opening
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := CommitCtx(ctx, f, testSRS); err != context.Canceled {
+		t.Fatal("CommitCtx should return the context error")
+	}
+	var point fr.Element
+	point.SetRandom()
+	if _, err := OpenCtx(ctx, f, &point, fft.NewDomain(64, 0, false), testSRS); err != context.Canceled {
+		t.Fatal("OpenCtx should return the context error")
+	}
+}
+
+func TestCommitOpenWithPool(t *testing.T) {
+
+	p := pool.New(2)
+	defer p.Stop()
+	domain := fft.NewDomain(64, 0, false)
+
+	f := randomPolynomial(60)
+	expected, err := Commit(f, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest, err := CommitCtx(context.Background(), f, testSRS, WithPool(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !digest.Equal(&expected) {
+		t.Fatal("commitment on a custom pool differs from Commit")
+	}
+
+	var point fr.Element
+	point.SetRandom()
+	expectedProof, err := Open(f, &point, domain, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := Open(f, &point, domain, testSRS, WithPool(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proof.H.Equal(&expectedProof.H) || !proof.ClaimedValue.Equal(&expectedProof.ClaimedValue) {
+		t.Fatal("opening proof on a custom pool differs from Open")
+	}
+	if err := Verify(&digest, &proof, testSRS); err != nil {
+		t.Fatal(err)
+	}
+
+	// Lagrange basis
+	lagrangeSRS, err := NewLagrangeSRS(testSRS, domain, WithPool(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluations := make([]fr.Element, domain.Cardinality)
+	copy(evaluations, f)
+	domain.FFT(evaluations, fft.DIF, 0)
+	fft.BitReverse(evaluations)
+	proof, err = OpenLagrange(evaluations, &point, domain, lagrangeSRS, WithPool(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proof.H.Equal(&expectedProof.H) || !proof.ClaimedValue.Equal(&expectedProof.ClaimedValue) {
+		t.Fatal("Lagrange opening proof on a custom pool differs from Open")
+	}
+
+	// FK20
+	proofs, err := OpenAll(f, domain, testSRS, WithPool(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range proofs {
+		if err := Verify(&digest, &proofs[i], testSRS); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
 func TestVerifySinglePoint(t *testing.T) {
 
 	domain := fft.NewDomain(64, 0, false)
@@ -291,6 +395,96 @@ func TestBatchVerifyMultiPoints(t *testing.T) {
 
 }
 
+func TestLagrangeSRS(t *testing.T) {
+
+	domain := fft.NewDomain(64, 0, false)
+	lagrangeSRS, err := NewLagrangeSRS(testSRS, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// [L_i(alpha)]gen = [f_i(alpha)]gen where f_i is the ifft of the i-th canonical vector
+	for _, i := range []int{0, 1, 17, 63} {
+		f := make(polynomial.Polynomial, domain.Cardinality)
+		f[i].SetOne()
+		domain.FFTInverse(f, fft.DIF, 0)
+		fft.BitReverse(f)
+		expected, err := Commit(f, testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !expected.Equal(&lagrangeSRS.G1[i]) {
+			t.Fatalf("wrong Lagrange basis element %d", i)
+		}
+	}
+
+	// the srs must be larger than the domain
+	if _, err := NewLagrangeSRS(testSRS, fft.NewDomain(2*uint64(len(testSRS.G1)), 0, false)); err != ErrLagrangeSRSSize {
+		t.Fatal("srs smaller than the domain should be rejected")
+	}
+}
+
+func TestCommitLagrange(t *testing.T) {
+
+	domain := fft.NewDomain(64, 0, false)
+	lagrangeSRS, err := NewLagrangeSRS(testSRS, domain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// evaluations of a random polynomial on the domain
+	f := randomPolynomial(60)
+	evaluations := make([]fr.Element, domain.Cardinality)
+	copy(evaluations, f)
+	domain.FFT(evaluations, fft.DIF, 0)
+	fft.BitReverse(evaluations)
+
+	digest, err := Commit(f, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digestLagrange, err := CommitLagrange(evaluations, lagrangeSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !digest.Equal(&digestLagrange) {
+		t.Fatal("commitments in canonical and Lagrange basis differ")
+	}
+
+	// compute opening proofs at a random point, and at a point of the domain
+	var point fr.Element
+	point.SetString("4321")
+	for _, p := range []fr.Element{point, domain.Generator} {
+		proof, err := OpenLagrange(evaluations, &p, domain, lagrangeSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// verify the claimed valued
+		expected := f.Eval(&p)
+		if !proof.ClaimedValue.Equal(&expected) {
+			t.Fatal("inconsistant claimed value")
+		}
+
+		// verify correct proof
+		err = Verify(&digest, &proof, testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// verify wrong proof
+		proof.ClaimedValue.Double(&proof.ClaimedValue)
+		err = Verify(&digest, &proof, testSRS)
+		if err == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+	}
+
+	if _, err := CommitLagrange(evaluations[1:], lagrangeSRS); err != ErrInvalidNbEvaluations {
+		t.Fatal("wrong number of evaluations should be rejected")
+	}
+}
+
 const benchSize = 1 << 16
 
 func BenchmarkKZGCommit(b *testing.B) {
@@ -346,6 +540,28 @@ func BenchmarkKZGOpen(b *testing.B) {
 	}
 }
 
+func BenchmarkKZGOpenLagrange(b *testing.B) {
+	benchSRS, err := NewSRS(ecc.NextPowerOfTwo(benchSize), new(big.Int).SetInt64(42))
+	if err != nil {
+		b.Fatal(err)
+	}
+	domain := fft.NewDomain(uint64(benchSize), 0, false)
+	lagrangeSRS, err := NewLagrangeSRS(benchSRS, domain)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// random polynomial in Lagrange form
+	p := randomPolynomial(benchSize)
+	var r fr.Element
+	r.SetRandom()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = OpenLagrange(p, &r, domain, lagrangeSRS)
+	}
+}
+
 func BenchmarkKZGVerify(b *testing.B) {
 	benchSRS, err := NewSRS(ecc.NextPowerOfTwo(benchSize), new(big.Int).SetInt64(42))
 	if err != nil {
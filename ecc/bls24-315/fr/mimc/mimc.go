@@ -17,8 +17,11 @@
 package mimc
 
 import (
+	"errors"
 	"hash"
+	"math"
 	"math/big"
+	"math/bits"
 
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
 	"golang.org/x/crypto/sha3"
@@ -26,6 +29,96 @@ import (
 
 const mimcNbRounds = 91
 
+// defaultExponent is the exponent of the s-box of NewMiMC without options, -1 standing for the inversion
+// note that gcd(5, r-1) != 1: x -> x^5 is not a permutation of fr, see WithExponent
+const defaultExponent = 5
+
+var (
+	ErrInvalidExponent = errors.New("invalid exponent: x -> x^e should be a permutation of fr, with e >= 3")
+	ErrInvalidNbRounds = errors.New("invalid number of rounds")
+)
+
+// Option configures the exponent and the number of rounds of the MiMC permutation
+type Option func(*mimcConfig)
+
+// mimcConfig is the configuration of the MiMC permutation set by the options
+type mimcConfig struct {
+	exponent int // exponent of the s-box, -1 for the inversion
+	nbRounds int // 0 to derive it from the exponent
+}
+
+// WithExponent sets the exponent e of the s-box x -> x^e, which must be coprime with r-1
+// If the number of rounds is not set, it is derived from the size of the field: ceil(log_e(r)) for
+// the Miyaguchi–Preneel construction, and twice as many for the Feistel sponge.
+func WithExponent(e int) Option {
+	return func(cfg *mimcConfig) {
+		cfg.exponent = e
+	}
+}
+
+// WithNbRounds sets the number of rounds of the permutation
+func WithNbRounds(nbRounds int) Option {
+	return func(cfg *mimcConfig) {
+		cfg.nbRounds = nbRounds
+	}
+}
+
+// newConfig returns the configuration set by opts. Without exponent option, the exponent is
+// defaultExponent and the number of rounds defaults to defaultNbRounds, otherwise the number of rounds
+// defaults to roundsFactor·ceil(log_e(r)).
+func newConfig(defaultExponent, defaultNbRounds, roundsFactor int, opts ...Option) (mimcConfig, error) {
+	var cfg mimcConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.exponent == 0 {
+		cfg.exponent = defaultExponent
+		if cfg.nbRounds == 0 {
+			cfg.nbRounds = defaultNbRounds
+		}
+	} else {
+		if cfg.exponent < 3 || !isPermutationExponent(cfg.exponent) {
+			return cfg, ErrInvalidExponent
+		}
+		if cfg.nbRounds == 0 {
+			cfg.nbRounds = roundsFactor * int(math.Ceil(float64(fr.Bits)/math.Log2(float64(cfg.exponent))))
+		}
+	}
+	if cfg.nbRounds < 1 {
+		return cfg, ErrInvalidNbRounds
+	}
+	return cfg, nil
+}
+
+// isPermutationExponent returns true if x -> x^e is a permutation of fr, i.e. gcd(e, r-1) = 1
+func isPermutationExponent(e int) bool {
+	var rMinus1, gcd big.Int
+	rMinus1.Sub(fr.Modulus(), big.NewInt(1))
+	gcd.GCD(nil, nil, big.NewInt(int64(e)), &rMinus1)
+	return gcd.IsInt64() && gcd.Int64() == 1
+}
+
+// sBox sets x to x^e, or to 1/x if e = -1
+func sBox(x *fr.Element, e int) {
+	switch e {
+	case -1:
+		x.Inverse(x)
+	case 5:
+		var tmp fr.Element
+		tmp.Square(x).Square(&tmp)
+		x.Mul(x, &tmp)
+	default:
+		// square and multiply, from the most significant bit of e
+		base := *x
+		for i := bits.Len(uint(e)) - 2; i >= 0; i-- {
+			x.Square(x)
+			if (e>>i)&1 == 1 {
+				x.Mul(x, &base)
+			}
+		}
+	}
+}
+
 // BlockSize size that mimc consumes
 const BlockSize = fr.Bytes
 
@@ -34,14 +127,19 @@ type Params []fr.Element
 
 // NewParams creates new mimc object
 func NewParams(seed string) Params {
+	return newParams(seed, mimcNbRounds)
+}
+
+// newParams derives nbRounds constants from seed, by iterating sha3
+func newParams(seed string, nbRounds int) Params {
 
 	// set the constants
-	res := make(Params, mimcNbRounds)
+	res := make(Params, nbRounds)
 
 	rnd := sha3.Sum256([]byte(seed))
 	value := new(big.Int).SetBytes(rnd[:])
 
-	for i := 0; i < mimcNbRounds; i++ {
+	for i := 0; i < nbRounds; i++ {
 		rnd = sha3.Sum256(value.Bytes())
 		value.SetBytes(rnd[:])
 		res[i].SetBigInt(value)
@@ -53,9 +151,10 @@ func NewParams(seed string) Params {
 // digest represents the partial evaluation of the checksum
 // along with the params of the mimc function
 type digest struct {
-	Params Params
-	h      fr.Element
-	data   []byte // data to hash
+	Params   Params
+	h        fr.Element
+	data     []byte // data to hash
+	exponent int    // exponent of the s-box, -1 for the inversion
 }
 
 // NewMiMC returns a MiMCImpl object, pure-go reference implementation
@@ -64,10 +163,25 @@ func NewMiMC(seed string) hash.Hash {
 	params := NewParams(seed)
 	//d.Reset()
 	d.Params = params
+	d.exponent = defaultExponent
 	d.Reset()
 	return d
 }
 
+// New returns the MiMC hash function of NewMiMC, with the exponent and the number of rounds set by opts
+// Without options, it is the hash function of NewMiMC: 91 rounds of x^5.
+func New(seed string, opts ...Option) (hash.Hash, error) {
+	cfg, err := newConfig(defaultExponent, mimcNbRounds, 1, opts...)
+	if err != nil {
+		return nil, err
+	}
+	d := new(digest)
+	d.Params = newParams(seed, cfg.nbRounds)
+	d.exponent = cfg.exponent
+	d.Reset()
+	return d, nil
+}
+
 // Reset resets the Hash to its initial state.
 func (d *digest) Reset() {
 	d.data = nil
@@ -149,12 +263,9 @@ func (d *digest) checksum() fr.Element {
 func (d *digest) encrypt(m fr.Element) {
 
 	for i := 0; i < len(d.Params); i++ {
-		// m = (m+k+c)^5
-		var tmp fr.Element
-		tmp.Add(&m, &d.h).Add(&tmp, &d.Params[i])
-		m.Square(&tmp).
-			Square(&m).
-			Mul(&m, &tmp)
+		// m = (m+k+c)^e
+		m.Add(&m, &d.h).Add(&m, &d.Params[i])
+		sBox(&m, d.exponent)
 	}
 	m.Add(&m, &d.h)
 	d.h = m
@@ -165,6 +276,7 @@ func Sum(seed string, msg []byte) ([]byte, error) {
 	params := NewParams(seed)
 	var d digest
 	d.Params = params
+	d.exponent = defaultExponent
 	if _, err := d.Write(msg); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,546 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24315
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// window sizes of the multi exponentiations with precomputed tables
+// as in MultiExp, c >= 4 so that the most significant chunk absorbs the carry of the signed digits
+const (
+	minPrecomputedC = 4
+	maxPrecomputedC = 16
+)
+
+var errInvalidPrecomputedTable = errors.New("invalid precomputed table")
+
+// precomputedParameters returns the window size c and the stride minimizing the cost of a multi
+// exponentiation of nbPoints precomputed points, with tables of at most maxMemory bytes
+// (no limit if maxMemory == 0)
+func precomputedParameters(nbPoints int, pointSize, maxMemory uint64) (bestC, bestStride uint64) {
+	minCost := math.MaxFloat64
+	for c := uint64(minPrecomputedC); c <= maxPrecomputedC; c++ {
+		nbChunks := (fr.Limbs*64 + c - 1) / c
+
+		// the stride is the smallest one such that the tables fit in maxMemory
+		stride := uint64(1)
+		if maxMemory != 0 {
+			maxRows := maxMemory / (uint64(nbPoints) * pointSize)
+			if maxRows == 0 {
+				maxRows = 1
+			}
+			stride = (nbChunks + maxRows - 1) / maxRows
+		}
+		nbRows := (nbChunks + stride - 1) / stride
+
+		// approximate cost (in group operations)
+		// cost = stride * (nbRows * nbPoints + 2^c)
+		cost := float64(stride * (nbRows*uint64(nbPoints) + (1 << c)))
+		if cost < minCost {
+			minCost = cost
+			bestC = c
+			bestStride = stride
+		}
+	}
+	return
+}
+
+// MultiExpPrecomputedG1 precomputed tables to compute multi exponentiations with
+// fixed bases, for example with the points of a KZG SRS.
+//
+// The scalars are split in nbChunks c-bit signed digits d_j, as in MultiExp, and
+// Σ_i s_i·P_i = Σ_j Σ_i d_(i,j)·[2^(jc)]P_i. The tables store the points [2^(qhc)]P_i, for a stride h,
+// so that the multi exponentiation is h bucket passes over ⌈nbChunks/h⌉ points per base, instead of
+// nbChunks bucket passes over the bases with MultiExp. A stride of 1 needs the most memory and
+// the fewest operations, larger strides fit smaller memory budgets.
+type MultiExpPrecomputedG1 struct {
+	c        uint64 // window size
+	stride   uint64 // h
+	nbPoints int
+	table    []G1Affine // table[q*nbPoints+i] = [2^(q*stride*c)]points[i]
+}
+
+// NewMultiExpPrecomputedG1 precomputes the tables to compute multi exponentiations of points,
+// using at most maxMemory bytes (no limit if maxMemory == 0). The tables store at least the points,
+// even if they don't fit in maxMemory. The tables are computed on pool.Default().
+func NewMultiExpPrecomputedG1(points []G1Affine, maxMemory uint64) *MultiExpPrecomputedG1 {
+	t := MultiExpPrecomputedG1{nbPoints: len(points)}
+	if len(points) == 0 {
+		t.c, t.stride = minPrecomputedC, 1
+		return &t
+	}
+	t.c, t.stride = precomputedParameters(len(points), SizeOfG1AffineUncompressed, maxMemory)
+	nbRows := t.nbRows()
+	t.table = make([]G1Affine, nbRows*len(points))
+	copy(t.table, points)
+
+	// row q is row q-1 doubled stride*c times
+	nbDoublings := int(t.stride * t.c)
+	parallel.Execute(len(points), func(start, end int) {
+		tmp := make([]G1Jac, end-start)
+		for q := 1; q < nbRows; q++ {
+			previous := t.table[(q-1)*len(points)+start : (q-1)*len(points)+end]
+			for i := range tmp {
+				tmp[i].FromAffine(&previous[i])
+				for k := 0; k < nbDoublings; k++ {
+					tmp[i].DoubleAssign()
+				}
+			}
+			row := t.table[q*len(points)+start : q*len(points)+end]
+			BatchJacobianToAffineG1(tmp, row)
+		}
+	})
+
+	return &t
+}
+
+// nbRows returns the number of precomputed points per base
+func (t *MultiExpPrecomputedG1) nbRows() int {
+	nbChunks := (fr.Limbs*64 + t.c - 1) / t.c
+	return int((nbChunks + t.stride - 1) / t.stride)
+}
+
+// NbPoints returns the number of bases of the precomputed tables
+func (t *MultiExpPrecomputedG1) NbPoints() int {
+	return t.nbPoints
+}
+
+// WriteTo writes the precomputed tables, with raw points
+func (t *MultiExpPrecomputedG1) WriteTo(w io.Writer) (int64, error) {
+	header := []uint64{t.c, t.stride, uint64(t.nbPoints)}
+	if err := binary.Write(w, binary.BigEndian, header); err != nil {
+		return 0, err
+	}
+	enc := NewEncoder(w, RawEncoding())
+	err := enc.Encode(t.table)
+	return enc.BytesWritten() + 8*int64(len(header)), err
+}
+
+// ReadFrom reads precomputed tables written by WriteTo
+func (t *MultiExpPrecomputedG1) ReadFrom(r io.Reader) (int64, error) {
+	var header [3]uint64
+	if err := binary.Read(r, binary.BigEndian, header[:]); err != nil {
+		return 0, err
+	}
+	n := int64(8 * len(header))
+	t.c, t.stride, t.nbPoints = header[0], header[1], int(header[2])
+	if t.c < minPrecomputedC || t.c > maxPrecomputedC || t.stride == 0 || t.stride > (fr.Limbs*64+t.c-1)/t.c {
+		return n, errInvalidPrecomputedTable
+	}
+
+	dec := NewDecoder(r)
+	err := dec.Decode(&t.table)
+	n += dec.BytesRead()
+	if err != nil {
+		return n, err
+	}
+	if len(t.table) != t.nbRows()*t.nbPoints {
+		return n, errInvalidPrecomputedTable
+	}
+	return n, nil
+}
+
+// MultiExpPrecomputed computes the multi exponentiation of the bases of table by scalars
+func (p *G1Affine) MultiExpPrecomputed(table *MultiExpPrecomputedG1, scalars []fr.Element, config ecc.MultiExpConfig) (*G1Affine, error) {
+	var _p G1Jac
+	if _, err := _p.MultiExpPrecomputed(table, scalars, config); err != nil {
+		return nil, err
+	}
+	p.FromJacobian(&_p)
+	return p, nil
+}
+
+// MultiExpPrecomputed computes the multi exponentiation of the bases of table by scalars
+func (p *G1Jac) MultiExpPrecomputed(table *MultiExpPrecomputedG1, scalars []fr.Element, config ecc.MultiExpConfig) (*G1Jac, error) {
+	if len(scalars) != table.nbPoints {
+		return nil, errors.New("len(scalars) != number of precomputed points")
+	}
+
+	// if nbTasks is not set, use all the workers of the pool
+	if config.NbTasks <= 0 {
+		config.NbTasks = config.Pool.NbWorkers()
+	}
+
+	p.Set(&g1Infinity)
+	if table.nbPoints == 0 {
+		return p, nil
+	}
+
+	c := table.c
+	stride := int(table.stride)
+	nbChunks := int((fr.Limbs*64 + c - 1) / c)
+	nbRows := table.nbRows()
+	digits := partitionScalars(scalars, c, config.ScalarsMont, config.NbTasks, config.Pool)
+
+	// each of the stride bucket passes is split over the bases
+	nbSplits := config.NbTasks / stride
+	if nbSplits < 1 {
+		nbSplits = 1
+	}
+	if nbSplits > table.nbPoints {
+		nbSplits = table.nbPoints
+	}
+	totals := make([]g1JacExtended, stride*nbSplits)
+
+	// stride*nbSplits tasks are processed
+	ctx := newMSMContext(config, stride*nbSplits)
+	if config.Context != nil {
+		if err := config.Context.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// task r*nbSplits+k processes the pass r over the k-th split of the bases
+	ctx.execute(len(totals), func(startTask, endTask int) {
+		for task := startTask; task < endTask; task++ {
+			r, k := task/nbSplits, task%nbSplits
+			start := k * table.nbPoints / nbSplits
+			end := (k + 1) * table.nbPoints / nbSplits
+
+			// chunks r, r + stride, r + 2 stride... are processed with rows 0, 1, 2...
+			selectors := make([]selector, 0, nbRows)
+			for j := r; j < nbChunks; j += stride {
+				selectors = append(selectors, newSelector(uint64(j), c))
+			}
+
+			msmProcessPrecomputedG1Affine(&totals[task], c, table, selectors, digits, start, end, ctx)
+		}
+	}, len(totals))
+	if config.Context != nil {
+		if err := config.Context.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// p = Σ_r [2^(rc)]Σ_k totals[r][k]
+	var _p g1JacExtended
+	_p.setInfinity()
+	for r := stride - 1; r >= 0; r-- {
+		for l := 0; l < int(c); l++ {
+			_p.double(&_p)
+		}
+		for k := 0; k < nbSplits; k++ {
+			_p.add(&totals[r*nbSplits+k])
+		}
+	}
+
+	return p.unsafeFromJacExtended(&_p), nil
+}
+
+// msmProcessPrecomputedG1Affine sets total to the weighted sum of the buckets of the digits
+// selected by selectors[q] of the bases start..end, with the table row q
+func msmProcessPrecomputedG1Affine(total *g1JacExtended,
+	c uint64,
+	table *MultiExpPrecomputedG1,
+	selectors []selector,
+	digits []fr.Element,
+	start, end int,
+	ctx *msmContext) {
+
+	msbWindow := uint64(1 << (c - 1))
+	buckets := make([]g1JacExtended, 1<<(c-1))
+	for i := 0; i < len(buckets); i++ {
+		buckets[i].setInfinity()
+	}
+
+	for q, s := range selectors {
+		points := table.table[q*table.nbPoints : (q+1)*table.nbPoints]
+		for i := start; i < end; i++ {
+			if (i-start)%msmCancellationPeriod == 0 && ctx.cancelled() {
+				break
+			}
+			bits := (digits[i][s.index] & s.mask) >> s.shift
+			if s.multiWordSelect {
+				bits += (digits[i][s.index+1] & s.maskHigh) << s.shiftHigh
+			}
+
+			if bits == 0 {
+				continue
+			}
+
+			// if msbWindow bit is set, we need to substract
+			if bits&msbWindow == 0 {
+				// add
+				buckets[bits-1].addMixed(&points[i])
+			} else {
+				// sub
+				buckets[bits & ^msbWindow].subMixed(&points[i])
+			}
+		}
+	}
+
+	// reduce buckets into total
+	// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+	var runningSum g1JacExtended
+	runningSum.setInfinity()
+	total.setInfinity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		if !buckets[k].ZZ.IsZero() {
+			runningSum.add(&buckets[k])
+		}
+		total.add(&runningSum)
+	}
+	ctx.taskDone()
+}
+
+// MultiExpPrecomputedG2 precomputed tables to compute multi exponentiations with
+// fixed bases, for example with the points of a KZG SRS.
+//
+// The scalars are split in nbChunks c-bit signed digits d_j, as in MultiExp, and
+// Σ_i s_i·P_i = Σ_j Σ_i d_(i,j)·[2^(jc)]P_i. The tables store the points [2^(qhc)]P_i, for a stride h,
+// so that the multi exponentiation is h bucket passes over ⌈nbChunks/h⌉ points per base, instead of
+// nbChunks bucket passes over the bases with MultiExp. A stride of 1 needs the most memory and
+// the fewest operations, larger strides fit smaller memory budgets.
+type MultiExpPrecomputedG2 struct {
+	c        uint64 // window size
+	stride   uint64 // h
+	nbPoints int
+	table    []G2Affine // table[q*nbPoints+i] = [2^(q*stride*c)]points[i]
+}
+
+// NewMultiExpPrecomputedG2 precomputes the tables to compute multi exponentiations of points,
+// using at most maxMemory bytes (no limit if maxMemory == 0). The tables store at least the points,
+// even if they don't fit in maxMemory. The tables are computed on pool.Default().
+func NewMultiExpPrecomputedG2(points []G2Affine, maxMemory uint64) *MultiExpPrecomputedG2 {
+	t := MultiExpPrecomputedG2{nbPoints: len(points)}
+	if len(points) == 0 {
+		t.c, t.stride = minPrecomputedC, 1
+		return &t
+	}
+	t.c, t.stride = precomputedParameters(len(points), SizeOfG2AffineUncompressed, maxMemory)
+	nbRows := t.nbRows()
+	t.table = make([]G2Affine, nbRows*len(points))
+	copy(t.table, points)
+
+	// row q is row q-1 doubled stride*c times
+	nbDoublings := int(t.stride * t.c)
+	parallel.Execute(len(points), func(start, end int) {
+		tmp := make([]G2Jac, end-start)
+		for q := 1; q < nbRows; q++ {
+			previous := t.table[(q-1)*len(points)+start : (q-1)*len(points)+end]
+			for i := range tmp {
+				tmp[i].FromAffine(&previous[i])
+				for k := 0; k < nbDoublings; k++ {
+					tmp[i].DoubleAssign()
+				}
+			}
+			row := t.table[q*len(points)+start : q*len(points)+end]
+			for i := range tmp {
+				row[i].FromJacobian(&tmp[i])
+			}
+		}
+	})
+
+	return &t
+}
+
+// nbRows returns the number of precomputed points per base
+func (t *MultiExpPrecomputedG2) nbRows() int {
+	nbChunks := (fr.Limbs*64 + t.c - 1) / t.c
+	return int((nbChunks + t.stride - 1) / t.stride)
+}
+
+// NbPoints returns the number of bases of the precomputed tables
+func (t *MultiExpPrecomputedG2) NbPoints() int {
+	return t.nbPoints
+}
+
+// WriteTo writes the precomputed tables, with raw points
+func (t *MultiExpPrecomputedG2) WriteTo(w io.Writer) (int64, error) {
+	header := []uint64{t.c, t.stride, uint64(t.nbPoints)}
+	if err := binary.Write(w, binary.BigEndian, header); err != nil {
+		return 0, err
+	}
+	enc := NewEncoder(w, RawEncoding())
+	err := enc.Encode(t.table)
+	return enc.BytesWritten() + 8*int64(len(header)), err
+}
+
+// ReadFrom reads precomputed tables written by WriteTo
+func (t *MultiExpPrecomputedG2) ReadFrom(r io.Reader) (int64, error) {
+	var header [3]uint64
+	if err := binary.Read(r, binary.BigEndian, header[:]); err != nil {
+		return 0, err
+	}
+	n := int64(8 * len(header))
+	t.c, t.stride, t.nbPoints = header[0], header[1], int(header[2])
+	if t.c < minPrecomputedC || t.c > maxPrecomputedC || t.stride == 0 || t.stride > (fr.Limbs*64+t.c-1)/t.c {
+		return n, errInvalidPrecomputedTable
+	}
+
+	dec := NewDecoder(r)
+	err := dec.Decode(&t.table)
+	n += dec.BytesRead()
+	if err != nil {
+		return n, err
+	}
+	if len(t.table) != t.nbRows()*t.nbPoints {
+		return n, errInvalidPrecomputedTable
+	}
+	return n, nil
+}
+
+// MultiExpPrecomputed computes the multi exponentiation of the bases of table by scalars
+func (p *G2Affine) MultiExpPrecomputed(table *MultiExpPrecomputedG2, scalars []fr.Element, config ecc.MultiExpConfig) (*G2Affine, error) {
+	var _p G2Jac
+	if _, err := _p.MultiExpPrecomputed(table, scalars, config); err != nil {
+		return nil, err
+	}
+	p.FromJacobian(&_p)
+	return p, nil
+}
+
+// MultiExpPrecomputed computes the multi exponentiation of the bases of table by scalars
+func (p *G2Jac) MultiExpPrecomputed(table *MultiExpPrecomputedG2, scalars []fr.Element, config ecc.MultiExpConfig) (*G2Jac, error) {
+	if len(scalars) != table.nbPoints {
+		return nil, errors.New("len(scalars) != number of precomputed points")
+	}
+
+	// if nbTasks is not set, use all the workers of the pool
+	if config.NbTasks <= 0 {
+		config.NbTasks = config.Pool.NbWorkers()
+	}
+
+	p.Set(&g2Infinity)
+	if table.nbPoints == 0 {
+		return p, nil
+	}
+
+	c := table.c
+	stride := int(table.stride)
+	nbChunks := int((fr.Limbs*64 + c - 1) / c)
+	nbRows := table.nbRows()
+	digits := partitionScalars(scalars, c, config.ScalarsMont, config.NbTasks, config.Pool)
+
+	// each of the stride bucket passes is split over the bases
+	nbSplits := config.NbTasks / stride
+	if nbSplits < 1 {
+		nbSplits = 1
+	}
+	if nbSplits > table.nbPoints {
+		nbSplits = table.nbPoints
+	}
+	totals := make([]g2JacExtended, stride*nbSplits)
+
+	// stride*nbSplits tasks are processed
+	ctx := newMSMContext(config, stride*nbSplits)
+	if config.Context != nil {
+		if err := config.Context.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// task r*nbSplits+k processes the pass r over the k-th split of the bases
+	ctx.execute(len(totals), func(startTask, endTask int) {
+		for task := startTask; task < endTask; task++ {
+			r, k := task/nbSplits, task%nbSplits
+			start := k * table.nbPoints / nbSplits
+			end := (k + 1) * table.nbPoints / nbSplits
+
+			// chunks r, r + stride, r + 2 stride... are processed with rows 0, 1, 2...
+			selectors := make([]selector, 0, nbRows)
+			for j := r; j < nbChunks; j += stride {
+				selectors = append(selectors, newSelector(uint64(j), c))
+			}
+
+			msmProcessPrecomputedG2Affine(&totals[task], c, table, selectors, digits, start, end, ctx)
+		}
+	}, len(totals))
+	if config.Context != nil {
+		if err := config.Context.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// p = Σ_r [2^(rc)]Σ_k totals[r][k]
+	var _p g2JacExtended
+	_p.setInfinity()
+	for r := stride - 1; r >= 0; r-- {
+		for l := 0; l < int(c); l++ {
+			_p.double(&_p)
+		}
+		for k := 0; k < nbSplits; k++ {
+			_p.add(&totals[r*nbSplits+k])
+		}
+	}
+
+	return p.unsafeFromJacExtended(&_p), nil
+}
+
+// msmProcessPrecomputedG2Affine sets total to the weighted sum of the buckets of the digits
+// selected by selectors[q] of the bases start..end, with the table row q
+func msmProcessPrecomputedG2Affine(total *g2JacExtended,
+	c uint64,
+	table *MultiExpPrecomputedG2,
+	selectors []selector,
+	digits []fr.Element,
+	start, end int,
+	ctx *msmContext) {
+
+	msbWindow := uint64(1 << (c - 1))
+	buckets := make([]g2JacExtended, 1<<(c-1))
+	for i := 0; i < len(buckets); i++ {
+		buckets[i].setInfinity()
+	}
+
+	for q, s := range selectors {
+		points := table.table[q*table.nbPoints : (q+1)*table.nbPoints]
+		for i := start; i < end; i++ {
+			if (i-start)%msmCancellationPeriod == 0 && ctx.cancelled() {
+				break
+			}
+			bits := (digits[i][s.index] & s.mask) >> s.shift
+			if s.multiWordSelect {
+				bits += (digits[i][s.index+1] & s.maskHigh) << s.shiftHigh
+			}
+
+			if bits == 0 {
+				continue
+			}
+
+			// if msbWindow bit is set, we need to substract
+			if bits&msbWindow == 0 {
+				// add
+				buckets[bits-1].addMixed(&points[i])
+			} else {
+				// sub
+				buckets[bits & ^msbWindow].subMixed(&points[i])
+			}
+		}
+	}
+
+	// reduce buckets into total
+	// total =  bucket[0] + 2*bucket[1] + 3*bucket[2] ... + n*bucket[n-1]
+	var runningSum g2JacExtended
+	runningSum.setInfinity()
+	total.setInfinity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		if !buckets[k].ZZ.IsZero() {
+			runningSum.add(&buckets[k])
+		}
+		total.add(&runningSum)
+	}
+	ctx.taskDone()
+}
@@ -0,0 +1,359 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24315
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestMultiExpPrecomputedG1(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 2
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// size of the multiExps
+	const nbSamples = 73
+
+	// multi exp points
+	var samplePoints [nbSamples]G1Affine
+	var g G1Jac
+	g.Set(&g1Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g1Gen)
+	}
+
+	// final scalar to use in double and add method (without mixer factor)
+	// n(n+1)(2n+1)/6  (sum of the squares from 1 to n)
+	var scalar big.Int
+	scalar.SetInt64(nbSamples)
+	scalar.Mul(&scalar, new(big.Int).SetInt64(nbSamples+1))
+	scalar.Mul(&scalar, new(big.Int).SetInt64(2*nbSamples+1))
+	scalar.Div(&scalar, new(big.Int).SetInt64(6))
+
+	// no memory limit, a limit forcing a stride > 1, and a limit smaller than the points
+	tables := []*MultiExpPrecomputedG1{
+		NewMultiExpPrecomputedG1(samplePoints[:], 0),
+		NewMultiExpPrecomputedG1(samplePoints[:], 5*nbSamples*SizeOfG1AffineUncompressed),
+		NewMultiExpPrecomputedG1(samplePoints[:], 1),
+	}
+	if tables[1].stride <= 1 || tables[2].nbRows() != 1 {
+		t.Fatal("memory budget not respected")
+	}
+
+	properties.Property("[G1] Multi exponentation with precomputed tables should be consistant with sum of square", prop.ForAll(
+		func(mixer fr.Element) bool {
+
+			var expected G1Jac
+
+			// compute expected result with double and add
+			var finalScalar, mixerBigInt big.Int
+			finalScalar.Mul(&scalar, mixer.ToBigIntRegular(&mixerBigInt))
+			expected.ScalarMultiplication(&g1Gen, &finalScalar)
+
+			// mixer ensures that all the words of a fpElement are set
+			var sampleScalars [nbSamples]fr.Element
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+			}
+
+			for _, table := range tables {
+				for _, nbTasks := range []int{1, 3, 64} {
+					var result G1Jac
+					if _, err := result.MultiExpPrecomputed(table, sampleScalars[:], ecc.MultiExpConfig{NbTasks: nbTasks, ScalarsMont: true}); err != nil {
+						return false
+					}
+					if !result.Equal(&expected) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.Property("[G1] Multi exponentation with serialized precomputed tables should be consistant with MultiExp", prop.ForAll(
+		func(mixer fr.Element) bool {
+
+			var sampleScalars [nbSamples]fr.Element
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer).
+					FromMont()
+			}
+
+			var buf bytes.Buffer
+			written, err := tables[1].WriteTo(&buf)
+			if err != nil {
+				return false
+			}
+			var table MultiExpPrecomputedG1
+			read, err := table.ReadFrom(&buf)
+			if err != nil || read != written || table.NbPoints() != nbSamples {
+				return false
+			}
+
+			var expected, result G1Affine
+			expected.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{})
+			if _, err := result.MultiExpPrecomputed(&table, sampleScalars[:], ecc.MultiExpConfig{}); err != nil {
+				return false
+			}
+			return result.Equal(&expected)
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// wrong number of scalars
+	var result G1Jac
+	if _, err := result.MultiExpPrecomputed(tables[0], make([]fr.Element, nbSamples-1), ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("wrong number of scalars should be rejected")
+	}
+
+	// the scalars r-1 set the most significant bits, with the smallest window size
+	var rMinusOne fr.Element
+	rMinusOne.SetOne().Neg(&rMinusOne)
+	for _, n := range []int{1, 2, 4} {
+		table := NewMultiExpPrecomputedG1(samplePoints[:n], 1)
+		if table.c != minPrecomputedC {
+			t.Fatal("memory budget should force the smallest window size")
+		}
+		scalars := make([]fr.Element, n)
+		for i := range scalars {
+			scalars[i] = rMinusOne
+		}
+		// Σ_i (r-1)·[i]g = -[n(n+1)/2]g
+		var expected, result G1Jac
+		expected.ScalarMultiplication(&g1Gen, big.NewInt(int64(n*(n+1)/2))).Neg(&expected)
+		if _, err := result.MultiExpPrecomputed(table, scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+			t.Fatal(err)
+		}
+		if !result.Equal(&expected) {
+			t.Fatalf("n=%d: multi exponentiation by r-1 with precomputed tables is wrong", n)
+		}
+	}
+}
+
+func BenchmarkMultiExpPrecomputedG1(b *testing.B) {
+	// ensure every words of the scalars are filled
+	var mixer fr.Element
+	mixer.SetString("7716837800905789770901243404444209691916730933998574719964609384059111546487")
+
+	const nbSamples = 1 << 16
+
+	samplePoints := make([]G1Affine, nbSamples)
+	sampleScalars := make([]fr.Element, nbSamples)
+
+	for i := 1; i <= nbSamples; i++ {
+		sampleScalars[i-1].SetUint64(uint64(i)).
+			Mul(&sampleScalars[i-1], &mixer).
+			FromMont()
+		samplePoints[i-1] = g1GenAff
+	}
+
+	var testPoint G1Affine
+
+	for _, maxMemory := range []uint64{0, 1 << 26, 1 << 24} {
+		table := NewMultiExpPrecomputedG1(samplePoints, maxMemory)
+		b.Run(fmt.Sprintf("c=%d stride=%d", table.c, table.stride), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				testPoint.MultiExpPrecomputed(table, sampleScalars, ecc.MultiExpConfig{})
+			}
+		})
+	}
+}
+
+func TestMultiExpPrecomputedG2(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 2
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// size of the multiExps
+	const nbSamples = 73
+
+	// multi exp points
+	var samplePoints [nbSamples]G2Affine
+	var g G2Jac
+	g.Set(&g2Gen)
+	for i := 1; i <= nbSamples; i++ {
+		samplePoints[i-1].FromJacobian(&g)
+		g.AddAssign(&g2Gen)
+	}
+
+	// final scalar to use in double and add method (without mixer factor)
+	// n(n+1)(2n+1)/6  (sum of the squares from 1 to n)
+	var scalar big.Int
+	scalar.SetInt64(nbSamples)
+	scalar.Mul(&scalar, new(big.Int).SetInt64(nbSamples+1))
+	scalar.Mul(&scalar, new(big.Int).SetInt64(2*nbSamples+1))
+	scalar.Div(&scalar, new(big.Int).SetInt64(6))
+
+	// no memory limit, a limit forcing a stride > 1, and a limit smaller than the points
+	tables := []*MultiExpPrecomputedG2{
+		NewMultiExpPrecomputedG2(samplePoints[:], 0),
+		NewMultiExpPrecomputedG2(samplePoints[:], 5*nbSamples*SizeOfG2AffineUncompressed),
+		NewMultiExpPrecomputedG2(samplePoints[:], 1),
+	}
+	if tables[1].stride <= 1 || tables[2].nbRows() != 1 {
+		t.Fatal("memory budget not respected")
+	}
+
+	properties.Property("[G2] Multi exponentation with precomputed tables should be consistant with sum of square", prop.ForAll(
+		func(mixer fr.Element) bool {
+
+			var expected G2Jac
+
+			// compute expected result with double and add
+			var finalScalar, mixerBigInt big.Int
+			finalScalar.Mul(&scalar, mixer.ToBigIntRegular(&mixerBigInt))
+			expected.ScalarMultiplication(&g2Gen, &finalScalar)
+
+			// mixer ensures that all the words of a fpElement are set
+			var sampleScalars [nbSamples]fr.Element
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer)
+			}
+
+			for _, table := range tables {
+				for _, nbTasks := range []int{1, 3, 64} {
+					var result G2Jac
+					if _, err := result.MultiExpPrecomputed(table, sampleScalars[:], ecc.MultiExpConfig{NbTasks: nbTasks, ScalarsMont: true}); err != nil {
+						return false
+					}
+					if !result.Equal(&expected) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.Property("[G2] Multi exponentation with serialized precomputed tables should be consistant with MultiExp", prop.ForAll(
+		func(mixer fr.Element) bool {
+
+			var sampleScalars [nbSamples]fr.Element
+			for i := 1; i <= nbSamples; i++ {
+				sampleScalars[i-1].SetUint64(uint64(i)).
+					Mul(&sampleScalars[i-1], &mixer).
+					FromMont()
+			}
+
+			var buf bytes.Buffer
+			written, err := tables[1].WriteTo(&buf)
+			if err != nil {
+				return false
+			}
+			var table MultiExpPrecomputedG2
+			read, err := table.ReadFrom(&buf)
+			if err != nil || read != written || table.NbPoints() != nbSamples {
+				return false
+			}
+
+			var expected, result G2Affine
+			expected.MultiExp(samplePoints[:], sampleScalars[:], ecc.MultiExpConfig{})
+			if _, err := result.MultiExpPrecomputed(&table, sampleScalars[:], ecc.MultiExpConfig{}); err != nil {
+				return false
+			}
+			return result.Equal(&expected)
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// wrong number of scalars
+	var result G2Jac
+	if _, err := result.MultiExpPrecomputed(tables[0], make([]fr.Element, nbSamples-1), ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("wrong number of scalars should be rejected")
+	}
+
+	// the scalars r-1 set the most significant bits, with the smallest window size
+	var rMinusOne fr.Element
+	rMinusOne.SetOne().Neg(&rMinusOne)
+	for _, n := range []int{1, 2, 4} {
+		table := NewMultiExpPrecomputedG2(samplePoints[:n], 1)
+		if table.c != minPrecomputedC {
+			t.Fatal("memory budget should force the smallest window size")
+		}
+		scalars := make([]fr.Element, n)
+		for i := range scalars {
+			scalars[i] = rMinusOne
+		}
+		// Σ_i (r-1)·[i]g = -[n(n+1)/2]g
+		var expected, result G2Jac
+		expected.ScalarMultiplication(&g2Gen, big.NewInt(int64(n*(n+1)/2))).Neg(&expected)
+		if _, err := result.MultiExpPrecomputed(table, scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+			t.Fatal(err)
+		}
+		if !result.Equal(&expected) {
+			t.Fatalf("n=%d: multi exponentiation by r-1 with precomputed tables is wrong", n)
+		}
+	}
+}
+
+func BenchmarkMultiExpPrecomputedG2(b *testing.B) {
+	// ensure every words of the scalars are filled
+	var mixer fr.Element
+	mixer.SetString("7716837800905789770901243404444209691916730933998574719964609384059111546487")
+
+	const nbSamples = 1 << 16
+
+	samplePoints := make([]G2Affine, nbSamples)
+	sampleScalars := make([]fr.Element, nbSamples)
+
+	for i := 1; i <= nbSamples; i++ {
+		sampleScalars[i-1].SetUint64(uint64(i)).
+			Mul(&sampleScalars[i-1], &mixer).
+			FromMont()
+		samplePoints[i-1] = g2GenAff
+	}
+
+	var testPoint G2Affine
+
+	for _, maxMemory := range []uint64{0, 1 << 26, 1 << 24} {
+		table := NewMultiExpPrecomputedG2(samplePoints, maxMemory)
+		b.Run(fmt.Sprintf("c=%d stride=%d", table.c, table.stride), func(b *testing.B) {
+			b.ResetTimer()
+			for j := 0; j < b.N; j++ {
+				testPoint.MultiExpPrecomputed(table, sampleScalars, ecc.MultiExpConfig{})
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24315
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/pool"
+)
+
+// default number of points decoded at once by the streaming multi exponentiations
+const defaultStreamChunkSize = 1 << 16
+
+// MultiExpStream computes the multi exponentiation of points decoded from dec by scalars,
+// with at most chunkSize points in memory (a default size is used if chunkSize <= 0).
+// The stream must contain a []G1Affine of len(scalars) points, written by Encoder,
+// compressed or raw, which is consumed. The points are decompressed on config.Pool.
+func (p *G1Affine) MultiExpStream(dec *Decoder, scalars []fr.Element, chunkSize int, config ecc.MultiExpConfig) (*G1Affine, error) {
+	var _p G1Jac
+	if _, err := _p.MultiExpStream(dec, scalars, chunkSize, config); err != nil {
+		return nil, err
+	}
+	p.FromJacobian(&_p)
+	return p, nil
+}
+
+// MultiExpStream computes the multi exponentiation of points decoded from dec by scalars,
+// with at most chunkSize points in memory (a default size is used if chunkSize <= 0).
+// The stream must contain a []G1Affine of len(scalars) points, written by Encoder,
+// compressed or raw, which is consumed. The points are decompressed on config.Pool.
+func (p *G1Jac) MultiExpStream(dec *Decoder, scalars []fr.Element, chunkSize int, config ecc.MultiExpConfig) (*G1Jac, error) {
+	nbPoints, err := dec.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if int(nbPoints) != len(scalars) {
+		return nil, errors.New("number of encoded points != len(scalars)")
+	}
+	if chunkSize <= 0 {
+		chunkSize = defaultStreamChunkSize
+	}
+	if chunkSize > len(scalars) && len(scalars) > 0 {
+		chunkSize = len(scalars)
+	}
+
+	// the progress is reported per chunk of points
+	progress := config.Progress
+	config.Progress = nil
+	nbChunks := (len(scalars) + chunkSize - 1) / chunkSize
+
+	p.Set(&g1Infinity)
+	points := make([]G1Affine, chunkSize)
+	var partial G1Jac
+	for start := 0; start < len(scalars); start += chunkSize {
+		end := start + chunkSize
+		if end > len(scalars) {
+			end = len(scalars)
+		}
+		if err := dec.decodePointsG1(points[:end-start], config.Pool); err != nil {
+			return nil, err
+		}
+		if _, err := partial.MultiExp(points[:end-start], scalars[start:end], config); err != nil {
+			return nil, err
+		}
+		p.AddAssign(&partial)
+		if progress != nil {
+			progress(start/chunkSize+1, nbChunks)
+		}
+	}
+	return p, nil
+}
+
+// decodePointsG1 decodes len(points) points from the stream, as the elements of a []G1Affine,
+// decompressing them on the pool pl
+func (dec *Decoder) decodePointsG1(points []G1Affine, pl *pool.Pool) error {
+	var buf [SizeOfG1AffineUncompressed]byte
+	compressed := make([]bool, len(points))
+	for i := 0; i < len(points); i++ {
+
+		// we start by reading compressed point size, if metadata tells us it is uncompressed, we read more.
+		read, err := io.ReadFull(dec.r, buf[:SizeOfG1AffineCompressed])
+		dec.n += int64(read)
+		if err != nil {
+			return err
+		}
+		nbBytes := SizeOfG1AffineCompressed
+		// most significant byte contains metadata
+		if !isCompressed(buf[0]) {
+			nbBytes = SizeOfG1AffineUncompressed
+			// we read more.
+			read, err = io.ReadFull(dec.r, buf[SizeOfG1AffineCompressed:SizeOfG1AffineUncompressed])
+			dec.n += int64(read)
+			if err != nil {
+				return err
+			}
+			if _, err = points[i].SetBytes(buf[:nbBytes]); err != nil {
+				return err
+			}
+		} else {
+			compressed[i] = !(points[i].unsafeSetCompressedBytes(buf[:nbBytes]))
+		}
+	}
+	var nbErrs uint64
+	pl.Execute(len(compressed), func(start, end int) {
+		for i := start; i < end; i++ {
+			if compressed[i] {
+				if err := points[i].unsafeComputeY(); err != nil {
+					atomic.AddUint64(&nbErrs, 1)
+				}
+			}
+		}
+	})
+	if nbErrs != 0 {
+		return errors.New("point decompression failed")
+	}
+	return nil
+}
+
+// MultiExpStream computes the multi exponentiation of points decoded from dec by scalars,
+// with at most chunkSize points in memory (a default size is used if chunkSize <= 0).
+// The stream must contain a []G2Affine of len(scalars) points, written by Encoder,
+// compressed or raw, which is consumed. The points are decompressed on config.Pool.
+func (p *G2Affine) MultiExpStream(dec *Decoder, scalars []fr.Element, chunkSize int, config ecc.MultiExpConfig) (*G2Affine, error) {
+	var _p G2Jac
+	if _, err := _p.MultiExpStream(dec, scalars, chunkSize, config); err != nil {
+		return nil, err
+	}
+	p.FromJacobian(&_p)
+	return p, nil
+}
+
+// MultiExpStream computes the multi exponentiation of points decoded from dec by scalars,
+// with at most chunkSize points in memory (a default size is used if chunkSize <= 0).
+// The stream must contain a []G2Affine of len(scalars) points, written by Encoder,
+// compressed or raw, which is consumed. The points are decompressed on config.Pool.
+func (p *G2Jac) MultiExpStream(dec *Decoder, scalars []fr.Element, chunkSize int, config ecc.MultiExpConfig) (*G2Jac, error) {
+	nbPoints, err := dec.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if int(nbPoints) != len(scalars) {
+		return nil, errors.New("number of encoded points != len(scalars)")
+	}
+	if chunkSize <= 0 {
+		chunkSize = defaultStreamChunkSize
+	}
+	if chunkSize > len(scalars) && len(scalars) > 0 {
+		chunkSize = len(scalars)
+	}
+
+	// the progress is reported per chunk of points
+	progress := config.Progress
+	config.Progress = nil
+	nbChunks := (len(scalars) + chunkSize - 1) / chunkSize
+
+	p.Set(&g2Infinity)
+	points := make([]G2Affine, chunkSize)
+	var partial G2Jac
+	for start := 0; start < len(scalars); start += chunkSize {
+		end := start + chunkSize
+		if end > len(scalars) {
+			end = len(scalars)
+		}
+		if err := dec.decodePointsG2(points[:end-start], config.Pool); err != nil {
+			return nil, err
+		}
+		if _, err := partial.MultiExp(points[:end-start], scalars[start:end], config); err != nil {
+			return nil, err
+		}
+		p.AddAssign(&partial)
+		if progress != nil {
+			progress(start/chunkSize+1, nbChunks)
+		}
+	}
+	return p, nil
+}
+
+// decodePointsG2 decodes len(points) points from the stream, as the elements of a []G2Affine,
+// decompressing them on the pool pl
+func (dec *Decoder) decodePointsG2(points []G2Affine, pl *pool.Pool) error {
+	var buf [SizeOfG2AffineUncompressed]byte
+	compressed := make([]bool, len(points))
+	for i := 0; i < len(points); i++ {
+
+		// we start by reading compressed point size, if metadata tells us it is uncompressed, we read more.
+		read, err := io.ReadFull(dec.r, buf[:SizeOfG2AffineCompressed])
+		dec.n += int64(read)
+		if err != nil {
+			return err
+		}
+		nbBytes := SizeOfG2AffineCompressed
+		// most significant byte contains metadata
+		if !isCompressed(buf[0]) {
+			nbBytes = SizeOfG2AffineUncompressed
+			// we read more.
+			read, err = io.ReadFull(dec.r, buf[SizeOfG2AffineCompressed:SizeOfG2AffineUncompressed])
+			dec.n += int64(read)
+			if err != nil {
+				return err
+			}
+			if _, err = points[i].SetBytes(buf[:nbBytes]); err != nil {
+				return err
+			}
+		} else {
+			compressed[i] = !(points[i].unsafeSetCompressedBytes(buf[:nbBytes]))
+		}
+	}
+	var nbErrs uint64
+	pl.Execute(len(compressed), func(start, end int) {
+		for i := start; i < end; i++ {
+			if compressed[i] {
+				if err := points[i].unsafeComputeY(); err != nil {
+					atomic.AddUint64(&nbErrs, 1)
+				}
+			}
+		}
+	})
+	if nbErrs != 0 {
+		return errors.New("point decompression failed")
+	}
+	return nil
+}
@@ -17,15 +17,18 @@
 package bls24315
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"math/bits"
+	"reflect"
 	"runtime"
 	"sync"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/pool"
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/prop"
 )
@@ -78,8 +81,8 @@ func TestMultiExpG1(t *testing.T) {
 					FromMont()
 			}
 
-			scalars16 := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU())
-			r16.msmC16(samplePoints[:], scalars16, runtime.NumCPU())
+			scalars16 := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU(), nil)
+			r16.msmC16(samplePoints[:], scalars16, runtime.NumCPU(), nil)
 
 			splitted1.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 128})
 			splitted2.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 51})
@@ -110,8 +113,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars5 := partitionScalars(sampleScalars[:], 5, false, runtime.NumCPU())
-				scalars16 := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU())
+				scalars5 := partitionScalars(sampleScalars[:], 5, false, runtime.NumCPU(), nil)
+				scalars16 := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU(), nil)
 
 				nbTasks := []int{1, 2, 3, runtime.NumCPU()}
 
@@ -119,8 +122,8 @@ func TestMultiExpG1(t *testing.T) {
 				// in which case each task may process multiple chunks
 				for i := 0; i < len(nbTasks); i++ {
 					var r5, r16 G1Jac
-					r5.msmC5(samplePoints[:], scalars5, nbTasks[i])
-					r16.msmC16(samplePoints[:], scalars16, nbTasks[i])
+					r5.msmC5(samplePoints[:], scalars5, nbTasks[i], nil)
+					r16.msmC16(samplePoints[:], scalars16, nbTasks[i], nil)
 					if !(r5.Equal(&expected) && r16.Equal(&expected)) {
 						return false
 					}
@@ -146,8 +149,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 4, false, runtime.NumCPU())
-				result.msmC4(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 4, false, runtime.NumCPU(), nil)
+				result.msmC4(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -173,8 +176,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 5, false, runtime.NumCPU())
-				result.msmC5(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 5, false, runtime.NumCPU(), nil)
+				result.msmC5(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -200,8 +203,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 6, false, runtime.NumCPU())
-				result.msmC6(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 6, false, runtime.NumCPU(), nil)
+				result.msmC6(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -227,8 +230,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 7, false, runtime.NumCPU())
-				result.msmC7(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 7, false, runtime.NumCPU(), nil)
+				result.msmC7(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -254,8 +257,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 8, false, runtime.NumCPU())
-				result.msmC8(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 8, false, runtime.NumCPU(), nil)
+				result.msmC8(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -281,8 +284,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 9, false, runtime.NumCPU())
-				result.msmC9(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 9, false, runtime.NumCPU(), nil)
+				result.msmC9(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -308,8 +311,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 10, false, runtime.NumCPU())
-				result.msmC10(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 10, false, runtime.NumCPU(), nil)
+				result.msmC10(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -335,8 +338,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 11, false, runtime.NumCPU())
-				result.msmC11(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 11, false, runtime.NumCPU(), nil)
+				result.msmC11(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -362,8 +365,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 12, false, runtime.NumCPU())
-				result.msmC12(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 12, false, runtime.NumCPU(), nil)
+				result.msmC12(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -389,8 +392,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 13, false, runtime.NumCPU())
-				result.msmC13(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 13, false, runtime.NumCPU(), nil)
+				result.msmC13(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -416,8 +419,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 14, false, runtime.NumCPU())
-				result.msmC14(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 14, false, runtime.NumCPU(), nil)
+				result.msmC14(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -443,8 +446,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 15, false, runtime.NumCPU())
-				result.msmC15(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 15, false, runtime.NumCPU(), nil)
+				result.msmC15(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -470,8 +473,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU())
-				result.msmC16(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU(), nil)
+				result.msmC16(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -497,8 +500,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 20, false, runtime.NumCPU())
-				result.msmC20(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 20, false, runtime.NumCPU(), nil)
+				result.msmC20(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -524,8 +527,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 21, false, runtime.NumCPU())
-				result.msmC21(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 21, false, runtime.NumCPU(), nil)
+				result.msmC21(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -551,8 +554,8 @@ func TestMultiExpG1(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 22, false, runtime.NumCPU())
-				result.msmC22(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 22, false, runtime.NumCPU(), nil)
+				result.msmC22(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -604,6 +607,160 @@ func TestMultiExpG1(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestMultiExpG1Context(t *testing.T) {
+
+	const nbSamples = 1 << 10
+	samplePoints := make([]G1Affine, nbSamples)
+	sampleScalars := make([]fr.Element, nbSamples)
+	for i := 0; i < nbSamples; i++ {
+		sampleScalars[i].SetRandom()
+		samplePoints[i] = g1GenAff
+	}
+
+	// progress reaches the total, and the result is unchanged
+	var expected, result G1Affine
+	expected.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{})
+	lastDone, lastTotal, byOne := 0, 0, true
+	progress := func(done, total int) {
+		byOne = byOne && done == lastDone+1 && (lastTotal == 0 || total == lastTotal)
+		lastDone, lastTotal = done, total
+	}
+	if _, err := result.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{Context: context.Background(), Progress: progress}); err != nil {
+		t.Fatal(err)
+	}
+	if !byOne {
+		t.Fatal("progress should increase by one task")
+	}
+	if !result.Equal(&expected) || lastDone == 0 || lastDone != lastTotal {
+		t.Fatal("multi exponentiation with a context should be consistant with MultiExp")
+	}
+
+	// cancelled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := result.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{Context: ctx}); err != context.Canceled {
+		t.Fatal("cancelled multi exponentiation should return the context error")
+	}
+	table := NewMultiExpPrecomputedG1(samplePoints, 0)
+	if _, err := result.MultiExpPrecomputed(table, sampleScalars, ecc.MultiExpConfig{Context: ctx}); err != context.Canceled {
+		t.Fatal("cancelled multi exponentiation should return the context error")
+	}
+
+	// a context cancelled during the multi exponentiation
+	ctx, cancel = context.WithCancel(context.Background())
+	cancelAfterFirstTask := func(done, total int) {
+		cancel()
+	}
+	if _, err := result.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{NbTasks: 1, Context: ctx, Progress: cancelAfterFirstTask}); err != context.Canceled {
+		t.Fatal("cancelled multi exponentiation should return the context error")
+	}
+}
+
+func TestMultiExpG1Pool(t *testing.T) {
+
+	const nbSamples = 1 << 10
+	samplePoints := make([]G1Affine, nbSamples)
+	sampleScalars := make([]fr.Element, nbSamples)
+	for i := 0; i < nbSamples; i++ {
+		sampleScalars[i].SetRandom()
+		samplePoints[i] = g1GenAff
+	}
+	var expected G1Affine
+	expected.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{})
+
+	// a pool with a single worker, splitting the multi exponentiation in more tasks than workers,
+	// and a stopped pool on which the caller does all the work
+	single, stopped := pool.New(1), pool.New(2)
+	defer single.Stop()
+	stopped.Stop()
+	table := NewMultiExpPrecomputedG1(samplePoints, 0)
+	for _, config := range []ecc.MultiExpConfig{
+		{Pool: single},
+		{Pool: single, NbTasks: 64},
+		{Pool: stopped, NbTasks: 8},
+	} {
+		var result G1Affine
+		if _, err := result.MultiExp(samplePoints, sampleScalars, config); err != nil {
+			t.Fatal(err)
+		}
+		if !result.Equal(&expected) {
+			t.Fatal("multi exponentiation on a pool should be consistant with MultiExp")
+		}
+		if _, err := result.MultiExpPrecomputed(table, sampleScalars, config); err != nil {
+			t.Fatal(err)
+		}
+		if !result.Equal(&expected) {
+			t.Fatal("multi exponentiation on a pool should be consistant with MultiExp")
+		}
+	}
+
+	// batch scalar multiplication on a pool
+	base := g1GenAff
+	if a, b := BatchScalarMultiplicationG1(&base, sampleScalars[:10]), BatchScalarMultiplicationG1(&base, sampleScalars[:10], single); !reflect.DeepEqual(a, b) {
+		t.Fatal("batch scalar multiplication on a pool should be consistant with the default pool")
+	}
+}
+
+func TestMultiExpG1BatchAffine(t *testing.T) {
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 2
+
+	properties := gopter.NewProperties(parameters)
+
+	genScalar := GenFr()
+
+	// points with many conflicts in the batches: duplicates, opposite points and infinity
+	const nbSamples = 300
+	var samplePoints [nbSamples]G1Affine
+	var g G1Jac
+	g.Set(&g1Gen)
+	for i := 0; i < nbSamples; i += 3 {
+		samplePoints[i].FromJacobian(&g)
+		samplePoints[i+1] = samplePoints[i]
+		if i%2 == 0 {
+			samplePoints[i+2].Neg(&samplePoints[i])
+		}
+		g.AddAssign(&g1Gen)
+	}
+
+	properties.Property("[G1] bucket accumulation in affine coordinates should be consistant with extended jacobian coordinates", prop.ForAll(
+		func(mixer fr.Element) bool {
+
+			var sampleScalars [nbSamples]fr.Element
+			for i := 0; i < nbSamples; i++ {
+				sampleScalars[i].SetUint64(uint64(i%7)).
+					Mul(&sampleScalars[i], &mixer).
+					FromMont()
+			}
+
+			for _, c := range []uint64{4, 8, 12, 16} {
+				scalars := partitionScalars(sampleScalars[:], c, false, runtime.NumCPU(), nil)
+				nbChunks := (fr.Limbs*64 + c - 1) / c
+				for chunk := uint64(0); chunk < nbChunks; chunk++ {
+					chExpected := make(chan g1JacExtended, 1)
+					chResult := make(chan g1JacExtended, 1)
+					buckets := make([]g1JacExtended, 1<<(c-1))
+					msmProcessChunkG1Affine(chunk, chExpected, buckets, c, samplePoints[:], scalars, nil)
+					msmProcessChunkG1AffineBatchAffine(chunk, chResult, c, samplePoints[:], scalars, nil)
+
+					var expected, result G1Jac
+					e, r := <-chExpected, <-chResult
+					expected.unsafeFromJacExtended(&e)
+					result.unsafeFromJacExtended(&r)
+					if !expected.Equal(&result) {
+						return false
+					}
+				}
+			}
+			return true
+		},
+		genScalar,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
 func BenchmarkMultiExpG1(b *testing.B) {
 	// ensure every words of the scalars are filled
 	var mixer fr.Element
@@ -747,8 +904,8 @@ func TestMultiExpG2(t *testing.T) {
 					FromMont()
 			}
 
-			scalars16 := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU())
-			r16.msmC16(samplePoints[:], scalars16, runtime.NumCPU())
+			scalars16 := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU(), nil)
+			r16.msmC16(samplePoints[:], scalars16, runtime.NumCPU(), nil)
 
 			splitted1.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 128})
 			splitted2.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 51})
@@ -779,8 +936,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars5 := partitionScalars(sampleScalars[:], 5, false, runtime.NumCPU())
-				scalars16 := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU())
+				scalars5 := partitionScalars(sampleScalars[:], 5, false, runtime.NumCPU(), nil)
+				scalars16 := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU(), nil)
 
 				nbTasks := []int{1, 2, 3, runtime.NumCPU()}
 
@@ -788,8 +945,8 @@ func TestMultiExpG2(t *testing.T) {
 				// in which case each task may process multiple chunks
 				for i := 0; i < len(nbTasks); i++ {
 					var r5, r16 G2Jac
-					r5.msmC5(samplePoints[:], scalars5, nbTasks[i])
-					r16.msmC16(samplePoints[:], scalars16, nbTasks[i])
+					r5.msmC5(samplePoints[:], scalars5, nbTasks[i], nil)
+					r16.msmC16(samplePoints[:], scalars16, nbTasks[i], nil)
 					if !(r5.Equal(&expected) && r16.Equal(&expected)) {
 						return false
 					}
@@ -815,8 +972,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 4, false, runtime.NumCPU())
-				result.msmC4(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 4, false, runtime.NumCPU(), nil)
+				result.msmC4(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -842,8 +999,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 5, false, runtime.NumCPU())
-				result.msmC5(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 5, false, runtime.NumCPU(), nil)
+				result.msmC5(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -869,8 +1026,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 6, false, runtime.NumCPU())
-				result.msmC6(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 6, false, runtime.NumCPU(), nil)
+				result.msmC6(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -896,8 +1053,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 7, false, runtime.NumCPU())
-				result.msmC7(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 7, false, runtime.NumCPU(), nil)
+				result.msmC7(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -923,8 +1080,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 8, false, runtime.NumCPU())
-				result.msmC8(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 8, false, runtime.NumCPU(), nil)
+				result.msmC8(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -950,8 +1107,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 9, false, runtime.NumCPU())
-				result.msmC9(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 9, false, runtime.NumCPU(), nil)
+				result.msmC9(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -977,8 +1134,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 10, false, runtime.NumCPU())
-				result.msmC10(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 10, false, runtime.NumCPU(), nil)
+				result.msmC10(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -1004,8 +1161,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 11, false, runtime.NumCPU())
-				result.msmC11(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 11, false, runtime.NumCPU(), nil)
+				result.msmC11(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -1031,8 +1188,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 12, false, runtime.NumCPU())
-				result.msmC12(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 12, false, runtime.NumCPU(), nil)
+				result.msmC12(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -1058,8 +1215,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 13, false, runtime.NumCPU())
-				result.msmC13(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 13, false, runtime.NumCPU(), nil)
+				result.msmC13(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -1085,8 +1242,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 14, false, runtime.NumCPU())
-				result.msmC14(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 14, false, runtime.NumCPU(), nil)
+				result.msmC14(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -1112,8 +1269,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 15, false, runtime.NumCPU())
-				result.msmC15(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 15, false, runtime.NumCPU(), nil)
+				result.msmC15(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -1139,8 +1296,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU())
-				result.msmC16(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU(), nil)
+				result.msmC16(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -1166,8 +1323,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 20, false, runtime.NumCPU())
-				result.msmC20(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 20, false, runtime.NumCPU(), nil)
+				result.msmC20(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -1193,8 +1350,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 21, false, runtime.NumCPU())
-				result.msmC21(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 21, false, runtime.NumCPU(), nil)
+				result.msmC21(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -1220,8 +1377,8 @@ func TestMultiExpG2(t *testing.T) {
 						FromMont()
 				}
 
-				scalars := partitionScalars(sampleScalars[:], 22, false, runtime.NumCPU())
-				result.msmC22(samplePoints[:], scalars, runtime.NumCPU())
+				scalars := partitionScalars(sampleScalars[:], 22, false, runtime.NumCPU(), nil)
+				result.msmC22(samplePoints[:], scalars, runtime.NumCPU(), nil)
 
 				// compute expected result with double and add
 				var finalScalar, mixerBigInt big.Int
@@ -1273,6 +1430,100 @@ func TestMultiExpG2(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestMultiExpG2Context(t *testing.T) {
+
+	const nbSamples = 1 << 10
+	samplePoints := make([]G2Affine, nbSamples)
+	sampleScalars := make([]fr.Element, nbSamples)
+	for i := 0; i < nbSamples; i++ {
+		sampleScalars[i].SetRandom()
+		samplePoints[i] = g2GenAff
+	}
+
+	// progress reaches the total, and the result is unchanged
+	var expected, result G2Affine
+	expected.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{})
+	lastDone, lastTotal, byOne := 0, 0, true
+	progress := func(done, total int) {
+		byOne = byOne && done == lastDone+1 && (lastTotal == 0 || total == lastTotal)
+		lastDone, lastTotal = done, total
+	}
+	if _, err := result.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{Context: context.Background(), Progress: progress}); err != nil {
+		t.Fatal(err)
+	}
+	if !byOne {
+		t.Fatal("progress should increase by one task")
+	}
+	if !result.Equal(&expected) || lastDone == 0 || lastDone != lastTotal {
+		t.Fatal("multi exponentiation with a context should be consistant with MultiExp")
+	}
+
+	// cancelled context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := result.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{Context: ctx}); err != context.Canceled {
+		t.Fatal("cancelled multi exponentiation should return the context error")
+	}
+	table := NewMultiExpPrecomputedG2(samplePoints, 0)
+	if _, err := result.MultiExpPrecomputed(table, sampleScalars, ecc.MultiExpConfig{Context: ctx}); err != context.Canceled {
+		t.Fatal("cancelled multi exponentiation should return the context error")
+	}
+
+	// a context cancelled during the multi exponentiation
+	ctx, cancel = context.WithCancel(context.Background())
+	cancelAfterFirstTask := func(done, total int) {
+		cancel()
+	}
+	if _, err := result.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{NbTasks: 1, Context: ctx, Progress: cancelAfterFirstTask}); err != context.Canceled {
+		t.Fatal("cancelled multi exponentiation should return the context error")
+	}
+}
+
+func TestMultiExpG2Pool(t *testing.T) {
+
+	const nbSamples = 1 << 10
+	samplePoints := make([]G2Affine, nbSamples)
+	sampleScalars := make([]fr.Element, nbSamples)
+	for i := 0; i < nbSamples; i++ {
+		sampleScalars[i].SetRandom()
+		samplePoints[i] = g2GenAff
+	}
+	var expected G2Affine
+	expected.MultiExp(samplePoints, sampleScalars, ecc.MultiExpConfig{})
+
+	// a pool with a single worker, splitting the multi exponentiation in more tasks than workers,
+	// and a stopped pool on which the caller does all the work
+	single, stopped := pool.New(1), pool.New(2)
+	defer single.Stop()
+	stopped.Stop()
+	table := NewMultiExpPrecomputedG2(samplePoints, 0)
+	for _, config := range []ecc.MultiExpConfig{
+		{Pool: single},
+		{Pool: single, NbTasks: 64},
+		{Pool: stopped, NbTasks: 8},
+	} {
+		var result G2Affine
+		if _, err := result.MultiExp(samplePoints, sampleScalars, config); err != nil {
+			t.Fatal(err)
+		}
+		if !result.Equal(&expected) {
+			t.Fatal("multi exponentiation on a pool should be consistant with MultiExp")
+		}
+		if _, err := result.MultiExpPrecomputed(table, sampleScalars, config); err != nil {
+			t.Fatal(err)
+		}
+		if !result.Equal(&expected) {
+			t.Fatal("multi exponentiation on a pool should be consistant with MultiExp")
+		}
+	}
+
+	// batch scalar multiplication on a pool
+	base := g2GenAff
+	if a, b := BatchScalarMultiplicationG2(&base, sampleScalars[:10]), BatchScalarMultiplicationG2(&base, sampleScalars[:10], single); !reflect.DeepEqual(a, b) {
+		t.Fatal("batch scalar multiplication on a pool should be consistant with the default pool")
+	}
+}
+
 func BenchmarkMultiExpG2(b *testing.B) {
 	// ensure every words of the scalars are filled
 	var mixer fr.Element
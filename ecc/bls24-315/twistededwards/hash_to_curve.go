@@ -0,0 +1,188 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+// constants of the Elligator 2 map, on the birationally equivalent Montgomery curve
+// K*t^2 = s^3 + J*s^2 + s, with J = 2(a+d)/(a-d) and K = 4/(a-d)
+// cf https://www.rfc-editor.org/rfc/rfc9380.html#section-6.7.1
+var (
+	ell2J, ell2K fr.Element // J/K, and K
+	ell2InvKSq   fr.Element // 1/K^2
+	ell2Z        fr.Element // non-square of the map
+	ell2Cofactor big.Int
+	ell2Once     sync.Once
+)
+
+// initEll2 computes the constants of the Elligator 2 map from the curve parameters
+func initEll2() {
+	var aMinusD fr.Element
+	aMinusD.Sub(&edwards.A, &edwards.D)
+
+	// J/K = (a+d)/2
+	ell2J.Add(&edwards.A, &edwards.D)
+	ell2J.Div(&ell2J, new(fr.Element).SetUint64(2))
+
+	// K = 4/(a-d), 1/K^2 = ((a-d)/4)^2
+	ell2K.SetUint64(4).Div(&ell2K, &aMinusD)
+	ell2InvKSq.SetUint64(4).Div(&aMinusD, &ell2InvKSq).Square(&ell2InvKSq)
+
+	// Z is the first non-square of 1, -1, 2, -2, ...
+	// cf https://www.rfc-editor.org/rfc/rfc9380.html#appendix-H.1
+	for c := uint64(1); ; c++ {
+		ell2Z.SetUint64(c)
+		if ell2Z.Legendre() == -1 {
+			break
+		}
+		ell2Z.Neg(&ell2Z)
+		if ell2Z.Legendre() == -1 {
+			break
+		}
+	}
+
+	edwards.Cofactor.ToBigInt(&ell2Cofactor)
+}
+
+// hashToFr hashes msg to count field elements.
+// https://www.rfc-editor.org/rfc/rfc9380.html#section-5.2
+func hashToFr(msg, dst []byte, count int) ([]fr.Element, error) {
+
+	// 128 bits of security
+	// L = ceil((ceil(log2(p)) + k) / 8), where k is the security parameter = 128
+	const L = (fr.Bits + 128 + 7) / 8
+
+	lenInBytes := count * L
+	pseudoRandomBytes, err := ecc.ExpandMsgXmd(msg, dst, lenInBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]fr.Element, count)
+	for i := 0; i < count; i++ {
+		res[i].SetBytes(pseudoRandomBytes[i*L : (i+1)*L])
+	}
+	return res, nil
+}
+
+// sgn0 returns the parity of the regular form of u
+// https://www.rfc-editor.org/rfc/rfc9380.html#section-4.1
+func sgn0(u fr.Element) bool {
+	u.FromMont()
+	return u[0]&1 == 1
+}
+
+// MapToCurve maps an fr.Element to a point on the curve, not in the prime order subgroup, using
+// the Elligator 2 map on the Montgomery curve and the rational map to the twisted Edwards curve
+// https://www.rfc-editor.org/rfc/rfc9380.html#section-6.8.2
+func MapToCurve(u fr.Element) PointAffine {
+	ell2Once.Do(initEll2)
+
+	var x1, x2, gx1, gx2, x, y, tmp, one fr.Element
+	one.SetOne()
+
+	// x1 = -(J/K) / (1 + Z*u^2), or -(J/K) if 1 + Z*u^2 = 0
+	tmp.Square(&u).Mul(&tmp, &ell2Z).Add(&tmp, &one)
+	x1.Neg(&ell2J)
+	if !tmp.IsZero() {
+		x1.Div(&x1, &tmp)
+	}
+
+	// gx(x) = x^3 + (J/K)*x^2 + x/K^2
+	gx := func(res, x *fr.Element) {
+		res.Add(x, &ell2J).Mul(res, x).Add(res, &ell2InvKSq).Mul(res, x)
+	}
+	gx(&gx1, &x1)
+
+	// x2 = -x1 - J/K
+	x2.Add(&x1, &ell2J).Neg(&x2)
+	gx(&gx2, &x2)
+
+	// (x, y) = (x1, sqrt(gx1)) with sgn0(y) = 1 if gx1 is square, or (x2, sqrt(gx2)) with sgn0(y) = 0
+	if gx1.Legendre() != -1 {
+		x.Set(&x1)
+		y.Sqrt(&gx1)
+		if !sgn0(y) {
+			y.Neg(&y)
+		}
+	} else {
+		x.Set(&x2)
+		y.Sqrt(&gx2)
+		if sgn0(y) {
+			y.Neg(&y)
+		}
+	}
+
+	// Montgomery point (s, t) = (x*K, y*K), and twisted Edwards point (s/t, (s-1)/(s+1)),
+	// or (0, 1) if t = 0 or s = -1
+	var res PointAffine
+	var s fr.Element
+	s.Mul(&x, &ell2K)
+	tmp.Add(&s, &one)
+	if y.IsZero() || tmp.IsZero() {
+		res.X.SetZero()
+		res.Y.SetOne()
+		return res
+	}
+	res.X.Div(&x, &y)
+	res.Y.Sub(&s, &one).Div(&res.Y, &tmp)
+	return res
+}
+
+// EncodeToCurve hashes msg to a point of the prime order subgroup, with a non-uniform distribution
+// https://www.rfc-editor.org/rfc/rfc9380.html#section-3
+func EncodeToCurve(msg, dst []byte) (PointAffine, error) {
+	var res PointAffine
+	u, err := hashToFr(msg, dst, 1)
+	if err != nil {
+		return res, err
+	}
+	Q := MapToCurve(u[0])
+
+	// clear the cofactor
+	var _res PointExtended
+	_res.FromAffine(&Q)
+	_res.ScalarMul(&_res, &ell2Cofactor)
+	res.FromExtended(&_res)
+	return res, nil
+}
+
+// HashToCurve hashes msg to a point of the prime order subgroup, with a uniform distribution
+// https://www.rfc-editor.org/rfc/rfc9380.html#section-3
+func HashToCurve(msg, dst []byte) (PointAffine, error) {
+	var res PointAffine
+	u, err := hashToFr(msg, dst, 2)
+	if err != nil {
+		return res, err
+	}
+	Q0 := MapToCurve(u[0])
+	Q1 := MapToCurve(u[1])
+
+	// Q0 + Q1, and clear the cofactor
+	var _res PointExtended
+	_res.FromAffine(&Q0)
+	_res.MixedAdd(&_res, &Q1)
+	_res.ScalarMul(&_res, &ell2Cofactor)
+	res.FromExtended(&_res)
+	return res, nil
+}
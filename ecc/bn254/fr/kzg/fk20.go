@@ -0,0 +1,200 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial"
+	"github.com/consensys/gnark-crypto/pool"
+)
+
+// FK20Setup precomputed data to compute the opening proofs of a polynomial on all the cells
+// of a domain at once, with the FK20 algorithm (https://eprint.iacr.org/2023/033.pdf).
+//
+// The domain of size n is split in n/k cells of size k: the i-th cell is the coset
+// ω^i·<ω^(n/k)>, on which the vanishing polynomial is X^k - ω^(ik). The proof of the i-th cell
+// is [q_i(alpha)]gen, q_i = (p - r_i)/(X^k - ω^(ik)), r_i the interpolation of p on the cell.
+//
+// The quotients [q_i(alpha)]gen are the FFT of the vector h_t = Σ_j p_(j+tk)[alpha^j]gen, which
+// is a sum of k Toeplitz matrix-vector products, computed with FFTs on G1 points.
+type FK20Setup struct {
+	domain     *fft.Domain // evaluation domain, of size n
+	cellDomain *fft.Domain // domain of size n/k, generated by ω^k
+	fftDomain  *fft.Domain // domain of the circulant embedding of the Toeplitz matrices
+	cellSize   int         // k
+	nbRows     int         // m = ⌈maxSize/k⌉, maxSize the maximum size of a polynomial
+	pool       *pool.Pool  // workers of the computations, or nil for the default pool
+
+	// srsFFT[r] FFT of [alpha^(r+(m-2)k)]gen, ..., [alpha^(r+k)]gen, [alpha^r]gen, 0...
+	srsFFT [][]bn254.G1Affine
+}
+
+// NewFK20Setup returns the FK20 precomputed data to open polynomials of size min(n, len(srs.G1))
+// on the cells of size cellSize of domain. cellSize must divide the domain cardinality.
+// The pool of the computations, of the setup and of ComputeCellProofs, is set with WithPool.
+func NewFK20Setup(srs *SRS, domain *fft.Domain, cellSize uint64, opts ...Option) (*FK20Setup, error) {
+	n := domain.Cardinality
+	if cellSize == 0 || cellSize > n || n%cellSize != 0 {
+		return nil, ErrInvalidCellSize
+	}
+	maxSize := n
+	if uint64(len(srs.G1)) < maxSize {
+		maxSize = uint64(len(srs.G1))
+	}
+
+	s := FK20Setup{
+		domain:   domain,
+		cellSize: int(cellSize),
+		nbRows:   int((maxSize + cellSize - 1) / cellSize),
+		pool:     newOptions(opts).pool,
+	}
+
+	// the proofs are computed at the powers of ω^k
+	s.cellDomain = fft.NewDomain(n/cellSize, 0, false, fft.WithPool(s.pool))
+	var omegaK fr.Element
+	omegaK.Exp(domain.Generator, new(big.Int).SetUint64(cellSize))
+	if !omegaK.Equal(&s.cellDomain.Generator) {
+		return nil, ErrInvalidDomain
+	}
+
+	if s.nbRows < 2 {
+		// the quotients are 0
+		return &s, nil
+	}
+
+	// the Toeplitz matrices of size m-1 are embedded in circulant matrices of size 2(m-1)
+	fftSize := ecc.NextPowerOfTwo(uint64(2 * (s.nbRows - 1)))
+	s.fftDomain = fft.NewDomain(fftSize, 0, false, fft.WithPool(s.pool))
+
+	s.srsFFT = make([][]bn254.G1Affine, s.cellSize)
+	for r := 0; r < s.cellSize; r++ {
+		points := make([]bn254.G1Jac, fftSize)
+		for v := 0; v < s.nbRows-1; v++ {
+			points[s.nbRows-2-v].FromAffine(&srs.G1[r+v*s.cellSize])
+		}
+		difFFTG1(points, s.fftDomain.Twiddles, 0, s.pool)
+		bitReverseG1Jac(points)
+		s.srsFFT[r] = make([]bn254.G1Affine, fftSize)
+		bn254.BatchJacobianToAffineG1(points, s.srsFFT[r])
+	}
+
+	return &s, nil
+}
+
+// ComputeCellProofs returns the opening proofs of p on the n/k cells of the domain of s.
+// p is in canonical form, in Montgomery form.
+func (s *FK20Setup) ComputeCellProofs(p polynomial.Polynomial) ([]bn254.G1Affine, error) {
+	if len(p) == 0 || len(p) > s.nbRows*s.cellSize || uint64(len(p)) > s.domain.Cardinality {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	nbCells := int(s.cellDomain.Cardinality)
+	res := make([]bn254.G1Affine, nbCells)
+	if s.nbRows < 2 {
+		return res, nil
+	}
+
+	// FFT of the rows p_r, p_(r+k), p_(r+2k)... of the Toeplitz matrices, scaled by 1/fftSize
+	// for the inverse FFT
+	fftSize := int(s.fftDomain.Cardinality)
+	coefficients := make([][]big.Int, s.cellSize)
+	s.pool.Execute(s.cellSize, func(start, end int) {
+		for r := start; r < end; r++ {
+			row := make([]fr.Element, fftSize)
+			for w := 0; w < s.nbRows && r+w*s.cellSize < len(p); w++ {
+				row[w] = p[r+w*s.cellSize]
+			}
+			s.fftDomain.FFT(row, fft.DIF, 0)
+			fft.BitReverse(row)
+			coefficients[r] = make([]big.Int, fftSize)
+			for j := 0; j < fftSize; j++ {
+				row[j].Mul(&row[j], &s.fftDomain.CardinalityInv)
+				row[j].ToBigIntRegular(&coefficients[r][j])
+			}
+		}
+	})
+
+	// Σ_r FFT(p_r) ⊙ FFT(srs_r)
+	c := make([]bn254.G1Jac, fftSize)
+	s.pool.Execute(fftSize, func(start, end int) {
+		var tmp bn254.G1Jac
+		for j := start; j < end; j++ {
+			for r := 0; r < s.cellSize; r++ {
+				tmp.FromAffine(&s.srsFFT[r][j])
+				tmp.ScalarMultiplication(&tmp, &coefficients[r][j])
+				c[j].AddAssign(&tmp)
+			}
+		}
+	})
+
+	// inverse FFT: h_t = c_(m-2+t), t = 1..m-1
+	difFFTG1(c, s.fftDomain.TwiddlesInv, 0, s.pool)
+	bitReverseG1Jac(c)
+
+	// the proofs are the FFT of h on the cells domain
+	h := make([]bn254.G1Jac, nbCells)
+	copy(h, c[s.nbRows-1:2*s.nbRows-2])
+	difFFTG1(h, s.cellDomain.Twiddles, 0, s.pool)
+	bitReverseG1Jac(h)
+	bn254.BatchJacobianToAffineG1(h, res)
+
+	return res, nil
+}
+
+// OpenAll computes the opening proofs of p at all the points of domain, in natural order,
+// with the FK20 algorithm, in O(n log(n)) instead of O(n²) for n calls to Open.
+// p is in canonical form, in Montgomery form.
+// The pool of the computations is set with WithPool; the FFT on domain runs on the pool of domain.
+func OpenAll(p polynomial.Polynomial, domain *fft.Domain, srs *SRS, opts ...Option) ([]OpeningProof, error) {
+	if len(p) == 0 || len(p) > len(srs.G1) {
+		return nil, ErrInvalidPolynomialSize
+	}
+	if uint64(len(p)) > domain.Cardinality {
+		return nil, ErrInvalidDomain
+	}
+
+	setup, err := NewFK20Setup(srs, domain, 1, opts...)
+	if err != nil {
+		return nil, err
+	}
+	proofs, err := setup.ComputeCellProofs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	// evaluations of p on the domain
+	evaluations := make([]fr.Element, domain.Cardinality)
+	copy(evaluations, p)
+	domain.FFT(evaluations, fft.DIF, 0)
+	fft.BitReverse(evaluations)
+
+	res := make([]OpeningProof, domain.Cardinality)
+	var point fr.Element
+	point.SetOne()
+	for i := 0; i < len(res); i++ {
+		res[i].H = proofs[i]
+		res[i].Point = point
+		res[i].ClaimedValue = evaluations[i]
+		point.Mul(&point, &domain.Generator)
+	}
+	return res, nil
+}
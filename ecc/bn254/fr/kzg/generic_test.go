@@ -0,0 +1,83 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func TestGenericKZG(t *testing.T) {
+	scheme := bn254.ID.KZG()
+
+	srs, err := scheme.NewSRS(32, big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := make([]*big.Int, 20)
+	for i := range p {
+		p[i], _ = rand.Int(rand.Reader, fr.Modulus())
+	}
+	point, _ := rand.Int(rand.Reader, fr.Modulus())
+
+	commitment, err := scheme.Commit(p, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := scheme.Open(p, point, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := scheme.Verify(commitment, &proof, srs); err != nil {
+		t.Fatal(err)
+	}
+
+	// the commitment is the one of the curve package
+	_p := toPolynomial(p)
+	digest, err := Commit(_p, srs.(*SRS))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !commitment.Equal((*bn254.GenericG1Affine)(&digest)) {
+		t.Fatal("wrong commitment")
+	}
+
+	// the SRS can be serialized
+	var buf bytes.Buffer
+	if _, err := srs.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	_srs := scheme.NewEmptySRS()
+	if _, err := _srs.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := scheme.Verify(commitment, &proof, _srs); err != nil {
+		t.Fatal(err)
+	}
+
+	// wrong claimed value
+	proof.ClaimedValue.Add(&proof.ClaimedValue, big.NewInt(1))
+	if err := scheme.Verify(commitment, &proof, srs); err == nil {
+		t.Fatal("verifying a wrong claimed value should fail")
+	}
+}
@@ -0,0 +1,806 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/internal/fptower"
+	"github.com/consensys/gnark-crypto/pool"
+)
+
+// This file reads and writes the setups of public powers of tau ceremonies on bn254:
+//   - the .ptau files of snarkjs (Hermez ceremony), points in Montgomery little-endian form;
+//   - the challenge (uncompressed big-endian points) and response (compressed big-endian points)
+//     files of the perpetual powers of tau ceremony (PPoT).
+
+var (
+	ErrInvalidPtauHeader      = errors.New("invalid ptau file: wrong magic, version or curve")
+	ErrInvalidPtauSection     = errors.New("invalid ptau file: unexpected section size")
+	ErrMissingPtauSection     = errors.New("invalid ptau file: missing section")
+	ErrInvalidAccumulatorSize = errors.New("accumulator sizes are not consistent with a power of 2")
+	ErrInvalidEncodedPoint    = errors.New("invalid point encoding")
+	ErrPointNotInSubgroup     = errors.New("point is not in the subgroup")
+	ErrInvalidAccumulator     = errors.New("accumulator points are not consistent")
+)
+
+// PPoTHashSize size of the blake2b hash prefixing PPoT challenge and response files
+const PPoTHashSize = 64
+
+// Accumulator powers of tau of a Groth16 phase 1 ceremony:
+// τ powers in G1 and G2, and α·τ, β·τ powers in G1.
+//
+// len(TauG1) = 2·2^power - 1 and len(TauG2) = len(AlphaTauG1) = len(BetaTauG1) = 2^power,
+// unless the accumulator was truncated on import with WithMaxSize.
+type Accumulator struct {
+	TauG1      []bn254.G1Affine // [τ^i]₁
+	TauG2      []bn254.G2Affine // [τ^i]₂
+	AlphaTauG1 []bn254.G1Affine // [α·τ^i]₁
+	BetaTauG1  []bn254.G1Affine // [β·τ^i]₁
+	BetaG2     bn254.G2Affine   // [β]₂
+
+	// Hash prefix of PPoT files (blake2b of the previous file of the ceremony)
+	Hash [PPoTHashSize]byte
+}
+
+// SRS returns the kzg SRS [τ^i]₁, ([1]₂, [τ]₂) of the accumulator.
+// The returned SRS shares its G1 points with the accumulator.
+func (acc *Accumulator) SRS() (*SRS, error) {
+	if len(acc.TauG1) < 2 || len(acc.TauG2) < 2 {
+		return nil, ErrMinSRSSize
+	}
+	var srs SRS
+	srs.G1 = acc.TauG1
+	srs.G2[0] = acc.TauG2[0]
+	srs.G2[1] = acc.TauG2[1]
+	return &srs, nil
+}
+
+// ImportOption option of the readers of external setups
+type ImportOption func(*importConfig)
+
+type importConfig struct {
+	maxSize          uint64
+	subgroupChecks   bool
+	consistencyCheck bool
+	pool             *pool.Pool
+}
+
+// WithMaxSize keeps only the first size points of each vector of the accumulator.
+// The other points are read and discarded, without being checked.
+func WithMaxSize(size uint64) ImportOption {
+	return func(cfg *importConfig) {
+		cfg.maxSize = size
+	}
+}
+
+// WithSubgroupChecks checks that all the imported points are on the curve and in the subgroup.
+func WithSubgroupChecks() ImportOption {
+	return func(cfg *importConfig) {
+		cfg.subgroupChecks = true
+	}
+}
+
+// WithConsistencyCheck checks, with random linear combinations and pairings, that the
+// imported points are successive powers of the same τ, consistent with α and β.
+func WithConsistencyCheck() ImportOption {
+	return func(cfg *importConfig) {
+		cfg.consistencyCheck = true
+	}
+}
+
+// WithImportPool decodes and checks the imported points on the pool p, instead of pool.Default().
+func WithImportPool(p *pool.Pool) ImportOption {
+	return func(cfg *importConfig) {
+		cfg.pool = p
+	}
+}
+
+// pointEncoding encoding of the points in the external setup files
+type pointEncoding uint8
+
+const (
+	// montgomeryLE snarkjs: coordinates in Montgomery form, little-endian, (0, 0) is infinity.
+	// E2 elements are A0 | A1.
+	montgomeryLE pointEncoding = iota
+
+	// uncompressedBE PPoT challenge: coordinates big-endian, first byte flag 0x40 for infinity.
+	// E2 elements are A1 | A0.
+	uncompressedBE
+
+	// compressedBE PPoT response: x big-endian, first byte flags 0x40 for infinity,
+	// 0x80 if y is the lexicographically largest root. E2 elements are A1 | A0.
+	compressedBE
+)
+
+const (
+	mPPoTInfinity byte = 0x40
+	mPPoTLargest  byte = 0x80
+	mPPoTFlags         = mPPoTInfinity | mPPoTLargest
+)
+
+func (enc pointEncoding) sizeG1() int {
+	if enc == compressedBE {
+		return fp.Bytes
+	}
+	return 2 * fp.Bytes
+}
+
+func (enc pointEncoding) sizeG2() int {
+	return 2 * enc.sizeG1()
+}
+
+// ptau sections
+const (
+	ptauMagic          = "ptau"
+	ptauVersion        = 1
+	ptauNbSections     = 7
+	ptauHeader         = 1
+	ptauTauG1          = 2
+	ptauTauG2          = 3
+	ptauAlphaTauG1     = 4
+	ptauBetaTauG1      = 5
+	ptauBetaG2         = 6
+	ptauContributions  = 7
+	ptauHeaderSize     = 4 + fp.Bytes + 4 + 4
+	ptauSectionHdrSize = 4 + 8
+)
+
+// ReadPtau reads the accumulator of a snarkjs .ptau file (phase 1, or prepared phase 2).
+// Sections other than the powers of τ, α and β (contributions, Lagrange bases) are skipped.
+func ReadPtau(r io.Reader, opts ...ImportOption) (*Accumulator, error) {
+	cfg := newImportConfig(opts)
+	br := bufio.NewReader(r)
+
+	var buf [ptauSectionHdrSize]byte
+	if _, err := io.ReadFull(br, buf[:12]); err != nil {
+		return nil, err
+	}
+	if string(buf[:4]) != ptauMagic || binary.LittleEndian.Uint32(buf[4:8]) != ptauVersion {
+		return nil, ErrInvalidPtauHeader
+	}
+	nbSections := binary.LittleEndian.Uint32(buf[8:12])
+
+	var acc Accumulator
+	power := -1
+	found := make(map[uint32]bool)
+	for i := uint32(0); i < nbSections; i++ {
+		if _, err := io.ReadFull(br, buf[:]); err != nil {
+			return nil, err
+		}
+		sectionType := binary.LittleEndian.Uint32(buf[:4])
+		sectionSize := binary.LittleEndian.Uint64(buf[4:])
+
+		if sectionType == ptauHeader {
+			if sectionSize != ptauHeaderSize {
+				return nil, ErrInvalidPtauHeader
+			}
+			var header [ptauHeaderSize]byte
+			if _, err := io.ReadFull(br, header[:]); err != nil {
+				return nil, err
+			}
+			if binary.LittleEndian.Uint32(header[:4]) != fp.Bytes {
+				return nil, ErrInvalidPtauHeader
+			}
+			for j := 0; j < fp.Bytes; j++ {
+				if header[4+j] != fpModulusBE[fp.Bytes-1-j] {
+					return nil, ErrInvalidPtauHeader
+				}
+			}
+			power = int(binary.LittleEndian.Uint32(header[4+fp.Bytes:]))
+			if power > 31 {
+				return nil, ErrInvalidPtauHeader
+			}
+			found[sectionType] = true
+			continue
+		}
+
+		if sectionType < ptauTauG1 || sectionType > ptauBetaG2 {
+			// skip contributions, Lagrange bases...
+			if _, err := io.CopyN(io.Discard, br, int64(sectionSize)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if power < 0 {
+			return nil, ErrMissingPtauSection
+		}
+		if err := readSection(br, &acc, sectionType, sectionSize, 1<<power, montgomeryLE, &cfg); err != nil {
+			return nil, err
+		}
+		found[sectionType] = true
+	}
+
+	for s := uint32(ptauHeader); s <= ptauBetaG2; s++ {
+		if !found[s] {
+			return nil, ErrMissingPtauSection
+		}
+	}
+
+	if err := cfg.check(&acc); err != nil {
+		return nil, err
+	}
+	return &acc, nil
+}
+
+// WritePtau writes the accumulator as a snarkjs .ptau file, with no contributions.
+// The accumulator must not be truncated.
+func WritePtau(w io.Writer, acc *Accumulator) error {
+	power, err := acc.power()
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	var buf [ptauHeaderSize]byte
+
+	copy(buf[:4], ptauMagic)
+	binary.LittleEndian.PutUint32(buf[4:8], ptauVersion)
+	binary.LittleEndian.PutUint32(buf[8:12], ptauNbSections)
+	if _, err := bw.Write(buf[:12]); err != nil {
+		return err
+	}
+
+	// header
+	writeSectionHeader := func(sectionType uint32, size int) error {
+		var hdr [ptauSectionHdrSize]byte
+		binary.LittleEndian.PutUint32(hdr[:4], sectionType)
+		binary.LittleEndian.PutUint64(hdr[4:], uint64(size))
+		_, err := bw.Write(hdr[:])
+		return err
+	}
+	if err := writeSectionHeader(ptauHeader, ptauHeaderSize); err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint32(buf[:4], fp.Bytes)
+	for j := 0; j < fp.Bytes; j++ {
+		buf[4+j] = fpModulusBE[fp.Bytes-1-j]
+	}
+	binary.LittleEndian.PutUint32(buf[4+fp.Bytes:], uint32(power))
+	binary.LittleEndian.PutUint32(buf[8+fp.Bytes:], uint32(power))
+	if _, err := bw.Write(buf[:]); err != nil {
+		return err
+	}
+
+	// points
+	sections := []struct {
+		sectionType uint32
+		g1          []bn254.G1Affine
+		g2          []bn254.G2Affine
+	}{
+		{sectionType: ptauTauG1, g1: acc.TauG1},
+		{sectionType: ptauTauG2, g2: acc.TauG2},
+		{sectionType: ptauAlphaTauG1, g1: acc.AlphaTauG1},
+		{sectionType: ptauBetaTauG1, g1: acc.BetaTauG1},
+		{sectionType: ptauBetaG2, g2: []bn254.G2Affine{acc.BetaG2}},
+	}
+	for _, s := range sections {
+		size := len(s.g1)*montgomeryLE.sizeG1() + len(s.g2)*montgomeryLE.sizeG2()
+		if err := writeSectionHeader(s.sectionType, size); err != nil {
+			return err
+		}
+		if err := writePoints(bw, s.g1, s.g2, montgomeryLE); err != nil {
+			return err
+		}
+	}
+
+	// no contributions
+	if err := writeSectionHeader(ptauContributions, 4); err != nil {
+		return err
+	}
+	if _, err := bw.Write(make([]byte, 4)); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// ReadPPoTChallenge reads the accumulator of a PPoT challenge file of 2^power powers.
+func ReadPPoTChallenge(r io.Reader, power uint8, opts ...ImportOption) (*Accumulator, error) {
+	return readPPoT(r, power, uncompressedBE, opts)
+}
+
+// ReadPPoTResponse reads the accumulator of a PPoT response file of 2^power powers.
+// The public key of the contribution that ends the file is not read.
+func ReadPPoTResponse(r io.Reader, power uint8, opts ...ImportOption) (*Accumulator, error) {
+	return readPPoT(r, power, compressedBE, opts)
+}
+
+// WritePPoTChallenge writes the accumulator as a PPoT challenge file, prefixed by acc.Hash.
+// The accumulator must not be truncated.
+func WritePPoTChallenge(w io.Writer, acc *Accumulator) error {
+	if _, err := acc.power(); err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(acc.Hash[:]); err != nil {
+		return err
+	}
+	toWrite := []struct {
+		g1 []bn254.G1Affine
+		g2 []bn254.G2Affine
+	}{
+		{g1: acc.TauG1},
+		{g2: acc.TauG2},
+		{g1: acc.AlphaTauG1},
+		{g1: acc.BetaTauG1},
+		{g2: []bn254.G2Affine{acc.BetaG2}},
+	}
+	for _, v := range toWrite {
+		if err := writePoints(bw, v.g1, v.g2, uncompressedBE); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func readPPoT(r io.Reader, power uint8, enc pointEncoding, opts []ImportOption) (*Accumulator, error) {
+	if power > 31 {
+		return nil, ErrInvalidAccumulatorSize
+	}
+	cfg := newImportConfig(opts)
+	br := bufio.NewReader(r)
+
+	var acc Accumulator
+	if _, err := io.ReadFull(br, acc.Hash[:]); err != nil {
+		return nil, err
+	}
+
+	n := uint64(1) << power
+	sections := []struct {
+		sectionType uint32
+		size        uint64
+	}{
+		{ptauTauG1, (2*n - 1) * uint64(enc.sizeG1())},
+		{ptauTauG2, n * uint64(enc.sizeG2())},
+		{ptauAlphaTauG1, n * uint64(enc.sizeG1())},
+		{ptauBetaTauG1, n * uint64(enc.sizeG1())},
+		{ptauBetaG2, uint64(enc.sizeG2())},
+	}
+	for _, s := range sections {
+		if err := readSection(br, &acc, s.sectionType, s.size, n, enc, &cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.check(&acc); err != nil {
+		return nil, err
+	}
+	return &acc, nil
+}
+
+// readSection reads the points of a section of an accumulator of n powers
+func readSection(r io.Reader, acc *Accumulator, sectionType uint32, sectionSize, n uint64, enc pointEncoding, cfg *importConfig) error {
+	var nbPoints uint64
+	var pointSize int
+	isG2 := sectionType == ptauTauG2 || sectionType == ptauBetaG2
+	switch sectionType {
+	case ptauTauG1:
+		nbPoints = 2*n - 1
+	case ptauBetaG2:
+		nbPoints = 1
+	default:
+		nbPoints = n
+	}
+	if isG2 {
+		pointSize = enc.sizeG2()
+	} else {
+		pointSize = enc.sizeG1()
+	}
+	if sectionSize != nbPoints*uint64(pointSize) {
+		return ErrInvalidPtauSection
+	}
+
+	nbKept := nbPoints
+	if cfg.maxSize != 0 && cfg.maxSize < nbKept {
+		nbKept = cfg.maxSize
+	}
+
+	var (
+		g1 []bn254.G1Affine
+		g2 []bn254.G2Affine
+	)
+	if isG2 {
+		g2 = make([]bn254.G2Affine, nbKept)
+	} else {
+		g1 = make([]bn254.G1Affine, nbKept)
+	}
+	if err := readPoints(r, g1, g2, enc, cfg.subgroupChecks, cfg.pool); err != nil {
+		return err
+	}
+	if _, err := io.CopyN(io.Discard, r, int64((nbPoints-nbKept)*uint64(pointSize))); err != nil {
+		return err
+	}
+
+	switch sectionType {
+	case ptauTauG1:
+		acc.TauG1 = g1
+	case ptauTauG2:
+		acc.TauG2 = g2
+	case ptauAlphaTauG1:
+		acc.AlphaTauG1 = g1
+	case ptauBetaTauG1:
+		acc.BetaTauG1 = g1
+	case ptauBetaG2:
+		acc.BetaG2 = g2[0]
+	}
+	return nil
+}
+
+// readPointsChunk number of points decoded in parallel at once
+const readPointsChunk = 1 << 16
+
+// readPoints reads len(g1) G1 points, or len(g2) G2 points
+func readPoints(r io.Reader, g1 []bn254.G1Affine, g2 []bn254.G2Affine, enc pointEncoding, subgroupChecks bool, pl *pool.Pool) error {
+	n, size := len(g1), enc.sizeG1()
+	if g2 != nil {
+		n, size = len(g2), enc.sizeG2()
+	}
+
+	buf := make([]byte, readPointsChunk*size)
+	for start := 0; start < n; start += readPointsChunk {
+		chunk := n - start
+		if chunk > readPointsChunk {
+			chunk = readPointsChunk
+		}
+		if _, err := io.ReadFull(r, buf[:chunk*size]); err != nil {
+			return err
+		}
+
+		var nbInvalid, nbNotInSubgroup uint64
+		pl.Execute(chunk, func(s, e int) {
+			for i := s; i < e; i++ {
+				b := buf[i*size : (i+1)*size]
+				if g2 != nil {
+					p := &g2[start+i]
+					if err := decodeG2(p, b, enc); err != nil {
+						atomic.AddUint64(&nbInvalid, 1)
+					} else if subgroupChecks && !p.IsInfinity() && !p.IsInSubGroup() {
+						atomic.AddUint64(&nbNotInSubgroup, 1)
+					}
+				} else {
+					p := &g1[start+i]
+					if err := decodeG1(p, b, enc); err != nil {
+						atomic.AddUint64(&nbInvalid, 1)
+					} else if subgroupChecks && !p.IsInfinity() && !p.IsInSubGroup() {
+						atomic.AddUint64(&nbNotInSubgroup, 1)
+					}
+				}
+			}
+		})
+		if nbInvalid != 0 {
+			return ErrInvalidEncodedPoint
+		}
+		if nbNotInSubgroup != 0 {
+			return ErrPointNotInSubgroup
+		}
+	}
+	return nil
+}
+
+// writePoints writes g1 then g2 points
+func writePoints(w io.Writer, g1 []bn254.G1Affine, g2 []bn254.G2Affine, enc pointEncoding) error {
+	var buf [4 * fp.Bytes]byte
+	for i := 0; i < len(g1); i++ {
+		b := buf[:enc.sizeG1()]
+		encodeG1(b, &g1[i], enc)
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < len(g2); i++ {
+		b := buf[:enc.sizeG2()]
+		encodeG2(b, &g2[i], enc)
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeG1(p *bn254.G1Affine, b []byte, enc pointEncoding) error {
+	switch enc {
+	case montgomeryLE:
+		if err := decodeElementLE(&p.X, b[:fp.Bytes]); err != nil {
+			return err
+		}
+		return decodeElementLE(&p.Y, b[fp.Bytes:])
+	case uncompressedBE:
+		if isPPoTInfinity(b) {
+			*p = bn254.G1Affine{}
+			return nil
+		}
+		if b[0]&mPPoTFlags != 0 {
+			return ErrInvalidEncodedPoint
+		}
+		if err := decodeElementBE(&p.X, b[:fp.Bytes]); err != nil {
+			return err
+		}
+		return decodeElementBE(&p.Y, b[fp.Bytes:])
+	default:
+		if isPPoTInfinity(b) {
+			*p = bn254.G1Affine{}
+			return nil
+		}
+		largest := b[0]&mPPoTLargest != 0
+		var x [fp.Bytes]byte
+		copy(x[:], b)
+		x[0] &^= mPPoTFlags
+		if err := decodeElementBE(&p.X, x[:]); err != nil {
+			return err
+		}
+		// y² = x³ + 3
+		var y fp.Element
+		y.Square(&p.X).Mul(&y, &p.X).Add(&y, &bCurveCoeff)
+		if y.Legendre() == -1 {
+			return ErrInvalidEncodedPoint
+		}
+		y.Sqrt(&y)
+		if y.LexicographicallyLargest() != largest {
+			y.Neg(&y)
+		}
+		p.Y = y
+		return nil
+	}
+}
+
+func decodeG2(p *bn254.G2Affine, b []byte, enc pointEncoding) error {
+	switch enc {
+	case montgomeryLE:
+		for i, e := range []*fp.Element{&p.X.A0, &p.X.A1, &p.Y.A0, &p.Y.A1} {
+			if err := decodeElementLE(e, b[i*fp.Bytes:(i+1)*fp.Bytes]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case uncompressedBE:
+		if isPPoTInfinity(b) {
+			*p = bn254.G2Affine{}
+			return nil
+		}
+		if b[0]&mPPoTFlags != 0 {
+			return ErrInvalidEncodedPoint
+		}
+		for i, e := range []*fp.Element{&p.X.A1, &p.X.A0, &p.Y.A1, &p.Y.A0} {
+			if err := decodeElementBE(e, b[i*fp.Bytes:(i+1)*fp.Bytes]); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		if isPPoTInfinity(b) {
+			*p = bn254.G2Affine{}
+			return nil
+		}
+		largest := b[0]&mPPoTLargest != 0
+		var x [2 * fp.Bytes]byte
+		copy(x[:], b)
+		x[0] &^= mPPoTFlags
+		if err := decodeElementBE(&p.X.A1, x[:fp.Bytes]); err != nil {
+			return err
+		}
+		if err := decodeElementBE(&p.X.A0, x[fp.Bytes:]); err != nil {
+			return err
+		}
+		// y² = x³ + 3/(9+u)
+		var y fptower.E2
+		y.Square(&p.X).Mul(&y, &p.X).Add(&y, &bTwistCurveCoeff)
+		if y.Legendre() == -1 {
+			return ErrInvalidEncodedPoint
+		}
+		y.Sqrt(&y)
+		if y.LexicographicallyLargest() != largest {
+			y.Neg(&y)
+		}
+		p.Y = y
+		return nil
+	}
+}
+
+func encodeG1(b []byte, p *bn254.G1Affine, enc pointEncoding) {
+	if enc == montgomeryLE {
+		encodeElementLE(b[:fp.Bytes], &p.X)
+		encodeElementLE(b[fp.Bytes:], &p.Y)
+		return
+	}
+	for i := range b {
+		b[i] = 0
+	}
+	if p.IsInfinity() {
+		b[0] = mPPoTInfinity
+		return
+	}
+	bx := p.X.Bytes()
+	copy(b, bx[:])
+	if enc == compressedBE {
+		if p.Y.LexicographicallyLargest() {
+			b[0] |= mPPoTLargest
+		}
+		return
+	}
+	by := p.Y.Bytes()
+	copy(b[fp.Bytes:], by[:])
+}
+
+func encodeG2(b []byte, p *bn254.G2Affine, enc pointEncoding) {
+	if enc == montgomeryLE {
+		for i, e := range []*fp.Element{&p.X.A0, &p.X.A1, &p.Y.A0, &p.Y.A1} {
+			encodeElementLE(b[i*fp.Bytes:(i+1)*fp.Bytes], e)
+		}
+		return
+	}
+	for i := range b {
+		b[i] = 0
+	}
+	if p.IsInfinity() {
+		b[0] = mPPoTInfinity
+		return
+	}
+	coordinates := []*fp.Element{&p.X.A1, &p.X.A0, &p.Y.A1, &p.Y.A0}
+	if enc == compressedBE {
+		coordinates = coordinates[:2]
+	}
+	for i, e := range coordinates {
+		be := e.Bytes()
+		copy(b[i*fp.Bytes:], be[:])
+	}
+	if enc == compressedBE && p.Y.LexicographicallyLargest() {
+		b[0] |= mPPoTLargest
+	}
+}
+
+// decodeElementLE sets e from its Montgomery form in little-endian
+func decodeElementLE(e *fp.Element, b []byte) error {
+	var be [fp.Bytes]byte
+	for i := 0; i < fp.Bytes; i++ {
+		be[i] = b[fp.Bytes-1-i]
+	}
+	if bytes.Compare(be[:], fpModulusBE[:]) >= 0 {
+		return ErrInvalidEncodedPoint
+	}
+	for i := 0; i < fp.Limbs; i++ {
+		e[i] = binary.LittleEndian.Uint64(b[i*8:])
+	}
+	return nil
+}
+
+// encodeElementLE writes the Montgomery form of e in little-endian
+func encodeElementLE(b []byte, e *fp.Element) {
+	for i := 0; i < fp.Limbs; i++ {
+		binary.LittleEndian.PutUint64(b[i*8:], e[i])
+	}
+}
+
+// decodeElementBE sets e from its regular form in big-endian
+func decodeElementBE(e *fp.Element, b []byte) error {
+	if bytes.Compare(b, fpModulusBE[:]) >= 0 {
+		return ErrInvalidEncodedPoint
+	}
+	e.SetBytes(b)
+	return nil
+}
+
+// isPPoTInfinity returns true if b is the PPoT encoding of the point at infinity
+func isPPoTInfinity(b []byte) bool {
+	if b[0] != mPPoTInfinity {
+		return false
+	}
+	for i := 1; i < len(b); i++ {
+		if b[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// power returns log2 of the number of powers of the accumulator
+func (acc *Accumulator) power() (int, error) {
+	n := len(acc.TauG2)
+	if n == 0 || n&(n-1) != 0 || len(acc.TauG1) != 2*n-1 || len(acc.AlphaTauG1) != n || len(acc.BetaTauG1) != n {
+		return 0, ErrInvalidAccumulatorSize
+	}
+	return bits.TrailingZeros(uint(n)), nil
+}
+
+func newImportConfig(opts []ImportOption) importConfig {
+	var cfg importConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// check runs the consistency checks of the accumulator if required
+func (cfg *importConfig) check(acc *Accumulator) error {
+	if !cfg.consistencyCheck {
+		return nil
+	}
+	if len(acc.TauG1) < 2 || len(acc.TauG2) < 2 || len(acc.AlphaTauG1) < 2 || len(acc.BetaTauG1) < 2 {
+		return ErrInvalidAccumulatorSize
+	}
+	_, _, g1, g2 := bn254.Generators()
+	if !acc.TauG1[0].Equal(&g1) || !acc.TauG2[0].Equal(&g2) {
+		return ErrInvalidAccumulator
+	}
+
+	tauG2 := [2]bn254.G2Affine{acc.TauG2[0], acc.TauG2[1]}
+	tauG1 := [2]bn254.G1Affine{acc.TauG1[0], acc.TauG1[1]}
+	if !areSuccessivePowers(acc.TauG1, &tauG2, cfg.pool) ||
+		!areSuccessivePowers(acc.AlphaTauG1, &tauG2, cfg.pool) ||
+		!areSuccessivePowers(acc.BetaTauG1, &tauG2, cfg.pool) ||
+		!areSuccessivePowersG2(acc.TauG2, &tauG1, cfg.pool) {
+		return ErrInvalidAccumulator
+	}
+
+	// [β]₁ and [β]₂ have the same discrete log
+	if !sameRatio(g1, acc.BetaTauG1[0], g2, acc.BetaG2) {
+		return ErrInvalidAccumulator
+	}
+	return nil
+}
+
+// areSuccessivePowersG2 checks with a random linear combination that g2[i+1] = τ·g2[i],
+// where g1 = [gen, [τ]gen]
+func areSuccessivePowersG2(g2 []bn254.G2Affine, g1 *[2]bn254.G1Affine, pl *pool.Pool) bool {
+	n := len(g2) - 1
+
+	var rho fr.Element
+	if _, err := rho.SetRandom(); err != nil {
+		return false
+	}
+	rhos := make([]fr.Element, n)
+	rhos[0].SetOne()
+	for i := 1; i < n; i++ {
+		rhos[i].Mul(&rhos[i-1], &rho)
+	}
+
+	var l, r bn254.G2Affine
+	config := ecc.MultiExpConfig{ScalarsMont: true, Pool: pl}
+	if _, err := l.MultiExp(g2[:n], rhos, config); err != nil {
+		return false
+	}
+	if _, err := r.MultiExp(g2[1:], rhos, config); err != nil {
+		return false
+	}
+
+	return sameRatio(g1[0], g1[1], l, r)
+}
+
+var (
+	fpModulusBE      [fp.Bytes]byte
+	bCurveCoeff      fp.Element
+	bTwistCurveCoeff fptower.E2
+)
+
+func init() {
+	fp.Modulus().FillBytes(fpModulusBE[:])
+
+	bCurveCoeff.SetUint64(3)
+	bTwistCurveCoeff.A0.SetUint64(9)
+	bTwistCurveCoeff.A1.SetUint64(1)
+	bTwistCurveCoeff.Inverse(&bTwistCurveCoeff)
+	bTwistCurveCoeff.MulByElement(&bTwistCurveCoeff, &bCurveCoeff)
+}
@@ -0,0 +1,356 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	"github.com/consensys/gnark-crypto/pool"
+)
+
+// testAccumulator returns an accumulator of 2^power powers with known τ, α, β
+func testAccumulator(power uint8) *Accumulator {
+	n := 1 << power
+	var tau, alpha, beta fr.Element
+	tau.SetUint64(42)
+	alpha.SetUint64(43)
+	beta.SetUint64(44)
+
+	powers := make([]fr.Element, 2*n-1)
+	alphaPowers := make([]fr.Element, n)
+	betaPowers := make([]fr.Element, n)
+	powers[0].SetOne()
+	for i := 1; i < len(powers); i++ {
+		powers[i].Mul(&powers[i-1], &tau)
+	}
+	for i := 0; i < n; i++ {
+		alphaPowers[i].Mul(&powers[i], &alpha).FromMont()
+		betaPowers[i].Mul(&powers[i], &beta).FromMont()
+	}
+	powersG2 := make([]fr.Element, n)
+	copy(powersG2, powers)
+	for i := 0; i < len(powers); i++ {
+		powers[i].FromMont()
+	}
+
+	_, _, g1, g2 := bn254.Generators()
+	var acc Accumulator
+	acc.TauG1 = bn254.BatchScalarMultiplicationG1(&g1, powers)
+	acc.AlphaTauG1 = bn254.BatchScalarMultiplicationG1(&g1, alphaPowers)
+	acc.BetaTauG1 = bn254.BatchScalarMultiplicationG1(&g1, betaPowers)
+	acc.TauG2 = make([]bn254.G2Affine, n)
+	var b big.Int
+	for i := 0; i < n; i++ {
+		acc.TauG2[i].ScalarMultiplication(&g2, powersG2[i].ToBigIntRegular(&b))
+	}
+	acc.BetaG2.ScalarMultiplication(&g2, beta.ToBigIntRegular(&b))
+	for i := 0; i < PPoTHashSize; i++ {
+		acc.Hash[i] = byte(i)
+	}
+	return &acc
+}
+
+func assertAccumulatorEqual(t *testing.T, expected, acc *Accumulator) {
+	t.Helper()
+	equalG1 := func(a, b []bn254.G1Affine) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !a[i].Equal(&b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if !equalG1(expected.TauG1, acc.TauG1) || !equalG1(expected.AlphaTauG1, acc.AlphaTauG1) ||
+		!equalG1(expected.BetaTauG1, acc.BetaTauG1) || len(expected.TauG2) != len(acc.TauG2) ||
+		!expected.BetaG2.Equal(&acc.BetaG2) {
+		t.Fatal("accumulators differ")
+	}
+	for i := range expected.TauG2 {
+		if !expected.TauG2[i].Equal(&acc.TauG2[i]) {
+			t.Fatal("accumulators differ")
+		}
+	}
+}
+
+func TestPtau(t *testing.T) {
+
+	const power = 4
+	expected := testAccumulator(power)
+
+	var buf bytes.Buffer
+	if err := WritePtau(&buf, expected); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buf.Bytes()
+
+	acc, err := ReadPtau(bytes.NewReader(encoded), WithSubgroupChecks(), WithConsistencyCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc.Hash = expected.Hash
+	assertAccumulatorEqual(t, expected, acc)
+
+	// import on a custom pool
+	pl := pool.New(2)
+	defer pl.Stop()
+	acc, err = ReadPtau(bytes.NewReader(encoded), WithSubgroupChecks(), WithConsistencyCheck(), WithImportPool(pl))
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc.Hash = expected.Hash
+	assertAccumulatorEqual(t, expected, acc)
+
+	// the first point is the generator (1, 2), in Montgomery form little-endian
+	// 12 bytes of file header, 12 bytes of section header + 44 bytes of header, 12 bytes of section header
+	const offset = 12 + 12 + 44 + 12
+	one, _ := hex.DecodeString("9d0d8fc58d435dd33d0bc7f528eb780a2c4679786fa36e662fdf079ac1770a0e")
+	two, _ := hex.DecodeString("3a1b1e8b1b87baa67b168eeb51d6f114588cf2f0de46ddcc5ebe0f3483ef141c")
+	if !bytes.Equal(encoded[offset:offset+32], one) || !bytes.Equal(encoded[offset+32:offset+64], two) {
+		t.Fatal("unexpected encoding of the generator")
+	}
+
+	// truncated import
+	acc, err = ReadPtau(bytes.NewReader(encoded), WithMaxSize(8), WithConsistencyCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(acc.TauG1) != 8 || len(acc.TauG2) != 8 || !acc.TauG1[7].Equal(&expected.TauG1[7]) {
+		t.Fatal("truncated import should keep the first points")
+	}
+
+	// wrong point
+	corrupted := make([]byte, len(encoded))
+	copy(corrupted, encoded)
+	copy(corrupted[offset+3*64:offset+4*64], encoded[offset+2*64:offset+3*64])
+	if _, err := ReadPtau(bytes.NewReader(corrupted), WithConsistencyCheck()); err != ErrInvalidAccumulator {
+		t.Fatal("inconsistent powers should be rejected")
+	}
+
+	// non canonical coordinate
+	copy(corrupted, encoded)
+	for i := 0; i < 32; i++ {
+		corrupted[offset+i] = 0xff
+	}
+	if _, err := ReadPtau(bytes.NewReader(corrupted)); err != ErrInvalidEncodedPoint {
+		t.Fatal("non canonical coordinate should be rejected")
+	}
+
+	// point not on the curve
+	copy(corrupted, encoded)
+	corrupted[offset+64] ^= 1
+	if _, err := ReadPtau(bytes.NewReader(corrupted), WithSubgroupChecks()); err != ErrPointNotInSubgroup {
+		t.Fatal("point not on the curve should be rejected")
+	}
+
+	// wrong magic
+	copy(corrupted, encoded)
+	corrupted[0] = 'x'
+	if _, err := ReadPtau(bytes.NewReader(corrupted)); err != ErrInvalidPtauHeader {
+		t.Fatal("wrong magic should be rejected")
+	}
+}
+
+func TestPPoT(t *testing.T) {
+
+	const power = 4
+	expected := testAccumulator(power)
+
+	// challenge
+	var buf bytes.Buffer
+	if err := WritePPoTChallenge(&buf, expected); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != PPoTHashSize+(2*16-1)*64+16*128+2*16*64+128 {
+		t.Fatal("unexpected challenge size")
+	}
+	acc, err := ReadPPoTChallenge(&buf, power, WithSubgroupChecks(), WithConsistencyCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertAccumulatorEqual(t, expected, acc)
+	if acc.Hash != expected.Hash {
+		t.Fatal("hash differs")
+	}
+
+	// response, with compressed points followed by the contribution public key
+	buf.Reset()
+	buf.Write(expected.Hash[:])
+	toWrite := []struct {
+		g1 []bn254.G1Affine
+		g2 []bn254.G2Affine
+	}{
+		{g1: expected.TauG1},
+		{g2: expected.TauG2},
+		{g1: expected.AlphaTauG1},
+		{g1: expected.BetaTauG1},
+		{g2: []bn254.G2Affine{expected.BetaG2}},
+	}
+	for _, v := range toWrite {
+		if err := writePoints(&buf, v.g1, v.g2, compressedBE); err != nil {
+			t.Fatal(err)
+		}
+	}
+	buf.Write(make([]byte, 768))
+	acc, err = ReadPPoTResponse(&buf, power, WithSubgroupChecks(), WithConsistencyCheck())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertAccumulatorEqual(t, expected, acc)
+
+	// the setup is usable as a kzg SRS
+	srs, err := acc.SRS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := randomPolynomial(16)
+	digest, err := Commit(f, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var point fr.Element
+	point.SetRandom()
+	proof, err := Open(f, &point, fft.NewDomain(16, 0, false), srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(&digest, &proof, srs); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPPoTPointEncoding(t *testing.T) {
+
+	// generator and infinity, uncompressed and compressed
+	_, _, g1, g2 := bn254.Generators()
+	var minusG1 bn254.G1Affine
+	minusG1.Neg(&g1)
+	var minusG2 bn254.G2Affine
+	minusG2.Neg(&g2)
+
+	for _, enc := range []pointEncoding{montgomeryLE, uncompressedBE, compressedBE} {
+		for _, p := range []bn254.G1Affine{g1, minusG1, {}} {
+			b := make([]byte, enc.sizeG1())
+			encodeG1(b, &p, enc)
+			var q bn254.G1Affine
+			if err := decodeG1(&q, b, enc); err != nil {
+				t.Fatal(err)
+			}
+			if !q.Equal(&p) {
+				t.Fatal("G1 encoding round trip failed")
+			}
+		}
+		for _, p := range []bn254.G2Affine{g2, minusG2, {}} {
+			b := make([]byte, enc.sizeG2())
+			encodeG2(b, &p, enc)
+			var q bn254.G2Affine
+			if err := decodeG2(&q, b, enc); err != nil {
+				t.Fatal(err)
+			}
+			if !q.Equal(&p) {
+				t.Fatal("G2 encoding round trip failed")
+			}
+		}
+	}
+
+	b := make([]byte, uncompressedBE.sizeG1())
+	encodeG1(b, &g1, uncompressedBE)
+	if b[31] != 1 || b[63] != 2 {
+		t.Fatal("unexpected uncompressed encoding of the generator")
+	}
+	encodeG1(b[:32], &g1, compressedBE)
+	if b[0]&mPPoTLargest != 0 {
+		t.Fatal("y = 2 is not the largest root")
+	}
+}
+
+func TestSnarkjsPointEncoding(t *testing.T) {
+
+	// Groth16 header section of a zkey written by snarkjs (testdata/authV2/circuit_final.zkey of
+	// github.com/iden3/go-jwz v1.0.0). snarkjs writes its points as in the .ptau files:
+	// n8q | q | n8r | r | nVars | nPublic | domainSize | [α]₁ | [β]₁ | [β]₂ | [γ]₂ | [δ]₁ | [δ]₂
+	header, err := os.ReadFile("testdata/snarkjs_groth16_header.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if binary.LittleEndian.Uint32(header) != fp.Bytes || binary.LittleEndian.Uint32(header[4+fp.Bytes:]) != fr.Bytes {
+		t.Fatal("unexpected field sizes")
+	}
+	leToBigInt := func(b []byte) *big.Int {
+		be := make([]byte, len(b))
+		for i := range b {
+			be[len(b)-1-i] = b[i]
+		}
+		return new(big.Int).SetBytes(be)
+	}
+	q := leToBigInt(header[4 : 4+fp.Bytes])
+	r := leToBigInt(header[8+fp.Bytes : 8+fp.Bytes+fr.Bytes])
+	if q.Cmp(fp.Modulus()) != 0 || r.Cmp(fr.Modulus()) != 0 {
+		t.Fatal("the zkey is not on bn254")
+	}
+
+	b := header[8+fp.Bytes+fr.Bytes+12:]
+	var g1 [3]bn254.G1Affine // α, β, δ
+	var g2 [3]bn254.G2Affine // β, γ, δ
+	for _, p := range []*bn254.G1Affine{&g1[0], &g1[1]} {
+		if err := decodeG1(p, b, montgomeryLE); err != nil {
+			t.Fatal(err)
+		}
+		b = b[montgomeryLE.sizeG1():]
+	}
+	for _, p := range []*bn254.G2Affine{&g2[0], &g2[1]} {
+		if err := decodeG2(p, b, montgomeryLE); err != nil {
+			t.Fatal(err)
+		}
+		b = b[montgomeryLE.sizeG2():]
+	}
+	if err := decodeG1(&g1[2], b, montgomeryLE); err != nil {
+		t.Fatal(err)
+	}
+	if err := decodeG2(&g2[2], b[montgomeryLE.sizeG1():], montgomeryLE); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range g1 {
+		if g1[i].IsInfinity() || !g1[i].IsInSubGroup() {
+			t.Fatal("G1 point not decoded in the subgroup")
+		}
+		if g2[i].IsInfinity() || !g2[i].IsInSubGroup() {
+			t.Fatal("G2 point not decoded in the subgroup")
+		}
+	}
+
+	// snarkjs sets γ = 1
+	_, _, gen1, gen2 := bn254.Generators()
+	if !g2[1].Equal(&gen2) {
+		t.Fatal("[γ]₂ should be the generator of G2")
+	}
+
+	// [β]₁ and [β]₂, [δ]₁ and [δ]₂ have the same discrete logarithms
+	if !sameRatio(gen1, g1[1], gen2, g2[0]) || !sameRatio(gen1, g1[2], gen2, g2[2]) {
+		t.Fatal("the G1 and G2 points are not consistent")
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mimc
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func TestMiMC(t *testing.T) {
+	msg := []byte("the quick brown fox jumps over the lazy dog, in several blocks of field elements")
+
+	// digest of the default parameters, which must not change
+	sum, err := Sum("seed", msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(sum) != "11004218039dad35468029398cd11f0cad5274e7ffd77d0f5053297301ca76e9" {
+		t.Fatal("the digest of the default parameters should not change")
+	}
+
+	// New without options is NewMiMC
+	h1 := NewMiMC("seed")
+	h2, err := New("seed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h1.Write(msg)
+	h2.Write(msg)
+	if hex.EncodeToString(h1.Sum(nil)) != hex.EncodeToString(h2.Sum(nil)) {
+		t.Fatal("New without options should be NewMiMC")
+	}
+
+	// the number of rounds is derived from the exponent
+	h3, err := New("seed", WithExponent(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h3.(*digest).Params) != 220/2 || h3.(*digest).exponent != 5 {
+		t.Fatal("the number of rounds should be derived from the exponent")
+	}
+	h4, err := New("seed", WithExponent(5), WithNbRounds(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h3.Write(msg)
+	h4.Write(msg)
+	if len(h4.(*digest).Params) != 10 || hex.EncodeToString(h3.Sum(nil)) == hex.EncodeToString(h4.Sum(nil)) {
+		t.Fatal("the number of rounds should be set by WithNbRounds")
+	}
+
+	// invalid options
+	for _, e := range []int{-1, 1, 2, 4} {
+		if _, err := New("seed", WithExponent(e)); err != ErrInvalidExponent {
+			t.Fatalf("x^%d should be rejected", e)
+		}
+	}
+	if _, err := New("seed", WithNbRounds(-1)); err != ErrInvalidNbRounds {
+		t.Fatal("negative number of rounds should be rejected")
+	}
+}
+
+func TestSBox(t *testing.T) {
+	var x, expected fr.Element
+	for _, e := range []int{3, 5, 7, 11, 17} {
+		x.SetRandom()
+		expected.Exp(x, big.NewInt(int64(e)))
+		sBox(&x, e)
+		if !x.Equal(&expected) {
+			t.Fatalf("sBox should compute x^%d", e)
+		}
+	}
+	x.SetRandom()
+	expected.Inverse(&x)
+	sBox(&x, -1)
+	if !x.Equal(&expected) {
+		t.Fatal("sBox should compute 1/x")
+	}
+}
+
+func TestSponge(t *testing.T) {
+	// https://github.com/iden3/circomlib/blob/master/test/mimcspongecircuit.js
+	var one, two fr.Element
+	one.SetOne()
+	two.SetUint64(2)
+	outputs, err := MultiHash([]fr.Element{one, two}, fr.Element{}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"2bcea035a1251603f1ceaf73cd4ae89427c47075bb8e3a944039ff1e3d6d2a6f",
+		"2f7d340a3c24b8ef9899ab5f019b85b87354c7f6c965a19ca090321f7e5425e9",
+		"0cf71423c39e70b9858eaa8e1dc3ac40a09c3927dc31d12014af16066f2bdcb6",
+	}
+	for i := range expected {
+		b := outputs[i].Bytes()
+		if hex.EncodeToString(b[:]) != expected[i] {
+			t.Fatal("MultiHash should be circomlib's MiMCSponge")
+		}
+	}
+
+	var key fr.Element
+	key.SetRandom()
+	inputs := make([]fr.Element, 5)
+	for i := range inputs {
+		inputs[i].SetRandom()
+	}
+
+	// the outputs are the successive permutations of the state
+	s, err := NewSponge(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.constants) != 220 || s.exponent != 5 {
+		t.Fatal("wrong default parameters")
+	}
+	s.Absorb(inputs...)
+	res := s.Squeeze(3)
+	var xL, xR fr.Element
+	for i := range inputs {
+		xL.Add(&xL, &inputs[i])
+		s.Permutation(&xL, &xR)
+	}
+	for i := range res {
+		if i > 0 {
+			s.Permutation(&xL, &xR)
+		}
+		if !res[i].Equal(&xL) {
+			t.Fatal("Squeeze should output the left half of the successive states")
+		}
+	}
+
+	// Reset, and MultiHash
+	s.Reset()
+	s.Absorb(inputs...)
+	res2, err := MultiHash(inputs, key, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Squeeze(1)[0].Equal(&res[0]) || !res2[2].Equal(&res[2]) {
+		t.Fatal("MultiHash should absorb the inputs in a reset sponge")
+	}
+	if _, err := MultiHash(inputs, key, 0); err == nil {
+		t.Fatal("MultiHash without output should be rejected")
+	}
+	if _, err := NewSponge(key, WithNbRounds(1)); err != ErrInvalidNbRounds {
+		t.Fatal("a single round should be rejected")
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkMiMC(b *testing.B) {
+	var x fr.Element
+	x.SetRandom()
+	buf := x.Bytes()
+	h := NewMiMC("seed")
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		h.Reset()
+		h.Write(buf[:])
+		h.Sum(nil)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func TestDivMod(t *testing.T) {
+
+	// schoolbook and Newton divisions, and a divisor with leading zeros
+	for _, sizes := range [][2]int{{30, 10}, {10, 30}, {500, 200}, {700, 130}} {
+		a := randomPolynomial(sizes[0])
+		b := randomPolynomial(sizes[1])
+		b = append(b, make(Polynomial, 3)...)
+
+		q, r := DivMod(a, b)
+		if len(r) >= sizes[1] {
+			t.Fatal("the remainder is too big")
+		}
+
+		// a = b*q + r
+		var g Polynomial
+		g.Mul(b, q)
+		g.Add(g, r)
+		g = trim(g)
+		if !g.Equal(a) {
+			t.Fatal("a != b*q + r")
+		}
+
+		var _q Polynomial
+		_q.Div(a, b)
+		if !_q.Equal(q) {
+			t.Fatal("Div and DivMod differ")
+		}
+	}
+}
+
+func TestDivModNewton(t *testing.T) {
+
+	a := randomPolynomial(400)
+	b := randomPolynomial(150)
+
+	q1, r1 := divModSchoolbook(a, b)
+	q2, r2 := divModNewton(a, b)
+	if !q1.Equal(q2) || !r1.Equal(r2) {
+		t.Fatal("schoolbook and Newton divisions differ")
+	}
+
+	// exact division
+	var c Polynomial
+	c.Mul(a, b)
+	q, r := DivMod(c, b)
+	if !q.Equal(a) || !isZero(r) {
+		t.Fatal("exact division fails")
+	}
+}
+
+func TestGCD(t *testing.T) {
+
+	// gcd(f*g, f*h) = f/lc(f), g and h being coprime with overwhelming probability
+	f := randomPolynomial(20)
+	g := randomPolynomial(30)
+	h := randomPolynomial(15)
+
+	var a, b, d Polynomial
+	a.Mul(f, g)
+	b.Mul(f, h)
+	d.GCD(a, b)
+
+	var lInv fr.Element
+	lInv.Inverse(&f[len(f)-1])
+	f.ScaleInPlace(&lInv)
+	if !d.Equal(f) {
+		t.Fatal("gcd fails")
+	}
+
+	// gcd(a, 0) = a/lc(a)
+	d.GCD(f, nil)
+	if !d.Equal(f) {
+		t.Fatal("gcd fails")
+	}
+}
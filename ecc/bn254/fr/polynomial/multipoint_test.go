@@ -0,0 +1,94 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func TestEvalMultiPoints(t *testing.T) {
+
+	for _, size := range []int{10, 100, 257} {
+		f := randomPolynomial(300)
+		x := make([]fr.Element, size)
+		for i := 0; i < size; i++ {
+			x[i].SetRandom()
+		}
+
+		evals := f.EvalMultiPoints(x)
+		for i := 0; i < size; i++ {
+			if e := f.Eval(&x[i]); !e.Equal(&evals[i]) {
+				t.Fatal("multi points evaluation fails")
+			}
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+
+	for _, size := range []int{1, 10, 100, 257} {
+		x := make([]fr.Element, size)
+		y := make([]fr.Element, size)
+		for i := 0; i < size; i++ {
+			x[i].SetRandom()
+			y[i].SetRandom()
+		}
+
+		f, err := Interpolate(x, y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(f) != size {
+			t.Fatal("wrong size of the interpolation")
+		}
+		for i := 0; i < size; i++ {
+			if e := f.Eval(&x[i]); !e.Equal(&y[i]) {
+				t.Fatal("interpolation fails")
+			}
+		}
+	}
+
+}
+
+func TestInterpolateErrors(t *testing.T) {
+
+	// duplicate points, below and above multiPointThreshold
+	for _, size := range []int{3, 50} {
+		x := make([]fr.Element, size)
+		for i := 0; i < len(x); i++ {
+			x[i].SetUint64(uint64(i))
+		}
+		x[size-1].Set(&x[1])
+		if _, err := Interpolate(x, make([]fr.Element, len(x))); err != ErrInterpolationDuplicatePoints {
+			t.Fatalf("duplicate points should be rejected (%d points)", size)
+		}
+	}
+
+	// as many values as points
+	x := make([]fr.Element, 10)
+	for i := 0; i < len(x); i++ {
+		x[i].SetUint64(uint64(i))
+	}
+	if _, err := Interpolate(x, make([]fr.Element, 3)); err != ErrInterpolationSize {
+		t.Fatal("fewer values than points should be rejected")
+	}
+	if _, err := Interpolate(x[:3], make([]fr.Element, len(x))); err != ErrInterpolationSize {
+		t.Fatal("more values than points should be rejected")
+	}
+}
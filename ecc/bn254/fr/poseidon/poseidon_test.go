@@ -0,0 +1,192 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package poseidon
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+func TestHashCircomlib(t *testing.T) {
+	// https://github.com/iden3/circomlibjs/blob/main/test/poseidon.js
+	vectors := []struct {
+		inputs   []uint64
+		expected string
+	}{
+		{[]uint64{1}, "18586133768512220936620570745912940619677854269274689475585506675881198879027"},
+		{[]uint64{1, 2}, "7853200120776062878684798364095072458815029376092732009249414926327459813530"},
+		{[]uint64{1, 2, 3, 4}, "18821383157269793795438455681495246036402687001665670618754263018637548127333"},
+		{[]uint64{1, 2, 0, 0, 0}, "1018317224307729531995786483840663576608797660851238720571059489595066344487"},
+		{[]uint64{3, 4, 0, 0, 0}, "5811595552068139067952687508729883632420015185677766880877743348592482390548"},
+	}
+	for _, v := range vectors {
+		inputs := make([]fr.Element, len(v.inputs))
+		for i := range inputs {
+			inputs[i].SetUint64(v.inputs[i])
+		}
+		h, err := Hash(inputs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var expected fr.Element
+		expected.SetString(v.expected)
+		if !h.Equal(&expected) {
+			t.Fatalf("Poseidon(%v) should be the one of circomlib", v.inputs)
+		}
+	}
+}
+
+func TestPermutation(t *testing.T) {
+	for width := 2; width <= MaxWidth; width++ {
+		params, err := NewDefaultParameters(width)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if params.NbPartialRounds%width != 0 || len(params.RoundKeys) != params.NbFullRounds+params.NbPartialRounds {
+			t.Fatal("wrong number of rounds")
+		}
+		if other, _ := NewDefaultParameters(width); other != params {
+			t.Fatal("default parameters should be computed once")
+		}
+
+		// Hash is the first element of the permutation of (0, inputs...)
+		state := make([]fr.Element, width)
+		for i := 1; i < width; i++ {
+			state[i].SetRandom()
+		}
+		h, err := Hash(state[1:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := params.Permutation(state); err != nil {
+			t.Fatal(err)
+		}
+		if !h.Equal(&state[0]) {
+			t.Fatal("Hash should be consistent with the permutation")
+		}
+
+		// the permutation doesn't map two states to the same one
+		state2 := make([]fr.Element, width)
+		copy(state2, state)
+		state2[width-1].SetOne().Add(&state2[width-1], &state[width-1])
+		if err := params.Permutation(state); err != nil {
+			t.Fatal(err)
+		}
+		if err := params.Permutation(state2); err != nil {
+			t.Fatal(err)
+		}
+		for i := range state {
+			if state[i].Equal(&state2[i]) {
+				t.Fatal("different states should have different images")
+			}
+		}
+
+		if err := params.Permutation(state[1:]); err != ErrInvalidSizeState {
+			t.Fatal("state of the wrong size should be rejected")
+		}
+	}
+
+	// invalid parameters
+	for _, width := range []int{-1, 0, 1, MaxWidth + 1} {
+		if _, err := NewDefaultParameters(width); err != ErrInvalidWidth {
+			t.Fatal("invalid width should be rejected")
+		}
+	}
+	if _, err := NewParameters(3, 7, 57); err != ErrInvalidNbRounds {
+		t.Fatal("odd number of full rounds should be rejected")
+	}
+	if _, err := Hash(nil); err != ErrInvalidNbInputs {
+		t.Fatal("empty input should be rejected")
+	}
+	if _, err := Hash(make([]fr.Element, MaxWidth)); err != ErrInvalidNbInputs {
+		t.Fatal("too many inputs should be rejected")
+	}
+}
+
+func TestSponge(t *testing.T) {
+	params, err := NewDefaultParameters(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 5 elements, absorbed in 3 blocks of 2 elements
+	var elements [5]fr.Element
+	var data []byte
+	for i := range elements {
+		elements[i].SetRandom()
+		b := elements[i].Bytes()
+		data = append(data, b[:]...)
+	}
+	state := make([]fr.Element, 3)
+	for i := 0; i < len(elements); i += 2 {
+		state[1].Add(&state[1], &elements[i])
+		if i+1 < len(elements) {
+			state[2].Add(&state[2], &elements[i+1])
+		}
+		if err := params.Permutation(state); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := state[0].Bytes()
+
+	h := NewSponge(params)
+	h.Write(data[:50])
+	h.Write(data[50:])
+	if string(h.Sum(nil)) != string(expected[:]) {
+		t.Fatal("the sponge should absorb the elements by blocks of width-1 elements")
+	}
+	if string(h.Sum(nil)) != string(expected[:]) {
+		t.Fatal("Sum should not change the state of the hash")
+	}
+	if string(NewPoseidon().Sum(nil)) == string(expected[:]) {
+		t.Fatal("the empty message should have a different digest")
+	}
+	h.Reset()
+	h.Write(data)
+	if string(h.Sum(nil)) != string(expected[:]) || h.Size() != fr.Bytes || h.BlockSize() != BlockSize {
+		t.Fatal("Reset should reset the hash")
+	}
+
+	// a message of 2 elements is circomlib's Poseidon hash of the elements
+	h.Reset()
+	h.Write(data[:2*BlockSize])
+	hash, err := Hash(elements[:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := hash.Bytes()
+	if string(h.Sum(nil)) != string(b[:]) {
+		t.Fatal("the sponge should be consistent with Hash")
+	}
+}
+
+// ------------------------------------------------------------
+// benches
+
+func BenchmarkPermutation(b *testing.B) {
+	params, _ := NewDefaultParameters(3)
+	var state [3]fr.Element
+	for i := range state {
+		state[i].SetRandom()
+	}
+
+	b.ResetTimer()
+	for j := 0; j < b.N; j++ {
+		_ = params.Permutation(state[:])
+	}
+}
@@ -181,6 +181,62 @@ func TestEddsaSHA256(t *testing.T) {
 
 }
 
+func TestBatchVerify(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	hFunc := sha256.New()
+
+	const nbSignatures = 20
+	pubKeys := make([]PublicKey, nbSignatures)
+	messages := make([][]byte, nbSignatures)
+	sigs := make([][]byte, nbSignatures)
+	for i := 0; i < nbSignatures; i++ {
+		privKey, err := GenerateKey(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubKeys[i] = privKey.PublicKey
+		messages[i] = []byte(fmt.Sprintf("message %d", i))
+		sigs[i], err = privKey.Sign(messages[i], hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// valid signatures, and empty batch
+	if valid, i, err := BatchVerify(pubKeys, messages, sigs, hFunc); !valid || i != -1 || err != nil {
+		t.Fatal("batch of valid signatures should be accepted")
+	}
+	if valid, _, err := BatchVerify(nil, nil, nil, hFunc); !valid || err != nil {
+		t.Fatal("empty batch should be accepted")
+	}
+
+	// wrong message
+	messages[7] = []byte("wrong message")
+	if valid, i, err := BatchVerify(pubKeys, messages, sigs, hFunc); valid || i != 7 || err != nil {
+		t.Fatal("batch with a wrong message should be rejected, and the invalid signature found")
+	}
+
+	// signature of another key, and wrong sizes
+	messages[7] = []byte("message 7")
+	sigs[3], sigs[4] = sigs[4], sigs[3]
+	if valid, i, _ := BatchVerify(pubKeys, messages, sigs, hFunc); valid || i != 3 {
+		t.Fatal("batch with a swapped signature should be rejected, and the invalid signature found")
+	}
+	sigs[3], sigs[4] = sigs[4], sigs[3]
+	if _, _, err := BatchVerify(pubKeys, messages, sigs[1:], hFunc); err == nil {
+		t.Fatal("wrong number of signatures should be rejected")
+	}
+
+	// truncated signature
+	sigs[12] = sigs[12][:len(sigs[12])-1]
+	if valid, i, err := BatchVerify(pubKeys, messages, sigs, hFunc); valid || i != 12 || err == nil {
+		t.Fatal("batch with an invalid encoding should be rejected, and the invalid signature found")
+	}
+}
+
 // benchmarks
 
 func BenchmarkVerify(b *testing.B) {
@@ -206,3 +262,27 @@ func BenchmarkVerify(b *testing.B) {
 		pubKey.Verify(signature, msgBin[:], hFunc)
 	}
 }
+
+func BenchmarkBatchVerify(b *testing.B) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src)
+
+	hFunc := sha256.New()
+
+	const nbSignatures = 256
+	pubKeys := make([]PublicKey, nbSignatures)
+	messages := make([][]byte, nbSignatures)
+	sigs := make([][]byte, nbSignatures)
+	for i := 0; i < nbSignatures; i++ {
+		privKey, _ := GenerateKey(r)
+		pubKeys[i] = privKey.PublicKey
+		messages[i] = []byte(fmt.Sprintf("message %d", i))
+		sigs[i], _ = privKey.Sign(messages[i], hFunc)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BatchVerify(pubKeys, messages, sigs, hFunc)
+	}
+}
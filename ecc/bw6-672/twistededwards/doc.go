@@ -14,5 +14,5 @@
 
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
-// Package mockcommitment provides a mock commitment scheme, for development and test purposes.
-package mockcommitment
+// Package twistededwards provides bw6-672's twisted edwards "companion curve" defined on fr.
+package twistededwards
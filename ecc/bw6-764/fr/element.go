@@ -25,9 +25,11 @@ package fr
 import (
 	"crypto/rand"
 	"encoding/binary"
+	"errors"
 	"io"
 	"math/big"
 	"math/bits"
+	"reflect"
 	"strconv"
 	"sync"
 )
@@ -106,28 +108,30 @@ func (z *Element) Set(x *Element) *Element {
 	return z
 }
 
-// SetInterface converts i1 from uint64, int, string, or Element, big.Int into Element
-// panic if provided type is not supported
-func (z *Element) SetInterface(i1 interface{}) *Element {
+// SetInterface converts provided interface into Element
+// returns an error if provided type is not supported
+// supported types: Element, *Element, uint64, int, string (interpreted as base10 integer),
+// *big.Int, big.Int, []byte
+func (z *Element) SetInterface(i1 interface{}) (*Element, error) {
 	switch c1 := i1.(type) {
 	case Element:
-		return z.Set(&c1)
+		return z.Set(&c1), nil
 	case *Element:
-		return z.Set(c1)
+		return z.Set(c1), nil
 	case uint64:
-		return z.SetUint64(c1)
+		return z.SetUint64(c1), nil
 	case int:
-		return z.SetString(strconv.Itoa(c1))
+		return z.SetString(strconv.Itoa(c1)), nil
 	case string:
-		return z.SetString(c1)
+		return z.SetString(c1), nil
 	case *big.Int:
-		return z.SetBigInt(c1)
+		return z.SetBigInt(c1), nil
 	case big.Int:
-		return z.SetBigInt(&c1)
+		return z.SetBigInt(&c1), nil
 	case []byte:
-		return z.SetBytes(c1)
+		return z.SetBytes(c1), nil
 	default:
-		panic("invalid type")
+		return nil, errors.New("can't set fr.Element from type " + reflect.TypeOf(i1).String())
 	}
 }
 
@@ -161,6 +165,16 @@ func (z *Element) Div(x, y *Element) *Element {
 	return z
 }
 
+// Bit returns the i'th bit, with lsb == bit 0.
+// It is the responsability of the caller to convert from Montgomery to Regular form if needed
+func (z *Element) Bit(i uint64) uint64 {
+	j := i / 64
+	if j >= 6 {
+		return 0
+	}
+	return uint64(z[j] >> (i % 64) & 1)
+}
+
 // Equal returns z == x
 func (z *Element) Equal(x *Element) bool {
 	return (z[5] == x[5]) && (z[4] == x[4]) && (z[3] == x[3]) && (z[2] == x[2]) && (z[1] == x[1]) && (z[0] == x[0])
@@ -171,6 +185,11 @@ func (z *Element) IsZero() bool {
 	return (z[5] | z[4] | z[3] | z[2] | z[1] | z[0]) == 0
 }
 
+// IsUint64 returns true if z[0] >= 0 and all other words are 0
+func (z *Element) IsUint64() bool {
+	return (z[5] | z[4] | z[3] | z[2] | z[1]) == 0
+}
+
 // Cmp compares (lexicographic order) z and x and returns:
 //
 //   -1 if z <  x
@@ -674,6 +693,33 @@ func BatchInvert(a []Element) []Element {
 	return res
 }
 
+func _butterflyGeneric(a, b *Element) {
+	t := *a
+	a.Add(a, b)
+	b.Sub(&t, b)
+}
+
+// BitLen returns the minimum number of bits needed to represent z
+// returns 0 if z == 0
+func (z *Element) BitLen() int {
+	if z[5] != 0 {
+		return 320 + bits.Len64(z[5])
+	}
+	if z[4] != 0 {
+		return 256 + bits.Len64(z[4])
+	}
+	if z[3] != 0 {
+		return 192 + bits.Len64(z[3])
+	}
+	if z[2] != 0 {
+		return 128 + bits.Len64(z[2])
+	}
+	if z[1] != 0 {
+		return 64 + bits.Len64(z[1])
+	}
+	return bits.Len64(z[0])
+}
+
 // Exp z = x^exponent mod q
 func (z *Element) Exp(x Element, exponent *big.Int) *Element {
 	var bZero big.Int
@@ -937,8 +983,17 @@ func (z *Element) Sqrt(x *Element) *Element {
 // Algorithm 16 in "Efficient Software-Implementation of Finite Fields with Applications to Cryptography"
 // if x == 0, sets and returns z = x
 func (z *Element) Inverse(x *Element) *Element {
+	inverse(z, x)
+	return z
+}
+
+// _inverseGeneric z = x^-1 mod q
+// Algorithm 16 in "Efficient Software-Implementation of Finite Fields with Applications to Cryptography"
+// if x == 0, sets and returns z = x
+func _inverseGeneric(z, x *Element) {
 	if x.IsZero() {
-		return z.Set(x)
+		z.SetZero()
+		return
 	}
 
 	// initialize u = q
@@ -966,29 +1021,20 @@ func (z *Element) Inverse(x *Element) *Element {
 
 	v := *x
 
-	var carry, borrow, t, t2 uint64
+	var carry, borrow uint64
 	var bigger bool
 
 	for {
 		for v[0]&1 == 0 {
 
 			// v = v >> 1
-			t2 = v[5] << 63
+
+			v[0] = v[0]>>1 | v[1]<<63
+			v[1] = v[1]>>1 | v[2]<<63
+			v[2] = v[2]>>1 | v[3]<<63
+			v[3] = v[3]>>1 | v[4]<<63
+			v[4] = v[4]>>1 | v[5]<<63
 			v[5] >>= 1
-			t = t2
-			t2 = v[4] << 63
-			v[4] = (v[4] >> 1) | t
-			t = t2
-			t2 = v[3] << 63
-			v[3] = (v[3] >> 1) | t
-			t = t2
-			t2 = v[2] << 63
-			v[2] = (v[2] >> 1) | t
-			t = t2
-			t2 = v[1] << 63
-			v[1] = (v[1] >> 1) | t
-			t = t2
-			v[0] = (v[0] >> 1) | t
 
 			if s[0]&1 == 1 {
 
@@ -1003,43 +1049,25 @@ func (z *Element) Inverse(x *Element) *Element {
 			}
 
 			// s = s >> 1
-			t2 = s[5] << 63
+
+			s[0] = s[0]>>1 | s[1]<<63
+			s[1] = s[1]>>1 | s[2]<<63
+			s[2] = s[2]>>1 | s[3]<<63
+			s[3] = s[3]>>1 | s[4]<<63
+			s[4] = s[4]>>1 | s[5]<<63
 			s[5] >>= 1
-			t = t2
-			t2 = s[4] << 63
-			s[4] = (s[4] >> 1) | t
-			t = t2
-			t2 = s[3] << 63
-			s[3] = (s[3] >> 1) | t
-			t = t2
-			t2 = s[2] << 63
-			s[2] = (s[2] >> 1) | t
-			t = t2
-			t2 = s[1] << 63
-			s[1] = (s[1] >> 1) | t
-			t = t2
-			s[0] = (s[0] >> 1) | t
 
 		}
 		for u[0]&1 == 0 {
 
 			// u = u >> 1
-			t2 = u[5] << 63
+
+			u[0] = u[0]>>1 | u[1]<<63
+			u[1] = u[1]>>1 | u[2]<<63
+			u[2] = u[2]>>1 | u[3]<<63
+			u[3] = u[3]>>1 | u[4]<<63
+			u[4] = u[4]>>1 | u[5]<<63
 			u[5] >>= 1
-			t = t2
-			t2 = u[4] << 63
-			u[4] = (u[4] >> 1) | t
-			t = t2
-			t2 = u[3] << 63
-			u[3] = (u[3] >> 1) | t
-			t = t2
-			t2 = u[2] << 63
-			u[2] = (u[2] >> 1) | t
-			t = t2
-			t2 = u[1] << 63
-			u[1] = (u[1] >> 1) | t
-			t = t2
-			u[0] = (u[0] >> 1) | t
 
 			if r[0]&1 == 1 {
 
@@ -1054,22 +1082,13 @@ func (z *Element) Inverse(x *Element) *Element {
 			}
 
 			// r = r >> 1
-			t2 = r[5] << 63
+
+			r[0] = r[0]>>1 | r[1]<<63
+			r[1] = r[1]>>1 | r[2]<<63
+			r[2] = r[2]>>1 | r[3]<<63
+			r[3] = r[3]>>1 | r[4]<<63
+			r[4] = r[4]>>1 | r[5]<<63
 			r[5] >>= 1
-			t = t2
-			t2 = r[4] << 63
-			r[4] = (r[4] >> 1) | t
-			t = t2
-			t2 = r[3] << 63
-			r[3] = (r[3] >> 1) | t
-			t = t2
-			t2 = r[2] << 63
-			r[2] = (r[2] >> 1) | t
-			t = t2
-			t2 = r[1] << 63
-			r[1] = (r[1] >> 1) | t
-			t = t2
-			r[0] = (r[0] >> 1) | t
 
 		}
 
@@ -1136,10 +1155,12 @@ func (z *Element) Inverse(x *Element) *Element {
 			}
 		}
 		if (u[0] == 1) && (u[5]|u[4]|u[3]|u[2]|u[1]) == 0 {
-			return z.Set(&r)
+			z.Set(&r)
+			return
 		}
 		if (v[0] == 1) && (v[5]|v[4]|v[3]|v[2]|v[1]) == 0 {
-			return z.Set(&s)
+			z.Set(&s)
+			return
 		}
 	}
 
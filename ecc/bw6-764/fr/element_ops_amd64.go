@@ -45,3 +45,9 @@ func fromMont(res *Element)
 
 //go:noescape
 func reduce(res *Element)
+
+//go:noescape
+func Butterfly(a, b *Element)
+
+//go:noescape
+func inverse(res, x *Element)
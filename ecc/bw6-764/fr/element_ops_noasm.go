@@ -1,3 +1,4 @@
+//go:build !amd64
 // +build !amd64
 
 // Copyright 2020 ConsenSys Software Inc.
@@ -39,10 +40,21 @@ func MulBy13(x *Element) {
 	mulByConstant(x, 13)
 }
 
+// Butterfly sets
+// a = a + b
+// b = a - b
+func Butterfly(a, b *Element) {
+	_butterflyGeneric(a, b)
+}
+
 func mul(z, x, y *Element) {
 	_mulGeneric(z, x, y)
 }
 
+func inverse(z, x *Element) {
+	_inverseGeneric(z, x)
+}
+
 // FromMont converts z in place (i.e. mutates) from Montgomery to regular representation
 // sets and returns z = z * 1
 func fromMont(z *Element) {
@@ -1,3 +1,4 @@
+//go:build gofuzz
 // +build gofuzz
 
 // Copyright 2020 ConsenSys Software Inc.
@@ -21,6 +22,9 @@ package fft
 import (
 	"bytes"
 	"fmt"
+	"github.com/consensys/gnark-crypto/ecc"
+
+	"github.com/consensys/gnark-crypto/ecc/bw6-764/fr"
 )
 
 const (
@@ -40,7 +44,7 @@ func Fuzz(data []byte) int {
 	if size > (1 << 15) {
 		size = 1 << 15
 	}
-	paddedSize := nextPowerOfTwo(uint64(size))
+	paddedSize := ecc.NextPowerOfTwo(uint64(size))
 	p1 := make([]fr.Element, paddedSize)
 	p2 := make([]fr.Element, paddedSize)
 	for i := 0; i < len(p1); i++ {
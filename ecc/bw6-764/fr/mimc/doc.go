@@ -14,5 +14,9 @@
 
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
-// Package mimc provides MiMC hash function using Miyaguchi–Preneel construction.
+// Package mimc provides MiMC hash function using Miyaguchi–Preneel construction, and the
+// MiMC-Feistel sponge with multiple outputs of circomlib.
+//
+// The exponent of the s-box and the number of rounds can be set with options. The default
+// parameters of NewMiMC are kept for compatibility.
 package mimc
@@ -46,7 +46,7 @@ func TestPolynomialEval(t *testing.T) {
 	expectedEval.Div(&expectedEval, &den)
 
 	// compute purported evaluation
-	purportedEval := f.Eval(&point).(fr.Element)
+	purportedEval := f.Eval(&point)
 
 	// check
 	if !purportedEval.Equal(&expectedEval) {
@@ -160,27 +160,27 @@ func TestPolynomialAdd(t *testing.T) {
 
 	// caller is empty
 	var g Polynomial
-	g.Add(&f1, &f2)
-	if !g.Equal(&expectedSum) {
+	g.Add(f1, f2)
+	if !g.Equal(expectedSum) {
 		t.Fatal("add polynomials fails")
 	}
-	if !f1.Equal(&f1Backup) {
+	if !f1.Equal(f1Backup) {
 		t.Fatal("side effect, f1 should not have been modified")
 	}
-	if !f2.Equal(&f2Backup) {
+	if !f2.Equal(f2Backup) {
 		t.Fatal("side effect, f2 should not have been modified")
 	}
 
 	// all operands are distincts
 	_f1 := f1.Clone()
-	_f1.Add(&f1, &f2)
-	if !_f1.Equal(&expectedSum) {
+	_f1.Add(f1, f2)
+	if !_f1.Equal(expectedSum) {
 		t.Fatal("add polynomials fails")
 	}
-	if !f1.Equal(&f1Backup) {
+	if !f1.Equal(f1Backup) {
 		t.Fatal("side effect, f1 should not have been modified")
 	}
-	if !f2.Equal(&f2Backup) {
+	if !f2.Equal(f2Backup) {
 		t.Fatal("side effect, f2 should not have been modified")
 	}
 
@@ -188,10 +188,10 @@ func TestPolynomialAdd(t *testing.T) {
 	_f1 = f1.Clone()
 	_f2 := f2.Clone()
 	_f1.Add(_f1, _f2)
-	if !_f1.Equal(&expectedSum) {
+	if !_f1.Equal(expectedSum) {
 		t.Fatal("add polynomials fails")
 	}
-	if !_f2.Equal(&f2Backup) {
+	if !_f2.Equal(f2Backup) {
 		t.Fatal("side effect, _f2 should not have been modified")
 	}
 
@@ -199,10 +199,132 @@ func TestPolynomialAdd(t *testing.T) {
 	_f1 = f1.Clone()
 	_f2 = f2.Clone()
 	_f1.Add(_f2, _f1)
-	if !_f1.Equal(&expectedSum) {
+	if !_f1.Equal(expectedSum) {
 		t.Fatal("add polynomials fails")
 	}
-	if !_f2.Equal(&f2Backup) {
+	if !_f2.Equal(f2Backup) {
 		t.Fatal("side effect, _f2 should not have been modified")
 	}
 }
+
+func TestPolynomialSub(t *testing.T) {
+
+	f1 := randomPolynomial(20)
+	f2 := randomPolynomial(10)
+
+	// (f1 - f2) + f2 = f1, and (f2 - f1) + f1 = f2
+	var g Polynomial
+	g.Sub(f1, f2)
+	g.Add(g, f2)
+	if !g.Equal(f1) {
+		t.Fatal("sub polynomials fails")
+	}
+	g.Sub(f2, f1)
+	g.Add(g, f1)
+	g = trim(g)
+	if !g.Equal(f2) {
+		t.Fatal("sub polynomials fails")
+	}
+
+	// first operand = caller
+	_f1 := f1.Clone()
+	_f1.Sub(_f1, f2)
+	var expected fr.Element
+	for i := 0; i < 20; i++ {
+		expected.Set(&f1[i])
+		if i < 10 {
+			expected.Sub(&expected, &f2[i])
+		}
+		if !_f1[i].Equal(&expected) {
+			t.Fatal("sub polynomials fails")
+		}
+	}
+}
+
+func TestPolynomialMul(t *testing.T) {
+
+	var point fr.Element
+	point.SetRandom()
+
+	// schoolbook and FFT products
+	for _, sizes := range [][2]int{{10, 20}, {100, 300}, {257, 256}} {
+		f1 := randomPolynomial(sizes[0])
+		f2 := randomPolynomial(sizes[1])
+
+		var g Polynomial
+		g.Mul(f1, f2)
+		if len(g) != sizes[0]+sizes[1]-1 {
+			t.Fatal("wrong size of the product")
+		}
+
+		expected := f1.Eval(&point)
+		e2 := f2.Eval(&point)
+		expected.Mul(&expected, &e2)
+		if e := g.Eval(&point); !e.Equal(&expected) {
+			t.Fatal("mul polynomials fails")
+		}
+
+		// the caller can be an operand
+		f1.Mul(f1, f2)
+		if !f1.Equal(g) {
+			t.Fatal("mul polynomials fails")
+		}
+	}
+}
+
+func TestPolynomialDerivative(t *testing.T) {
+
+	// f = Σ_{i<20} X^i, f' = Σ_{i<19} (i+1)X^i
+	f := make(Polynomial, 20)
+	for i := 0; i < 20; i++ {
+		f[i].SetOne()
+	}
+
+	var g Polynomial
+	g.Derivative(f)
+	if len(g) != 19 {
+		t.Fatal("wrong size of the derivative")
+	}
+	var expected fr.Element
+	for i := 0; i < 19; i++ {
+		expected.SetUint64(uint64(i + 1))
+		if !g[i].Equal(&expected) {
+			t.Fatal("derivative fails")
+		}
+	}
+
+	// in place
+	f.Derivative(f)
+	if !f.Equal(g) {
+		t.Fatal("derivative fails")
+	}
+}
+
+func TestPolynomialCompose(t *testing.T) {
+
+	f1 := randomPolynomial(10)
+	f2 := randomPolynomial(20)
+
+	var point fr.Element
+	point.SetRandom()
+
+	var g Polynomial
+	g.Compose(f1, f2)
+	if len(g) != 9*19+1 {
+		t.Fatal("wrong size of the composition")
+	}
+
+	e2 := f2.Eval(&point)
+	expected := f1.Eval(&e2)
+	if e := g.Eval(&point); !e.Equal(&expected) {
+		t.Fatal("compose polynomials fails")
+	}
+}
+
+func randomPolynomial(size int) Polynomial {
+	f := make(Polynomial, size)
+	for i := 0; i < size; i++ {
+		f[i].SetRandom()
+	}
+	return f
+}
@@ -63,11 +63,11 @@ func svdwMapG1(u fp.Element) G1Affine {
 	// constants
 	// sage script to find z: https://tools.ietf.org/html/draft-irtf-cfrg-hash-to-curve-06#appendix-E.1
 	var z, c1, c2, c3, c4 fp.Element
-	z.SetString("6891450384315732539396789682275657542479668912536150109513790160209623422243491736087683183289411687640864567753786613451161759120554247759349511699125301598951605099378508850372543631423596795951899700429969112842764913119068298")
-	c1.SetString("6891450384315732539396789682275657542479668912536150109513790160209623422243491736087683183289411687640864567753786613451161759120554247759349511699125301598951605099378508850372543631423596795951899700429969112842764913119068297")
-	c2.SetString("3445725192157866269698394841137828771239834456268075054756895080104811711121745868043841591644705843820432283876893306725580879560277123879674755849562650799475802549689254425186271815711798397975949850214984556421382456559534150")
-	c3.SetString("2182960152898512813619964857824135058119549744733933027309101102500149371618029333119454649414652046451676139417492373311188410797637685205282384107101848498985501847368325320398966905951842291237024226539185867974515894527324091")
-	c4.SetString("2297150128105244179798929894091885847493222970845383369837930053403207807414497245362561061096470562546954855917928871150387253040184749253116503899708433866317201699792836283457514543807865598650633233476656370947588304373022769")
+	z.SetString("2")
+	c1.SetString("9")
+	c2.SetString("68329444329132864467197568511618182454014420331056460436975631002437675229068809106541898992803088028958632075576179474454705413190143815496594695210093719775938505552919338551925737771092989947112892199011722292890815739205779588")
+	c3.SetString("8038758156368572303212607352224080721815160692633827492410761118317497083577898731899545077925528678213246302981750191052737066222958595046886589270118991430336882591797343393465900768911628754918073149023397655664152068569031144")
+	c4.SetString("68329444329132864467197568511618182454014420331056460436975631002437675229068809106541898992803088028958632075576179474454705413190143815496594695210093719775938505552919338551925737771092989947112892199011722292890815739205779586")
 
 	var tv1, tv2, tv3, tv4, one, x1, gx1, x2, gx2, x3, x, gx, y fp.Element
 	one.SetOne()
@@ -169,10 +169,10 @@ func svdwMapG2(u fp.Element) G2Affine {
 	// sage script to find z: https://tools.ietf.org/html/draft-irtf-cfrg-hash-to-curve-06#appendix-E.1
 	var z, c1, c2, c3, c4 fp.Element
 	z.SetOne()
-	c1.SetString("5")
-	c2.SetString("3445725192157866269698394841137828771239834456268075054756895080104811711121745868043841591644705843820432283876893306725580879560277123879674755849562650799475802549689254425186271815711798397975949850214984556421382456559534149")
-	c3.SetString("378874360756025602651941062713020507280436976585470911399777026151523911612119848324634470943575106408430190507547620786752781340972262719171181914238577622702998866608798996925461957883547117052976856458948797244029892674069463")
-	c4.SetString("4594300256210488359597859788183771694986445941690766739675860106806415614828994490725122122192941125093909711835857742300774506080369498506233007799416867732634403399585672566915029087615731197301266466953312741895176608746045526")
+	c1.SetString("3")
+	c2.SetString("34164722164566432233598784255809091227007210165528230218487815501218837614534404553270949496401544014479316037788089737227352706595071907748297347605046859887969252776459669275962868885546494973556446099505861146445407869602889794")
+	c3.SetString("10416465880335151874249458439172451409091000278551027579268015541056431233596379682991614557487836364322108820119305044815358518686125891419303558286853644135194493720729499857839522395632922994097373484855182227027273855733400522")
+	c4.SetString("68329444329132864467197568511618182454014420331056460436975631002437675229068809106541898992803088028958632075576179474454705413190143815496594695210093719775938505552919338551925737771092989947112892199011722292890815739205779585")
 
 	var tv1, tv2, tv3, tv4, one, x1, gx1, x2, gx2, x3, x, gx, y fp.Element
 	one.SetOne()
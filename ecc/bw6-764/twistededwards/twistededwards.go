@@ -0,0 +1,42 @@
+package twistededwards
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bw6-764/fr"
+)
+
+// CurveParams curve parameters: ax^2 + y^2 = 1 + d*x^2*y^2
+type CurveParams struct {
+	A, D     fr.Element // in Montgomery form
+	Cofactor fr.Element // not in Montgomery form
+	Order    big.Int
+	Base     PointAffine
+}
+
+var edwards CurveParams
+
+// GetEdwardsCurve returns the twisted Edwards curve on BW6-764's Fr
+func GetEdwardsCurve() CurveParams {
+	// copy to keep Order private
+	var res CurveParams
+
+	res.A.Set(&edwards.A)
+	res.D.Set(&edwards.D)
+	res.Cofactor.Set(&edwards.Cofactor)
+	res.Order.Set(&edwards.Order)
+	res.Base.Set(&edwards.Base)
+
+	return res
+}
+
+func init() {
+
+	edwards.A.SetOne().Neg(&edwards.A)
+	edwards.D.SetString("204395105068627677694261897296258810628652976175332740073653477015428765835342568954052951943468159619851364608369")
+	edwards.Cofactor.SetUint64(8).FromMont()
+	edwards.Order.SetString("80931978090014473999925243547232995618559437358571023475166871003004580055654869432628160602301937371157243215867", 10)
+
+	edwards.Base.X.SetString("254322441161856519397674638197177118518584457799123385257046571974599440352142494741881145699829067043667597510534")
+	edwards.Base.Y.SetString("577806886812761853736578098966499467356352302249655739176665872708573476126530165647958786702648966704324039831290")
+}
@@ -0,0 +1,330 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/cp8-632/fr"
+)
+
+func TestPolynomialEval(t *testing.T) {
+
+	// build polynomial
+	f := make(Polynomial, 20)
+	for i := 0; i < 20; i++ {
+		f[i].SetOne()
+	}
+
+	// random value
+	var point fr.Element
+	point.SetRandom()
+
+	// compute manually f(val)
+	var expectedEval, one, den fr.Element
+	var expo big.Int
+	one.SetOne()
+	expo.SetUint64(20)
+	expectedEval.Exp(point, &expo).
+		Sub(&expectedEval, &one)
+	den.Sub(&point, &one)
+	expectedEval.Div(&expectedEval, &den)
+
+	// compute purported evaluation
+	purportedEval := f.Eval(&point)
+
+	// check
+	if !purportedEval.Equal(&expectedEval) {
+		t.Fatal("polynomial evaluation failed")
+	}
+}
+
+func TestPolynomialAddConstantInPlace(t *testing.T) {
+
+	// build polynomial
+	f := make(Polynomial, 20)
+	for i := 0; i < 20; i++ {
+		f[i].SetOne()
+	}
+
+	// constant to add
+	var c fr.Element
+	c.SetRandom()
+
+	// add constant
+	f.AddConstantInPlace(&c)
+
+	// check
+	var expectedCoeffs, one fr.Element
+	one.SetOne()
+	expectedCoeffs.Add(&one, &c)
+	for i := 0; i < 20; i++ {
+		if !f[i].Equal(&expectedCoeffs) {
+			t.Fatal("AddConstantInPlace failed")
+		}
+	}
+}
+
+func TestPolynomialSubConstantInPlace(t *testing.T) {
+
+	// build polynomial
+	f := make(Polynomial, 20)
+	for i := 0; i < 20; i++ {
+		f[i].SetOne()
+	}
+
+	// constant to sub
+	var c fr.Element
+	c.SetRandom()
+
+	// sub constant
+	f.SubConstantInPlace(&c)
+
+	// check
+	var expectedCoeffs, one fr.Element
+	one.SetOne()
+	expectedCoeffs.Sub(&one, &c)
+	for i := 0; i < 20; i++ {
+		if !f[i].Equal(&expectedCoeffs) {
+			t.Fatal("SubConstantInPlace failed")
+		}
+	}
+}
+
+func TestPolynomialScaleInPlace(t *testing.T) {
+
+	// build polynomial
+	f := make(Polynomial, 20)
+	for i := 0; i < 20; i++ {
+		f[i].SetOne()
+	}
+
+	// constant to scale by
+	var c fr.Element
+	c.SetRandom()
+
+	// scale by constant
+	f.ScaleInPlace(&c)
+
+	// check
+	for i := 0; i < 20; i++ {
+		if !f[i].Equal(&c) {
+			t.Fatal("ScaleInPlace failed")
+		}
+	}
+
+}
+
+func TestPolynomialAdd(t *testing.T) {
+
+	// build unbalanced polynomials
+	f1 := make(Polynomial, 20)
+	f1Backup := make(Polynomial, 20)
+	for i := 0; i < 20; i++ {
+		f1[i].SetOne()
+		f1Backup[i].SetOne()
+	}
+	f2 := make(Polynomial, 10)
+	f2Backup := make(Polynomial, 10)
+	for i := 0; i < 10; i++ {
+		f2[i].SetOne()
+		f2Backup[i].SetOne()
+	}
+
+	// expected result
+	var one, two fr.Element
+	one.SetOne()
+	two.Double(&one)
+	expectedSum := make(Polynomial, 20)
+	for i := 0; i < 10; i++ {
+		expectedSum[i].Set(&two)
+	}
+	for i := 10; i < 20; i++ {
+		expectedSum[i].Set(&one)
+	}
+
+	// caller is empty
+	var g Polynomial
+	g.Add(f1, f2)
+	if !g.Equal(expectedSum) {
+		t.Fatal("add polynomials fails")
+	}
+	if !f1.Equal(f1Backup) {
+		t.Fatal("side effect, f1 should not have been modified")
+	}
+	if !f2.Equal(f2Backup) {
+		t.Fatal("side effect, f2 should not have been modified")
+	}
+
+	// all operands are distincts
+	_f1 := f1.Clone()
+	_f1.Add(f1, f2)
+	if !_f1.Equal(expectedSum) {
+		t.Fatal("add polynomials fails")
+	}
+	if !f1.Equal(f1Backup) {
+		t.Fatal("side effect, f1 should not have been modified")
+	}
+	if !f2.Equal(f2Backup) {
+		t.Fatal("side effect, f2 should not have been modified")
+	}
+
+	// first operand = caller
+	_f1 = f1.Clone()
+	_f2 := f2.Clone()
+	_f1.Add(_f1, _f2)
+	if !_f1.Equal(expectedSum) {
+		t.Fatal("add polynomials fails")
+	}
+	if !_f2.Equal(f2Backup) {
+		t.Fatal("side effect, _f2 should not have been modified")
+	}
+
+	// second operand = caller
+	_f1 = f1.Clone()
+	_f2 = f2.Clone()
+	_f1.Add(_f2, _f1)
+	if !_f1.Equal(expectedSum) {
+		t.Fatal("add polynomials fails")
+	}
+	if !_f2.Equal(f2Backup) {
+		t.Fatal("side effect, _f2 should not have been modified")
+	}
+}
+
+func TestPolynomialSub(t *testing.T) {
+
+	f1 := randomPolynomial(20)
+	f2 := randomPolynomial(10)
+
+	// (f1 - f2) + f2 = f1, and (f2 - f1) + f1 = f2
+	var g Polynomial
+	g.Sub(f1, f2)
+	g.Add(g, f2)
+	if !g.Equal(f1) {
+		t.Fatal("sub polynomials fails")
+	}
+	g.Sub(f2, f1)
+	g.Add(g, f1)
+	g = trim(g)
+	if !g.Equal(f2) {
+		t.Fatal("sub polynomials fails")
+	}
+
+	// first operand = caller
+	_f1 := f1.Clone()
+	_f1.Sub(_f1, f2)
+	var expected fr.Element
+	for i := 0; i < 20; i++ {
+		expected.Set(&f1[i])
+		if i < 10 {
+			expected.Sub(&expected, &f2[i])
+		}
+		if !_f1[i].Equal(&expected) {
+			t.Fatal("sub polynomials fails")
+		}
+	}
+}
+
+func TestPolynomialMul(t *testing.T) {
+
+	var point fr.Element
+	point.SetRandom()
+
+	// schoolbook and FFT products
+	for _, sizes := range [][2]int{{10, 20}, {100, 300}, {257, 256}} {
+		f1 := randomPolynomial(sizes[0])
+		f2 := randomPolynomial(sizes[1])
+
+		var g Polynomial
+		g.Mul(f1, f2)
+		if len(g) != sizes[0]+sizes[1]-1 {
+			t.Fatal("wrong size of the product")
+		}
+
+		expected := f1.Eval(&point)
+		e2 := f2.Eval(&point)
+		expected.Mul(&expected, &e2)
+		if e := g.Eval(&point); !e.Equal(&expected) {
+			t.Fatal("mul polynomials fails")
+		}
+
+		// the caller can be an operand
+		f1.Mul(f1, f2)
+		if !f1.Equal(g) {
+			t.Fatal("mul polynomials fails")
+		}
+	}
+}
+
+func TestPolynomialDerivative(t *testing.T) {
+
+	// f = Σ_{i<20} X^i, f' = Σ_{i<19} (i+1)X^i
+	f := make(Polynomial, 20)
+	for i := 0; i < 20; i++ {
+		f[i].SetOne()
+	}
+
+	var g Polynomial
+	g.Derivative(f)
+	if len(g) != 19 {
+		t.Fatal("wrong size of the derivative")
+	}
+	var expected fr.Element
+	for i := 0; i < 19; i++ {
+		expected.SetUint64(uint64(i + 1))
+		if !g[i].Equal(&expected) {
+			t.Fatal("derivative fails")
+		}
+	}
+
+	// in place
+	f.Derivative(f)
+	if !f.Equal(g) {
+		t.Fatal("derivative fails")
+	}
+}
+
+func TestPolynomialCompose(t *testing.T) {
+
+	f1 := randomPolynomial(10)
+	f2 := randomPolynomial(20)
+
+	var point fr.Element
+	point.SetRandom()
+
+	var g Polynomial
+	g.Compose(f1, f2)
+	if len(g) != 9*19+1 {
+		t.Fatal("wrong size of the composition")
+	}
+
+	e2 := f2.Eval(&point)
+	expected := f1.Eval(&e2)
+	if e := g.Eval(&point); !e.Equal(&expected) {
+		t.Fatal("compose polynomials fails")
+	}
+}
+
+func randomPolynomial(size int) Polynomial {
+	f := make(Polynomial, size)
+	for i := 0; i < size; i++ {
+		f[i].SetRandom()
+	}
+	return f
+}
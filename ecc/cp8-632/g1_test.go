@@ -35,7 +35,23 @@ func TestMapToCurveG1(t *testing.T) {
 
 	properties := gopter.NewProperties(parameters)
 
+	properties.Property("[G1] Svsw mapping should output point on the curve before clearing the cofactor", prop.ForAll(
+		func(a fp.Element) bool {
+			g := svdwMapG1(a)
+			return g.IsOnCurve()
+		},
+		GenFp(),
+	))
+
 	properties.Property("[G1] Svsw mapping should output point on the curve", prop.ForAll(
+		func(a fp.Element) bool {
+			g := MapToCurveG1Svdw(a)
+			return g.IsOnCurve()
+		},
+		GenFp(),
+	))
+
+	properties.Property("[G1] Svsw mapping should output point in the subgroup", prop.ForAll(
 		func(a fp.Element) bool {
 			g := MapToCurveG1Svdw(a)
 			return g.IsInSubGroup()
@@ -35,7 +35,23 @@ func TestMapToCurveG2(t *testing.T) {
 
 	properties := gopter.NewProperties(parameters)
 
+	properties.Property("[G2] Svsw mapping should output point on the curve before clearing the cofactor", prop.ForAll(
+		func(a fptower.E2) bool {
+			g := svdwMapG2(a)
+			return g.IsOnCurve()
+		},
+		GenE2(),
+	))
+
 	properties.Property("[G2] Svsw mapping should output point on the curve", prop.ForAll(
+		func(a fptower.E2) bool {
+			g := MapToCurveG2Svdw(a)
+			return g.IsOnCurve()
+		},
+		GenE2(),
+	))
+
+	properties.Property("[G2] Svsw mapping should output point in the subgroup", prop.ForAll(
 		func(a fptower.E2) bool {
 			g := MapToCurveG2Svdw(a)
 			return g.IsInSubGroup()
@@ -168,13 +168,14 @@ func svdwMapG2(u fptower.E2) G2Affine {
 	var z, c1, c2, c3, c4 fptower.E2
 	z.A0.SetUint64(3)
 	z.A1.SetOne()
-	c1.A0.SetUint64(8)
-	c1.A1.SetString("16857842227199225999646786835636637546980511455593181936643515867446449681250963223102065881329922732942164707714798074010252040453159496268796306973476256192757039177873952554508334906059908")
-	c2.A0.SetString("16857842227199225999646786835636637546980511455593181936643515867446449681250963223102065881329922732942164707714798074010252040453159496268796306973476256192757039177873952554508334906059908")
-	c3.A0.SetString("1558368830738803275349191582824076350469659905985053863787578252089423397226386761007877077017425397430888291929495114607835172204836958213805200837320679070769936597535825877280079363727884")
-	c3.A1.SetString("13970901216447696439790762183452979468072948204674220807153539451802418909813609701163289640364991206264590799408075205392342243124614515256445217727464822437358491433858694389471223006170314")
-	c4.A0.SetString("1983275556141085411723151392427839711409471935952139051369825396170170550735407438012007750744696792110842906789976244001206122406254058384564271408644265434442004609161641477000980577183516")
-	c4.A1.SetString("5949826668423256235169454177283519134228415807856417154109476188510511652206222314036023252234090376332528720369928732003618367218762175153692814225932796303326013827484924431002941731550556")
+	c1.A0.SetUint64(44)
+	c1.A1.SetString("8428921113599612999823393417818318773490255727796590968321757933723224840625481611551032940664961366471082353857399037005126020226579748134398153486738128096378519588936976277254167453029982")
+	c2.A0.SetString("8428921113599612999823393417818318773490255727796590968321757933723224840625481611551032940664961366471082353857399037005126020226579748134398153486738128096378519588936976277254167453029953")
+	c2.A1.SetString("8428921113599612999823393417818318773490255727796590968321757933723224840625481611551032940664961366471082353857399037005126020226579748134398153486738128096378519588936976277254167453029954")
+	c3.A0.SetString("7212604402079426320789734719013139801840668768643217176505807313510113623202260472490439320115589836066195859931600864422101289640550268516510670620180966149961569066872673546748964922997604")
+	c3.A1.SetString("117054201446766934359423061802153254648111204726957542776212236858113018968535900601351243066188425542069294142909687019921366663139776978252927710178510097735605962212076702724793879925723")
+	c4.A0.SetString("15718404017735153531733052543453225303770390230691736363802792957861680985291192745284075293163775442500666573224543091538155282086308161165706088651178900921496164779369473958969643291958802")
+	c4.A1.SetString("14345234893509220044760090704155266959388962087861789134994742271951831531185828323298291763835341528378861129095261445994859188669846295785058902467897472774079213581171768984858912372401065")
 
 	var tv1, tv2, tv3, tv4, one, x1, gx1, x2, gx2, x3, x, gx, y fptower.E2
 	one.SetOne()
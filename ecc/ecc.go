@@ -24,8 +24,15 @@ limitations under the License.
 //	* MiMC
 //	* twisted edwards "companion curves"
 //	* EdDSA (on the "companion" twisted edwards curves)
+//	* a curve-agnostic API keyed by ID, for example ecc.BN254.G1()
 package ecc
 
+import (
+	"context"
+
+	"github.com/consensys/gnark-crypto/pool"
+)
+
 // ID represent a unique ID for a curve
 type ID uint16
 
@@ -78,6 +85,13 @@ func (id ID) String() string {
 
 // MultiExpConfig enables to set optional configuration attribute to a call to MultiExp
 type MultiExpConfig struct {
-	NbTasks     int  // go routines to be used in the multiexp. can be larger than num cpus.
-	ScalarsMont bool // indicates if the scalars are in montgommery form. Default to false.
+	NbTasks     int             // go routines to be used in the multiexp. can be larger than num cpus.
+	ScalarsMont bool            // indicates if the scalars are in montgommery form. Default to false.
+	Context     context.Context // if set, the multiexp stops early and returns Context.Err() when the context is done.
+	Progress    Progress        // if set, reports the progress of the multiexp.
+	Pool        *pool.Pool      // workers running the multiexp. Default to pool.Default().
 }
+
+// Progress is called by long running operations with the number of processed tasks out of total.
+// Calls are not concurrent, and done increases up to total.
+type Progress func(done, total int)
@@ -0,0 +1,214 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ecc
+
+import (
+	"io"
+	"math/big"
+	"strings"
+)
+
+// Group is a prime order group of a curve, G1 or G2, obtained with ID.G1 or ID.G2.
+//
+// The generic API is implemented by the curve packages, which register it when they are
+// linked in the binary: ecc.BN254.G1() requires ecc/bn254 to be imported, ecc.BN254.KZG()
+// requires ecc/bn254/fr/kzg and ecc.BN254.NewDomain requires ecc/bn254/fr/fft.
+// Scalars are big.Int, reduced modulo Order() by the implementations.
+type Group interface {
+	// Curve returns the ID of the curve of the group
+	Curve() ID
+
+	// Order returns the order of the group, which is the modulus of the scalar field fr
+	Order() *big.Int
+
+	// NewPoint returns a new point set to the identity (the point at infinity)
+	NewPoint() Point
+
+	// Generator returns a new point set to the generator of the group
+	Generator() Point
+
+	// MultiExp returns Σ scalars[i]*points[i]
+	MultiExp(points []Point, scalars []*big.Int, config MultiExpConfig) (Point, error)
+}
+
+// Point is an affine point of a Group. As for the points of the curve packages, the
+// receiver is set to the result of the operation and returned. The arguments must be
+// points of the same group, or the methods panic.
+type Point interface {
+	// Set sets p to a and returns p
+	Set(a Point) Point
+
+	// Add sets p to a+b and returns p
+	Add(a, b Point) Point
+
+	// Sub sets p to a-b and returns p
+	Sub(a, b Point) Point
+
+	// Neg sets p to -a and returns p
+	Neg(a Point) Point
+
+	// ScalarMultiplication sets p to s*a and returns p
+	ScalarMultiplication(a Point, s *big.Int) Point
+
+	// Equal returns true if p and a are the same point
+	Equal(a Point) bool
+
+	// IsInfinity returns true if p is the identity
+	IsInfinity() bool
+
+	// IsOnCurve returns true if p is on the curve
+	IsOnCurve() bool
+
+	// IsInSubGroup returns true if p is on the curve and in the prime order group
+	IsInSubGroup() bool
+
+	// Bytes returns the compressed encoding of p, as in the curve packages
+	Bytes() []byte
+
+	// RawBytes returns the uncompressed encoding of p, as in the curve packages
+	RawBytes() []byte
+
+	// SetBytes sets p from a compressed or uncompressed encoding, checks it is in the
+	// prime order group, and returns the number of bytes read
+	SetBytes(buf []byte) (int, error)
+
+	String() string
+}
+
+// Pairing is the pairing of a curve, obtained with ID.Pairing
+type Pairing interface {
+	// PairingCheck returns true if ∏ e(P[i], Q[i]) = 1, with P in G1 and Q in G2
+	PairingCheck(P, Q []Point) (bool, error)
+}
+
+// Domain is an FFT domain of the scalar field of a curve, obtained with ID.NewDomain
+type Domain interface {
+	// Cardinality returns the size of the domain, a power of 2
+	Cardinality() uint64
+
+	// FFT evaluates in place the polynomial of coefficients a, of size Cardinality, on the
+	// domain. The input and the output are in natural order.
+	FFT(a []*big.Int)
+
+	// FFTInverse interpolates in place the evaluations a, of size Cardinality, on the domain.
+	// The input and the output are in natural order.
+	FFTInverse(a []*big.Int)
+}
+
+// SRS is the structured reference string of a polynomial commitment scheme
+type SRS interface {
+	io.ReaderFrom
+	io.WriterTo
+}
+
+// OpeningProof is a KZG opening proof of a polynomial at a single point
+type OpeningProof struct {
+	H            Point   // commitment to the quotient polynomial (f - f(z))/(x-z)
+	Point        big.Int // point z at which the polynomial is evaluated
+	ClaimedValue big.Int // purported value f(z)
+}
+
+// KZG is the KZG polynomial commitment scheme of a curve, obtained with ID.KZG.
+// Polynomials are given by their coefficients in canonical basis, and commitments are G1 points.
+type KZG interface {
+	// NewSRS returns a new SRS of size powers using alpha as randomness source.
+	// In production, a SRS generated through MPC should be used.
+	NewSRS(size uint64, alpha *big.Int) (SRS, error)
+
+	// NewEmptySRS returns an empty SRS, to be set with ReadFrom
+	NewEmptySRS() SRS
+
+	// Commit commits to the polynomial p
+	Commit(p []*big.Int, srs SRS) (Point, error)
+
+	// Open computes an opening proof of the polynomial p at point
+	Open(p []*big.Int, point *big.Int, srs SRS) (OpeningProof, error)
+
+	// Verify verifies an opening proof of the polynomial of the given commitment
+	Verify(commitment Point, proof *OpeningProof, srs SRS) error
+}
+
+// registered implementations of the generic API, indexed by ID
+var (
+	groups1    [CP8_632 + 1]Group
+	groups2    [CP8_632 + 1]Group
+	pairings   [CP8_632 + 1]Pairing
+	kzgs       [CP8_632 + 1]KZG
+	newDomains [CP8_632 + 1]func(m uint64) Domain
+)
+
+// RegisterCurve registers the groups and the pairing of a curve.
+// It is called by the curve packages, since we cannot import them here due to import cycles.
+func RegisterCurve(id ID, g1, g2 Group, pairing Pairing) {
+	groups1[id] = g1
+	groups2[id] = g2
+	pairings[id] = pairing
+}
+
+// RegisterKZG registers the KZG scheme of a curve. It is called by the kzg packages.
+func RegisterKZG(id ID, kzg KZG) {
+	kzgs[id] = kzg
+}
+
+// RegisterDomain registers the FFT domain constructor of a curve. It is called by the fft packages.
+func RegisterDomain(id ID, newDomain func(m uint64) Domain) {
+	newDomains[id] = newDomain
+}
+
+// G1 returns the group G1 of the curve
+func (id ID) G1() Group {
+	if groups1[id] == nil {
+		panic(id.notRegistered(""))
+	}
+	return groups1[id]
+}
+
+// G2 returns the group G2 of the curve
+func (id ID) G2() Group {
+	if groups2[id] == nil {
+		panic(id.notRegistered(""))
+	}
+	return groups2[id]
+}
+
+// Pairing returns the pairing of the curve
+func (id ID) Pairing() Pairing {
+	if pairings[id] == nil {
+		panic(id.notRegistered(""))
+	}
+	return pairings[id]
+}
+
+// KZG returns the KZG polynomial commitment scheme of the curve
+func (id ID) KZG() KZG {
+	if kzgs[id] == nil {
+		panic(id.notRegistered("/fr/kzg"))
+	}
+	return kzgs[id]
+}
+
+// NewDomain returns an FFT domain of the scalar field of cardinality the smallest power of 2 ≥ m
+func (id ID) NewDomain(m uint64) Domain {
+	if newDomains[id] == nil {
+		panic(id.notRegistered("/fr/fft"))
+	}
+	return newDomains[id](m)
+}
+
+func (id ID) notRegistered(pkg string) string {
+	return "ecc: " + id.String() + " is not registered, import github.com/consensys/gnark-crypto/ecc/" + strings.ReplaceAll(id.String(), "_", "-") + pkg
+}
@@ -224,7 +224,7 @@ func ExpandMsgXmd(msg, dst []byte, lenInBytes int) ([]byte, error) {
 	b1 := h.Sum(nil)
 
 	res := make([]byte, lenInBytes)
-	copy(res[:h.Size()], b1)
+	copy(res, b1)
 
 	for i := 2; i <= ell; i++ {
 		// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
@@ -246,7 +246,7 @@ func ExpandMsgXmd(msg, dst []byte, lenInBytes int) ([]byte, error) {
 			return nil, err
 		}
 		b1 = h.Sum(nil)
-		copy(res[h.Size()*(i-1):h.Size()*i], b1)
+		copy(res[h.Size()*(i-1):], b1)
 	}
 	return res, nil
 }
@@ -1,6 +1,7 @@
 package ecc
 
 import (
+	"encoding/hex"
 	"math/big"
 	"testing"
 )
@@ -57,3 +58,27 @@ func BenchmarkSplitting256(b *testing.B) {
 	}
 
 }
+
+func TestExpandMsgXmd(t *testing.T) {
+	// https://www.rfc-editor.org/rfc/rfc9380.html#appendix-K.1, and lengths which are not a multiple of
+	// the size of the hash
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	vectors := []struct {
+		msg        string
+		lenInBytes int
+		expected   string
+	}{
+		{"", 32, "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{"abc", 48, "2b877f5f0dfd881405426c6b87b39205ef53a548b0e4d567fc007cb37c6fa1f3b19f42871efefca518ac950c27ac4e28"},
+		{"abc", 20, "c9c9c73ca9bc779cf93a9aa5c9c8560c6e66722d"},
+	}
+	for _, v := range vectors {
+		res, err := ExpandMsgXmd([]byte(v.msg), dst, v.lenInBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(res) != v.expected {
+			t.Fatalf("ExpandMsgXmd(%q, %d): wrong output", v.msg, v.lenInBytes)
+		}
+	}
+}
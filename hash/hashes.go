@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package hash provides MiMC hash function defined over curves implemented in gnark-crypto/ecc.
+// Package hash provides MiMC, Poseidon and Poseidon2 hash functions defined over curves implemented in gnark-crypto/ecc.
 //
 // Originally developed and used in a ZKP context.
 package hash
@@ -21,11 +21,35 @@ import (
 	"hash"
 
 	bls377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/mimc"
+	bls377poseidon "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/poseidon"
+	bls377poseidon2 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/poseidon2"
+	bls379 "github.com/consensys/gnark-crypto/ecc/bls12-379/fr/mimc"
+	bls379poseidon "github.com/consensys/gnark-crypto/ecc/bls12-379/fr/poseidon"
+	bls379poseidon2 "github.com/consensys/gnark-crypto/ecc/bls12-379/fr/poseidon2"
 	bls381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/mimc"
+	bls381poseidon "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/poseidon"
+	bls381poseidon2 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/poseidon2"
 	bls315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/mimc"
+	bls315poseidon "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/poseidon"
+	bls315poseidon2 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/poseidon2"
 	bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	bn254poseidon "github.com/consensys/gnark-crypto/ecc/bn254/fr/poseidon"
+	bn254poseidon2 "github.com/consensys/gnark-crypto/ecc/bn254/fr/poseidon2"
 	bw633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/mimc"
+	bw633poseidon "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/poseidon"
+	bw633poseidon2 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/poseidon2"
+	bw672 "github.com/consensys/gnark-crypto/ecc/bw6-672/fr/mimc"
+	bw672poseidon "github.com/consensys/gnark-crypto/ecc/bw6-672/fr/poseidon"
+	bw672poseidon2 "github.com/consensys/gnark-crypto/ecc/bw6-672/fr/poseidon2"
 	bw761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/mimc"
+	bw761poseidon "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/poseidon"
+	bw761poseidon2 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/poseidon2"
+	bw764 "github.com/consensys/gnark-crypto/ecc/bw6-764/fr/mimc"
+	bw764poseidon "github.com/consensys/gnark-crypto/ecc/bw6-764/fr/poseidon"
+	bw764poseidon2 "github.com/consensys/gnark-crypto/ecc/bw6-764/fr/poseidon2"
+	cp632 "github.com/consensys/gnark-crypto/ecc/cp8-632/fr/mimc"
+	cp632poseidon "github.com/consensys/gnark-crypto/ecc/cp8-632/fr/poseidon"
+	cp632poseidon2 "github.com/consensys/gnark-crypto/ecc/cp8-632/fr/poseidon2"
 )
 
 type Hash uint
@@ -37,19 +61,69 @@ const (
 	MIMC_BW6_761
 	MIMC_BLS24_315
 	MIMC_BW6_633
+	POSEIDON_BN254
+	POSEIDON_BLS12_381
+	POSEIDON_BLS12_377
+	POSEIDON_BW6_761
+	POSEIDON_BLS24_315
+	POSEIDON_BW6_633
+	POSEIDON2_BN254
+	POSEIDON2_BLS12_381
+	POSEIDON2_BLS12_377
+	POSEIDON2_BW6_761
+	POSEIDON2_BLS24_315
+	POSEIDON2_BW6_633
+	MIMC_BLS12_379
+	MIMC_BW6_764
+	MIMC_BW6_672
+	MIMC_CP8_632
+	POSEIDON_BLS12_379
+	POSEIDON_BW6_764
+	POSEIDON_BW6_672
+	POSEIDON_CP8_632
+	POSEIDON2_BLS12_379
+	POSEIDON2_BW6_764
+	POSEIDON2_BW6_672
+	POSEIDON2_CP8_632
 )
 
 // size of digests in bytes
 var digestSize = []uint8{
-	MIMC_BN254:     32,
-	MIMC_BLS12_381: 48,
-	MIMC_BLS12_377: 48,
-	MIMC_BW6_761:   96,
-	MIMC_BLS24_315: 48,
-	MIMC_BW6_633:   80,
+	MIMC_BN254:          32,
+	MIMC_BLS12_381:      48,
+	MIMC_BLS12_377:      48,
+	MIMC_BW6_761:        96,
+	MIMC_BLS24_315:      48,
+	MIMC_BW6_633:        80,
+	POSEIDON_BN254:      32,
+	POSEIDON_BLS12_381:  32,
+	POSEIDON_BLS12_377:  32,
+	POSEIDON_BW6_761:    48,
+	POSEIDON_BLS24_315:  32,
+	POSEIDON_BW6_633:    40,
+	POSEIDON2_BN254:     32,
+	POSEIDON2_BLS12_381: 32,
+	POSEIDON2_BLS12_377: 32,
+	POSEIDON2_BW6_761:   48,
+	POSEIDON2_BLS24_315: 32,
+	POSEIDON2_BW6_633:   40,
+	MIMC_BLS12_379:      32,
+	MIMC_BW6_764:        48,
+	MIMC_BW6_672:        40,
+	MIMC_CP8_632:        40,
+	POSEIDON_BLS12_379:  32,
+	POSEIDON_BW6_764:    48,
+	POSEIDON_BW6_672:    40,
+	POSEIDON_CP8_632:    40,
+	POSEIDON2_BLS12_379: 32,
+	POSEIDON2_BW6_764:   48,
+	POSEIDON2_BW6_672:   40,
+	POSEIDON2_CP8_632:   40,
 }
 
-// New creates the corresponding mimc hash function.
+// New creates the corresponding hash function.
+// The seed is used by MiMC to derive its constants, and is ignored by Poseidon and Poseidon2, which use
+// their default parameters of width 3.
 func (m Hash) New(seed string) hash.Hash {
 	switch m {
 	case MIMC_BN254:
@@ -64,12 +138,60 @@ func (m Hash) New(seed string) hash.Hash {
 		return bls315.NewMiMC(seed)
 	case MIMC_BW6_633:
 		return bw633.NewMiMC(seed)
+	case POSEIDON_BN254:
+		return bn254poseidon.NewPoseidon()
+	case POSEIDON_BLS12_381:
+		return bls381poseidon.NewPoseidon()
+	case POSEIDON_BLS12_377:
+		return bls377poseidon.NewPoseidon()
+	case POSEIDON_BW6_761:
+		return bw761poseidon.NewPoseidon()
+	case POSEIDON_BLS24_315:
+		return bls315poseidon.NewPoseidon()
+	case POSEIDON_BW6_633:
+		return bw633poseidon.NewPoseidon()
+	case POSEIDON2_BN254:
+		return bn254poseidon2.NewPoseidon2()
+	case POSEIDON2_BLS12_381:
+		return bls381poseidon2.NewPoseidon2()
+	case POSEIDON2_BLS12_377:
+		return bls377poseidon2.NewPoseidon2()
+	case POSEIDON2_BW6_761:
+		return bw761poseidon2.NewPoseidon2()
+	case POSEIDON2_BLS24_315:
+		return bls315poseidon2.NewPoseidon2()
+	case POSEIDON2_BW6_633:
+		return bw633poseidon2.NewPoseidon2()
+	case MIMC_BLS12_379:
+		return bls379.NewMiMC(seed)
+	case MIMC_BW6_764:
+		return bw764.NewMiMC(seed)
+	case MIMC_BW6_672:
+		return bw672.NewMiMC(seed)
+	case MIMC_CP8_632:
+		return cp632.NewMiMC(seed)
+	case POSEIDON_BLS12_379:
+		return bls379poseidon.NewPoseidon()
+	case POSEIDON_BW6_764:
+		return bw764poseidon.NewPoseidon()
+	case POSEIDON_BW6_672:
+		return bw672poseidon.NewPoseidon()
+	case POSEIDON_CP8_632:
+		return cp632poseidon.NewPoseidon()
+	case POSEIDON2_BLS12_379:
+		return bls379poseidon2.NewPoseidon2()
+	case POSEIDON2_BW6_764:
+		return bw764poseidon2.NewPoseidon2()
+	case POSEIDON2_BW6_672:
+		return bw672poseidon2.NewPoseidon2()
+	case POSEIDON2_CP8_632:
+		return cp632poseidon2.NewPoseidon2()
 	default:
-		panic("Unknown mimc ID")
+		panic("Unknown hash ID")
 	}
 }
 
-// String returns the mimc ID to string format.
+// String returns the hash ID to string format.
 func (m Hash) String() string {
 	switch m {
 	case MIMC_BN254:
@@ -84,8 +206,56 @@ func (m Hash) String() string {
 		return "MIMC_BLS315"
 	case MIMC_BW6_633:
 		return "MIMC_BW633"
+	case POSEIDON_BN254:
+		return "POSEIDON_BN254"
+	case POSEIDON_BLS12_381:
+		return "POSEIDON_BLS381"
+	case POSEIDON_BLS12_377:
+		return "POSEIDON_BLS377"
+	case POSEIDON_BW6_761:
+		return "POSEIDON_BW761"
+	case POSEIDON_BLS24_315:
+		return "POSEIDON_BLS315"
+	case POSEIDON_BW6_633:
+		return "POSEIDON_BW633"
+	case POSEIDON2_BN254:
+		return "POSEIDON2_BN254"
+	case POSEIDON2_BLS12_381:
+		return "POSEIDON2_BLS381"
+	case POSEIDON2_BLS12_377:
+		return "POSEIDON2_BLS377"
+	case POSEIDON2_BW6_761:
+		return "POSEIDON2_BW761"
+	case POSEIDON2_BLS24_315:
+		return "POSEIDON2_BLS315"
+	case POSEIDON2_BW6_633:
+		return "POSEIDON2_BW633"
+	case MIMC_BLS12_379:
+		return "MIMC_BLS379"
+	case MIMC_BW6_764:
+		return "MIMC_BW764"
+	case MIMC_BW6_672:
+		return "MIMC_BW672"
+	case MIMC_CP8_632:
+		return "MIMC_CP632"
+	case POSEIDON_BLS12_379:
+		return "POSEIDON_BLS379"
+	case POSEIDON_BW6_764:
+		return "POSEIDON_BW764"
+	case POSEIDON_BW6_672:
+		return "POSEIDON_BW672"
+	case POSEIDON_CP8_632:
+		return "POSEIDON_CP632"
+	case POSEIDON2_BLS12_379:
+		return "POSEIDON2_BLS379"
+	case POSEIDON2_BW6_764:
+		return "POSEIDON2_BW764"
+	case POSEIDON2_BW6_672:
+		return "POSEIDON2_BW672"
+	case POSEIDON2_CP8_632:
+		return "POSEIDON2_CP632"
 	default:
-		panic("Unknown mimc ID")
+		panic("Unknown hash ID")
 	}
 }
 
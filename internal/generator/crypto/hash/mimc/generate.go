@@ -1,19 +1,43 @@
 package mimc
 
 import (
+	"math"
+	"math/big"
 	"path/filepath"
 
 	"github.com/consensys/bavard"
 	"github.com/consensys/gnark-crypto/internal/generator/config"
 )
 
+// mimcData is the data of the templates of the mimc package of a curve
+type mimcData struct {
+	config.Curve
+	SpongeExponent int // exponent of the Feistel sponge, the smallest e >= 5 coprime with r-1
+	SpongeNbRounds int // number of rounds of the Feistel sponge, 2·ceil(log_e(r))
+}
+
 func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
 	conf.Package = "mimc"
+	data := mimcData{Curve: conf}
+
+	var r, rMinus1, gcd big.Int
+	r.SetString(conf.FrModulus, 10)
+	rMinus1.Sub(&r, big.NewInt(1))
+	for e := int64(5); ; e++ {
+		if gcd.GCD(nil, nil, big.NewInt(e), &rMinus1).IsInt64() && gcd.Int64() == 1 {
+			data.SpongeExponent = int(e)
+			break
+		}
+	}
+	data.SpongeNbRounds = 2 * int(math.Ceil(float64(r.BitLen())/math.Log2(float64(data.SpongeExponent))))
+
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "mimc.go"), Templates: []string{"mimc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "feistel.go"), Templates: []string{"feistel.go.tmpl"}},
+		{File: filepath.Join(baseDir, "mimc_test.go"), Templates: []string{"tests/mimc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "fuzz.go"), Templates: []string{"fuzz.go.tmpl"}, BuildTag: "gofuzz"},
 	}
-	return bgen.Generate(conf, conf.Package, "./crypto/hash/mimc/template", entries...)
+	return bgen.Generate(data, conf.Package, "./crypto/hash/mimc/template", entries...)
 
 }
@@ -0,0 +1,117 @@
+package poseidon
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+// maxWidth is the largest width of the permutations with default parameters, as in circomlib
+const maxWidth = 17
+
+// securityLevel is the security level in bits of the default parameters
+const securityLevel = 128
+
+// permutation is the data of the templates of the Poseidon and Poseidon2 packages of a curve
+type permutation struct {
+	config.Curve
+	MaxWidth        int   // largest width of the permutations with default parameters
+	Alpha           int   // exponent of the s-box, the smallest integer >= 3 coprime with r-1
+	NbFullRounds    []int // NbFullRounds[t-2] is the number of full rounds for width t
+	NbPartialRounds []int // NbPartialRounds[t-2] is the number of partial rounds for width t, before rounding
+}
+
+// Generate generates the Poseidon and Poseidon2 permutations and hash functions over the scalar field of conf
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	var r big.Int
+	r.SetString(conf.FrModulus, 10)
+	data := permutation{Curve: conf, MaxWidth: maxWidth, Alpha: sboxExponent(&r)}
+	if data.Alpha != 3 && data.Alpha != 5 && data.Alpha != 7 && data.Alpha != 11 {
+		return fmt.Errorf("%s: no addition chain for the s-box x^%d", conf.Name, data.Alpha)
+	}
+	for t := 2; t <= maxWidth; t++ {
+		rF, rP := roundNumbers(&r, t, data.Alpha)
+		data.NbFullRounds = append(data.NbFullRounds, rF)
+		data.NbPartialRounds = append(data.NbPartialRounds, rP)
+	}
+
+	conf.Package = "poseidon"
+	data.Curve = conf
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "poseidon", "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "poseidon", "poseidon.go"), Templates: []string{"poseidon.go.tmpl", "sbox.go.tmpl"}},
+		{File: filepath.Join(baseDir, "poseidon", "grain.go"), Templates: []string{"grain.go.tmpl"}},
+		{File: filepath.Join(baseDir, "poseidon", "hash.go"), Templates: []string{"hash.go.tmpl"}},
+		{File: filepath.Join(baseDir, "poseidon", "poseidon_test.go"), Templates: []string{"tests/poseidon.go.tmpl", "tests/hash.go.tmpl"}},
+	}
+	if err := bgen.Generate(data, conf.Package, "./crypto/hash/poseidon/template", entries...); err != nil {
+		return err
+	}
+
+	conf.Package = "poseidon2"
+	data.Curve = conf
+	entries = []bavard.Entry{
+		{File: filepath.Join(baseDir, "poseidon2", "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "poseidon2", "poseidon2.go"), Templates: []string{"poseidon2.go.tmpl", "sbox.go.tmpl"}},
+		{File: filepath.Join(baseDir, "poseidon2", "grain.go"), Templates: []string{"grain.go.tmpl"}},
+		{File: filepath.Join(baseDir, "poseidon2", "hash.go"), Templates: []string{"hash.go.tmpl"}},
+		{File: filepath.Join(baseDir, "poseidon2", "poseidon2_test.go"), Templates: []string{"tests/poseidon2.go.tmpl", "tests/hash.go.tmpl"}},
+	}
+	return bgen.Generate(data, conf.Package, "./crypto/hash/poseidon/template", entries...)
+}
+
+// sboxExponent returns the smallest integer alpha >= 3 such that x -> x^alpha is a permutation of Fr
+func sboxExponent(r *big.Int) int {
+	var rMinus1, gcd big.Int
+	rMinus1.Sub(r, big.NewInt(1))
+	for alpha := int64(3); ; alpha++ {
+		if gcd.GCD(nil, nil, big.NewInt(alpha), &rMinus1).IsInt64() && gcd.Int64() == 1 {
+			return int(alpha)
+		}
+	}
+}
+
+// roundNumbers returns the numbers of full and partial rounds for width t and the s-box x^alpha,
+// minimizing the number of s-boxes with the security margin of the paper (2 more full rounds, and
+// 7.5% more partial rounds), as in calc_round_numbers.py of the reference implementation
+// https://extgit.iaik.tugraz.at/krypto/hadeshash
+func roundNumbers(r *big.Int, t, alpha int) (nbFullRounds, nbPartialRounds int) {
+	fr, _ := new(big.Float).SetInt(r).Float64()
+	logP := math.Log2(fr)
+	n := float64(r.BitLen())
+	M := float64(securityLevel)
+	logAlpha := func(x float64) float64 { return math.Log(x) / math.Log(float64(alpha)) }
+
+	satisfied := func(rF, rP int) bool {
+		var rF1 float64 = 10 // statistical
+		if M <= math.Floor(logP-float64(alpha-1)/2)*float64(t+1) {
+			rF1 = 6
+		}
+		rF2 := 1 + math.Ceil(logAlpha(2)*math.Min(M, n)) + math.Ceil(logAlpha(float64(t))) - float64(rP) // interpolation
+		rF3 := logAlpha(2)*math.Min(M, logP) - float64(rP)                                               // Gröbner 1
+		rF4 := float64(t) - 1 + logAlpha(2)*math.Min(M/float64(t+1), logP/2) - float64(rP)               // Gröbner 2
+		rF5 := (float64(t) - 2 + M/(2*math.Log2(float64(alpha))) - float64(rP)) / float64(t-1)           // Gröbner 3
+		rFMax := math.Max(math.Max(math.Ceil(rF1), math.Ceil(rF2)), math.Max(math.Max(math.Ceil(rF3), math.Ceil(rF4)), math.Ceil(rF5)))
+		return float64(rF) >= rFMax
+	}
+
+	minCost := math.MaxInt
+	for rP := 1; rP < 500; rP++ {
+		for rF := 4; rF < 100; rF += 2 {
+			if !satisfied(rF, rP) {
+				continue
+			}
+			// security margin
+			rFMargin, rPMargin := rF+2, int(math.Ceil(float64(rP)*1.075))
+			cost := t*rFMargin + rPMargin
+			if cost < minCost || (cost == minCost && rFMargin < nbFullRounds) {
+				nbFullRounds, nbPartialRounds, minCost = rFMargin, rPMargin, cost
+			}
+		}
+	}
+	return
+}
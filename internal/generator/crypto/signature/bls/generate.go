@@ -0,0 +1,69 @@
+package bls
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+// variant describes a BLS signature instantiation: the groups in which
+// the public keys and the signatures live, and the hash to curve used
+type variant struct {
+	config.Curve
+	SchemeID       string // name of the signature.SignatureScheme constant
+	PublicKeyGroup string
+	SignatureGroup string
+	HashToCurve    string // suffix of the HashToCurve function of the curve package
+	SuiteID        string // ciphersuite ID, used to derive the domain separation tags
+}
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	var g1Suite, g2Suite string
+	var g2HashToCurve string
+	switch conf.Name {
+	case "bls12-381":
+		g1Suite, g2Suite = "BLS12381G1_XMD:SHA-256_SSWU_RO_POP_", "BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+		g2HashToCurve = "SSWU"
+	case "bls12-377":
+		g1Suite, g2Suite = "BLS12377G1_XMD:SHA-256_SSWU_RO_POP_", "BLS12377G2_XMD:SHA-256_SVDW_RO_POP_"
+		g2HashToCurve = "Svdw"
+	default:
+		return nil
+	}
+
+	variants := []variant{
+		{
+			Curve:          conf,
+			SchemeID:       "BLS_MINPK_" + conf.EnumID,
+			PublicKeyGroup: "G1",
+			SignatureGroup: "G2",
+			HashToCurve:    g2HashToCurve,
+			SuiteID:        g2Suite,
+		},
+		{
+			Curve:          conf,
+			SchemeID:       "BLS_MINSIG_" + conf.EnumID,
+			PublicKeyGroup: "G2",
+			SignatureGroup: "G1",
+			HashToCurve:    "SSWU",
+			SuiteID:        g1Suite,
+		},
+	}
+	variants[0].Package = "minpk"
+	variants[1].Package = "minsig"
+
+	for _, v := range variants {
+		dir := filepath.Join(baseDir, v.Package)
+		entries := []bavard.Entry{
+			{File: filepath.Join(dir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+			{File: filepath.Join(dir, "bls.go"), Templates: []string{"bls.go.tmpl"}},
+			{File: filepath.Join(dir, "bls_test.go"), Templates: []string{"bls.test.go.tmpl"}},
+			{File: filepath.Join(dir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		}
+		if err := bgen.Generate(v, v.Package, "./crypto/signature/bls/template", entries...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
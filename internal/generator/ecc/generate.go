@@ -18,8 +18,14 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "multiexp.go"), Templates: []string{"multiexp.go.tmpl"}},
 		{File: filepath.Join(baseDir, "multiexp_test.go"), Templates: []string{"tests/multiexp.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_precomputed.go"), Templates: []string{"multiexp_precomputed.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_precomputed_test.go"), Templates: []string{"tests/multiexp_precomputed.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_stream.go"), Templates: []string{"multiexp_stream.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_stream_test.go"), Templates: []string{"tests/multiexp_stream.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal_test.go"), Templates: []string{"tests/marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "generic.go"), Templates: []string{"generic.go.tmpl"}},
+		{File: filepath.Join(baseDir, "generic_test.go"), Templates: []string{"tests/generic.go.tmpl"}},
 	}
 	conf.Package = packageName
 	if err := bgen.Generate(conf, packageName, "./ecc/template", entries...); err != nil {
@@ -7,15 +7,60 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/config"
 )
 
+// twistedEdwards is the data of the templates of a twisted Edwards companion curve
+type twistedEdwards struct {
+	config.Curve
+	AIsMinusOne     bool // the curve is -x^2 + y^2 = 1 + d*x^2*y^2, with faster formulas
+	HasEndomorphism bool // scalar multiplication using GLV
+}
+
+// Generate generates the twisted Edwards companion curve (a=-1) of conf in baseDir
 func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
 	conf.Package = "twistededwards"
+	data := twistedEdwards{Curve: conf, AIsMinusOne: true}
 
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "point.go"), Templates: []string{"pointtwistededwards.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp.go"), Templates: []string{"multiexp.go.tmpl"}},
+		{File: filepath.Join(baseDir, "hash_to_curve.go"), Templates: []string{"hash_to_curve.go.tmpl"}},
 		{File: filepath.Join(baseDir, "twistededwards_test.go"), Templates: []string{"tests/pointtwistededwards.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_test.go"), Templates: []string{"tests/multiexp.go.tmpl"}},
+		{File: filepath.Join(baseDir, "hash_to_curve_test.go"), Templates: []string{"tests/hash_to_curve.go.tmpl"}},
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+	}
+
+	return bgen.Generate(data, conf.Package, "./edwards/template", entries...)
+
+}
+
+// GenerateBandersnatch generates Bandersnatch, the companion curve of bls12-381 with an efficient
+// endomorphism, and the banderwagon quotient group in baseDir. It is a no-op for other curves.
+func GenerateBandersnatch(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	if conf.Name != "bls12-381" {
+		return nil
+	}
+	conf.Package = "bandersnatch"
+	data := twistedEdwards{Curve: conf, HasEndomorphism: true}
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "point.go"), Templates: []string{"pointtwistededwards.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp.go"), Templates: []string{"multiexp.go.tmpl"}},
+		{File: filepath.Join(baseDir, "hash_to_curve.go"), Templates: []string{"hash_to_curve.go.tmpl"}},
+		{File: filepath.Join(baseDir, "bandersnatch_test.go"), Templates: []string{"tests/pointtwistededwards.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_test.go"), Templates: []string{"tests/multiexp.go.tmpl"}},
+		{File: filepath.Join(baseDir, "hash_to_curve_test.go"), Templates: []string{"tests/hash_to_curve.go.tmpl"}},
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 	}
+	if err := bgen.Generate(data, conf.Package, "./edwards/template", entries...); err != nil {
+		return err
+	}
 
-	return bgen.Generate(conf, conf.Package, "./edwards/template", entries...)
+	entries = []bavard.Entry{
+		{File: filepath.Join(baseDir, "banderwagon", "banderwagon.go"), Templates: []string{"banderwagon.go.tmpl"}},
+		{File: filepath.Join(baseDir, "banderwagon", "banderwagon_test.go"), Templates: []string{"tests/banderwagon.go.tmpl"}},
+		{File: filepath.Join(baseDir, "banderwagon", "doc.go"), Templates: []string{"doc.go.tmpl"}},
+	}
+	data.Package = "banderwagon"
+	return bgen.Generate(data, data.Package, "./edwards/template/banderwagon", entries...)
 
 }
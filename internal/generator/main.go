@@ -12,6 +12,8 @@ import (
 	"github.com/consensys/gnark-crypto/field/generator"
 	"github.com/consensys/gnark-crypto/internal/generator/config"
 	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/mimc"
+	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/poseidon"
+	"github.com/consensys/gnark-crypto/internal/generator/crypto/signature/bls"
 	"github.com/consensys/gnark-crypto/internal/generator/crypto/signature/eddsa"
 	"github.com/consensys/gnark-crypto/internal/generator/ecc"
 	"github.com/consensys/gnark-crypto/internal/generator/edwards"
@@ -63,12 +65,21 @@ func main() {
 			// generate mimc on fr
 			assertNoError(mimc.Generate(conf, filepath.Join(curveDir, "fr", "mimc"), bgen))
 
+			// generate poseidon and poseidon2 on fr
+			assertNoError(poseidon.Generate(conf, filepath.Join(curveDir, "fr"), bgen))
+
 			// generate twisted edwards companion curves
 			assertNoError(edwards.Generate(conf, filepath.Join(curveDir, "twistededwards"), bgen))
 
 			// generate eddsa on companion curves
 			assertNoError(eddsa.Generate(conf, filepath.Join(curveDir, "twistededwards", "eddsa"), bgen))
 
+			// generate bandersnatch and banderwagon (no-op for curves other than bls12-381)
+			assertNoError(edwards.GenerateBandersnatch(conf, filepath.Join(curveDir, "bandersnatch"), bgen))
+
+			// generate bls signatures (no-op for curves other than bls12-381 and bls12-377)
+			assertNoError(bls.Generate(conf, filepath.Join(curveDir, "bls"), bgen))
+
 			// generate G1, G2, multiExp, ...
 			assertNoError(ecc.Generate(conf, curveDir, bgen))
 
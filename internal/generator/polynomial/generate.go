@@ -14,6 +14,10 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "polynomial.go"), Templates: []string{"polynomial.go.tmpl"}},
 		{File: filepath.Join(baseDir, "polynomial_test.go"), Templates: []string{"polynomial.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "division.go"), Templates: []string{"division.go.tmpl"}},
+		{File: filepath.Join(baseDir, "division_test.go"), Templates: []string{"division.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multipoint.go"), Templates: []string{"multipoint.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multipoint_test.go"), Templates: []string{"multipoint.test.go.tmpl"}},
 	}
 	return bgen.Generate(conf, conf.Package, "./polynomial/template/", entries...)
 }
@@ -0,0 +1,229 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pool provides a pool of workers on which the parallel routines of gnark-crypto
+// (multi exponentiations, FFTs, KZG commitments, ...) are scheduled.
+//
+// By default, all these routines share a process-wide pool with runtime.NumCPU() workers;
+// SetDefault replaces it, to bound the CPU used by gnark-crypto in a process. The pool of a
+// single multi exponentiation can also be set in ecc.MultiExpConfig.
+//
+// The tasks of concurrent callers are served in round robin, so that a large computation doesn't
+// starve the others.
+package pool
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Pool executes parallel work on a fixed number of workers.
+//
+// The go routine calling Execute also processes the tasks of its own call while it waits for
+// them, which makes nested calls from the workers safe. Hence, at most NbWorkers() go routines,
+// plus the callers of Execute, are running tasks of the pool at any time.
+type Pool struct {
+	lock      sync.Mutex
+	cond      *sync.Cond // signaled when a job is submitted or the pool is stopped
+	jobs      []*job     // jobs with tasks not started yet, served in round robin
+	nbWorkers int
+	stopped   bool
+}
+
+// job is a call to Execute, split in nbTasks tasks
+type job struct {
+	work                func(int, int)
+	nbTasks             int
+	nbIterationsPerTask int
+	nbExtraIterations   int // the first nbExtraIterations tasks process one more iteration
+	next                int // next task to start
+	wg                  sync.WaitGroup
+}
+
+// New returns a pool with nbWorkers workers, or runtime.NumCPU() workers if nbWorkers <= 0.
+func New(nbWorkers int) *Pool {
+	if nbWorkers <= 0 {
+		nbWorkers = runtime.NumCPU()
+	}
+	p := &Pool{nbWorkers: nbWorkers}
+	p.cond = sync.NewCond(&p.lock)
+	for i := 0; i < nbWorkers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+var (
+	defaultLock sync.Mutex
+	defaultPool *Pool
+)
+
+// Default returns the pool used when none is given, which is created on first use
+// with runtime.NumCPU() workers.
+func Default() *Pool {
+	defaultLock.Lock()
+	defer defaultLock.Unlock()
+	if defaultPool == nil {
+		defaultPool = New(runtime.NumCPU())
+	}
+	return defaultPool
+}
+
+// SetDefault sets the pool used when none is given. The previous default pool is not stopped.
+func SetDefault(p *Pool) {
+	defaultLock.Lock()
+	defaultPool = p
+	defaultLock.Unlock()
+}
+
+// NbWorkers returns the number of workers of the pool
+func (p *Pool) NbWorkers() int {
+	if p == nil {
+		p = Default()
+	}
+	return p.nbWorkers
+}
+
+// Stop stops the workers once the submitted tasks are started. Calls to Execute on a stopped
+// pool are processed by their caller alone.
+func (p *Pool) Stop() {
+	p.lock.Lock()
+	p.stopped = true
+	p.cond.Broadcast()
+	p.lock.Unlock()
+}
+
+// Execute splits [0, nbIterations) in maxTasks[0] ranges, or NbWorkers() ranges if maxTasks
+// is not set, calls work on each range in parallel, and returns when all calls returned.
+// If p is nil, the default pool is used.
+func (p *Pool) Execute(nbIterations int, work func(int, int), maxTasks ...int) {
+	if p == nil {
+		p = Default()
+	}
+
+	nbTasks := p.nbWorkers
+	if len(maxTasks) == 1 {
+		nbTasks = maxTasks[0]
+	}
+	if nbTasks < 1 {
+		nbTasks = 1
+	}
+	nbIterationsPerTask := nbIterations / nbTasks
+
+	// more tasks than iterations: a task will work on exactly one iteration
+	if nbIterationsPerTask < 1 {
+		nbIterationsPerTask = 1
+		nbTasks = nbIterations
+	}
+	if nbTasks <= 1 {
+		if nbIterations > 0 {
+			work(0, nbIterations)
+		}
+		return
+	}
+
+	j := &job{
+		work:                work,
+		nbTasks:             nbTasks,
+		nbIterationsPerTask: nbIterationsPerTask,
+		nbExtraIterations:   nbIterations - nbTasks*nbIterationsPerTask,
+	}
+	j.wg.Add(nbTasks)
+
+	p.lock.Lock()
+	p.jobs = append(p.jobs, j)
+	p.cond.Broadcast()
+
+	// the caller processes the tasks of its job which are not started yet, then waits
+	// for the ones started by the workers
+	for j.next < j.nbTasks {
+		p.run(j)
+	}
+	p.lock.Unlock()
+
+	j.wg.Wait()
+}
+
+// ExecuteCtx is Execute, and stops early when ctx is done. The ranges are split in blocks, and
+// ctx is checked before each block; work is called on disjoint ranges covering [0, nbIterations)
+// unless ctx is done, in which case ExecuteCtx returns ctx.Err().
+func (p *Pool) ExecuteCtx(ctx context.Context, nbIterations int, work func(int, int), maxTasks ...int) error {
+	const nbBlocksPerTask = 16
+	p.Execute(nbIterations, func(start, end int) {
+		blockSize := (end - start + nbBlocksPerTask - 1) / nbBlocksPerTask
+		for i := start; i < end; i += blockSize {
+			if ctx.Err() != nil {
+				return
+			}
+			blockEnd := i + blockSize
+			if blockEnd > end {
+				blockEnd = end
+			}
+			work(i, blockEnd)
+		}
+	}, maxTasks...)
+	return ctx.Err()
+}
+
+// worker runs the tasks of the jobs in round robin, until the pool is stopped
+func (p *Pool) worker() {
+	p.lock.Lock()
+	for {
+		for len(p.jobs) == 0 && !p.stopped {
+			p.cond.Wait()
+		}
+		if len(p.jobs) == 0 {
+			p.lock.Unlock()
+			return
+		}
+		p.run(p.jobs[0])
+	}
+}
+
+// run starts the next task of j, which must have one, and moves j at the end of the queue.
+// p.lock must be held, it is released while the task runs.
+func (p *Pool) run(j *job) {
+	task := j.next
+	j.next++
+
+	// remove j from the queue, and put it back at the end if it has more tasks
+	for i := range p.jobs {
+		if p.jobs[i] == j {
+			p.jobs = append(p.jobs[:i], p.jobs[i+1:]...)
+			break
+		}
+	}
+	if j.next < j.nbTasks {
+		p.jobs = append(p.jobs, j)
+	}
+
+	start := task*j.nbIterationsPerTask + min(task, j.nbExtraIterations)
+	end := start + j.nbIterationsPerTask
+	if task < j.nbExtraIterations {
+		end++
+	}
+
+	p.lock.Unlock()
+	j.work(start, end)
+	j.wg.Done()
+	p.lock.Lock()
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecute(t *testing.T) {
+	p := New(3)
+	defer p.Stop()
+
+	for _, nbIterations := range []int{0, 1, 2, 7, 100, 1001} {
+		for _, maxTasks := range [][]int{nil, {1}, {4}, {64}, {2000}} {
+			counts := make([]int32, nbIterations)
+			p.Execute(nbIterations, func(start, end int) {
+				for i := start; i < end; i++ {
+					atomic.AddInt32(&counts[i], 1)
+				}
+			}, maxTasks...)
+			for i := range counts {
+				if counts[i] != 1 {
+					t.Fatalf("iteration %d of %d processed %d times", i, nbIterations, counts[i])
+				}
+			}
+		}
+	}
+}
+
+func TestExecuteNested(t *testing.T) {
+	// the tasks wait for nested calls, which must not starve with a single worker
+	p := New(1)
+	defer p.Stop()
+
+	var count int32
+	p.Execute(4, func(start, end int) {
+		for i := start; i < end; i++ {
+			p.Execute(4, func(start, end int) {
+				for j := start; j < end; j++ {
+					p.Execute(4, func(start, end int) {
+						atomic.AddInt32(&count, int32(end-start))
+					}, 4)
+				}
+			}, 4)
+		}
+	}, 4)
+	if count != 64 {
+		t.Fatal("nested calls not processed")
+	}
+}
+
+func TestExecuteConcurrency(t *testing.T) {
+	const nbWorkers, nbCallers = 2, 3
+	p := New(nbWorkers)
+	defer p.Stop()
+
+	var running, maxRunning int32
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	wg.Add(nbCallers)
+	for c := 0; c < nbCallers; c++ {
+		go func() {
+			defer wg.Done()
+			p.Execute(64, func(start, end int) {
+				r := atomic.AddInt32(&running, 1)
+				lock.Lock()
+				if r > maxRunning {
+					maxRunning = r
+				}
+				lock.Unlock()
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&running, -1)
+			}, 64)
+		}()
+	}
+	wg.Wait()
+	if maxRunning > nbWorkers+nbCallers {
+		t.Fatalf("%d tasks ran concurrently on %d workers and %d callers", maxRunning, nbWorkers, nbCallers)
+	}
+}
+
+func TestExecuteCtx(t *testing.T) {
+	p := New(2)
+	defer p.Stop()
+
+	var count int32
+	if err := p.ExecuteCtx(context.Background(), 1000, func(start, end int) {
+		atomic.AddInt32(&count, int32(end-start))
+	}); err != nil || count != 1000 {
+		t.Fatal("ExecuteCtx should process all the iterations")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	count = 0
+	if err := p.ExecuteCtx(ctx, 1000, func(start, end int) {
+		atomic.AddInt32(&count, int32(end-start))
+	}); err != context.Canceled || count != 0 {
+		t.Fatal("ExecuteCtx should stop when the context is done")
+	}
+}
+
+func TestStop(t *testing.T) {
+	p := New(2)
+	p.Stop()
+
+	// the caller processes the tasks alone
+	var count int32
+	p.Execute(100, func(start, end int) {
+		atomic.AddInt32(&count, int32(end-start))
+	}, 10)
+	if count != 100 {
+		t.Fatal("stopped pool should still execute the work")
+	}
+}
+
+func TestDefault(t *testing.T) {
+	previous := Default()
+	defer SetDefault(previous)
+
+	p := New(5)
+	defer p.Stop()
+	SetDefault(p)
+	if Default() != p || (*Pool)(nil).NbWorkers() != 5 {
+		t.Fatal("nil pool should use the default pool")
+	}
+}
@@ -75,7 +75,7 @@ type Signer interface {
 
 type SignatureScheme uint
 
-const maxSignatures = 6
+const maxSignatures = 14
 
 const (
 	EDDSA_BN254 SignatureScheme = iota
@@ -84,6 +84,14 @@ const (
 	EDDSA_BW6_761
 	EDDSA_BLS24_315
 	EDDSA_BW6_633
+	BLS_MINPK_BLS12_381
+	BLS_MINSIG_BLS12_381
+	BLS_MINPK_BLS12_377
+	BLS_MINSIG_BLS12_377
+	EDDSA_BLS12_379
+	EDDSA_BW6_764
+	EDDSA_BW6_672
+	EDDSA_CP8_632
 )
 
 var signatures = make([]func(io.Reader) (Signer, error), maxSignatures)